@@ -238,9 +238,10 @@ func TestSupervisorStartsCollectorWithRemoteConfig(t *testing.T) {
 	}, 10*time.Second, 500*time.Millisecond, "Log never appeared in output")
 }
 
-func TestSupervisorRestartsCollectorAfterBadConfig(t *testing.T) {
+func TestSupervisorRejectsBadConfig(t *testing.T) {
 	var healthReport atomic.Value
 	var agentConfig atomic.Value
+	var remoteConfigStatus atomic.Value
 	server := newOpAMPServer(
 		t,
 		defaultConnectingHandler,
@@ -255,6 +256,9 @@ func TestSupervisorRestartsCollectorAfterBadConfig(t *testing.T) {
 						agentConfig.Store(string(config.Body))
 					}
 				}
+				if message.RemoteConfigStatus != nil {
+					remoteConfigStatus.Store(message.RemoteConfigStatus)
+				}
 
 				return &protobufs.ServerToAgent{}
 			},
@@ -279,25 +283,18 @@ func TestSupervisorRestartsCollectorAfterBadConfig(t *testing.T) {
 	})
 
 	require.Eventually(t, func() bool {
-		cfg, ok := agentConfig.Load().(string)
+		status, ok := remoteConfigStatus.Load().(*protobufs.RemoteConfigStatus)
 		if ok {
-			// The effective config may be structurally different compared to what was sent,
-			// so just check that it includes some strings we know to be unique to the remote config.
-			return strings.Contains(cfg, "doesntexist")
+			return bytes.Equal(status.LastRemoteConfigHash, hash) &&
+				status.Status == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED &&
+				status.ErrorMessage != ""
 		}
 
 		return false
-	}, 5*time.Second, 500*time.Millisecond, "Collector was not started with remote config")
-
-	require.Eventually(t, func() bool {
-		health := healthReport.Load().(*protobufs.ComponentHealth)
+	}, 10*time.Second, 250*time.Millisecond, "Supervisor never reported that the remote config failed")
 
-		if health != nil {
-			return !health.Healthy && health.LastError != ""
-		}
-
-		return false
-	}, 5*time.Second, 250*time.Millisecond, "Supervisor never reported that the Collector was unhealthy")
+	cfgStr, _ := agentConfig.Load().(string)
+	require.NotContains(t, cfgStr, "doesntexist", "Collector was started with the invalid remote config")
 
 	cfg, hash, _, _ = createSimplePipelineCollectorConf(t)
 
@@ -313,9 +310,15 @@ func TestSupervisorRestartsCollectorAfterBadConfig(t *testing.T) {
 	})
 
 	require.Eventually(t, func() bool {
-		health := healthReport.Load().(*protobufs.ComponentHealth)
+		status, ok := remoteConfigStatus.Load().(*protobufs.RemoteConfigStatus)
+		return ok && bytes.Equal(status.LastRemoteConfigHash, hash) &&
+			status.Status == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED
+	}, 10*time.Second, 250*time.Millisecond, "Supervisor never reported that the remote config was applied")
 
-		if health != nil {
+	require.Eventually(t, func() bool {
+		health, ok := healthReport.Load().(*protobufs.ComponentHealth)
+
+		if ok && health != nil {
 			return health.Healthy && health.LastError == ""
 		}
 
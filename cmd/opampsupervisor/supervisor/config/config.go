@@ -4,6 +4,9 @@
 package config
 
 import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"net/http"
@@ -23,6 +26,7 @@ type Supervisor struct {
 	Agent        Agent
 	Capabilities Capabilities `mapstructure:"capabilities"`
 	Storage      Storage      `mapstructure:"storage"`
+	Packages     Packages     `mapstructure:"packages"`
 }
 
 func (s Supervisor) Validate() error {
@@ -34,6 +38,12 @@ func (s Supervisor) Validate() error {
 		return err
 	}
 
+	if s.Capabilities.AcceptsPackages {
+		if err := s.Packages.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -51,6 +61,7 @@ type Capabilities struct {
 	ReportsOwnMetrics              bool `mapstructure:"reports_own_metrics"`
 	ReportsHealth                  bool `mapstructure:"reports_health"`
 	ReportsRemoteConfig            bool `mapstructure:"reports_remote_config"`
+	AcceptsPackages                bool `mapstructure:"accepts_packages"`
 }
 
 func (c Capabilities) SupportedCapabilities() protobufs.AgentCapabilities {
@@ -84,6 +95,11 @@ func (c Capabilities) SupportedCapabilities() protobufs.AgentCapabilities {
 		supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_AcceptsOpAMPConnectionSettings
 	}
 
+	if c.AcceptsPackages {
+		supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_AcceptsPackages |
+			protobufs.AgentCapabilities_AgentCapabilities_ReportsPackageStatuses
+	}
+
 	return supportedCapabilities
 }
 
@@ -121,6 +137,9 @@ type Agent struct {
 	Executable              string
 	OrphanDetectionInterval time.Duration    `mapstructure:"orphan_detection_interval"`
 	Description             AgentDescription `mapstructure:"description"`
+	// ConfigFiles is an ordered list of local config files, merged on top of the remote config.
+	// The keys they set can't be overridden by the remote config.
+	ConfigFiles []string `mapstructure:"config_files"`
 }
 
 func (a Agent) Validate() error {
@@ -137,9 +156,75 @@ func (a Agent) Validate() error {
 		return fmt.Errorf("could not stat agent::executable path: %w", err)
 	}
 
+	for _, configFile := range a.ConfigFiles {
+		if _, err := os.Stat(configFile); err != nil {
+			return fmt.Errorf("could not stat agent::config_files path: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// Packages is the configuration of the Collector executable updates, offered
+// by the OpAMP server as the top-level package.
+type Packages struct {
+	// PublicKeyFile is the path to the PEM-encoded Ed25519 public key the
+	// signatures of the packages are verified with. Required, packages without
+	// a valid signature are rejected.
+	PublicKeyFile string `mapstructure:"public_key_file"`
+	// AllowLocalFiles allows the packages to be read from the local file system,
+	// when offered with a file:// download URL.
+	AllowLocalFiles bool `mapstructure:"allow_local_files"`
+	// HealthCheckTimeout is how long the updated Collector has to become healthy
+	// before the update is rolled back.
+	HealthCheckTimeout time.Duration `mapstructure:"health_check_timeout"`
+}
+
+func (p Packages) Validate() error {
+	if p.HealthCheckTimeout <= 0 {
+		return errors.New("packages::health_check_timeout must be positive")
+	}
+
+	if p.PublicKeyFile == "" {
+		return errors.New("packages::public_key_file must be set when accepting packages")
+	}
+	if _, err := p.PublicKey(); err != nil {
+		return fmt.Errorf("invalid packages::public_key_file: %w", err)
+	}
+
+	return nil
+}
+
+// PublicKey loads the public key the package signatures are verified with,
+// or returns nil if none is configured.
+func (p Packages) PublicKey() (ed25519.PublicKey, error) {
+	if p.PublicKeyFile == "" {
+		return nil, nil
+	}
+
+	by, err := os.ReadFile(p.PublicKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(by)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	edKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported public key type %T, must be Ed25519", key)
+	}
+
+	return edKey, nil
+}
+
 type AgentDescription struct {
 	IdentifyingAttributes    map[string]string `mapstructure:"identifying_attributes"`
 	NonIdentifyingAttributes map[string]string `mapstructure:"non_identifying_attributes"`
@@ -169,6 +254,7 @@ func DefaultSupervisor() Supervisor {
 			ReportsOwnMetrics:              true,
 			ReportsHealth:                  true,
 			ReportsRemoteConfig:            false,
+			AcceptsPackages:                false,
 		},
 		Storage: Storage{
 			Directory: defaultStorageDir,
@@ -176,5 +262,8 @@ func DefaultSupervisor() Supervisor {
 		Agent: Agent{
 			OrphanDetectionInterval: 5 * time.Second,
 		},
+		Packages: Packages{
+			HealthCheckTimeout: 30 * time.Second,
+		},
 	}
 }
@@ -4,6 +4,10 @@
 package config
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -223,6 +227,111 @@ func TestValidate(t *testing.T) {
 			},
 			expectedError: "agent::orphan_detection_interval must be positive",
 		},
+		{
+			name: "Missing local config file",
+			config: Supervisor{
+				Server: OpAMPServer{
+					Endpoint: "wss://localhost:9090/opamp",
+					TLSSetting: configtls.ClientConfig{
+						Insecure: true,
+					},
+				},
+				Agent: Agent{
+					Executable:              "${file_path}",
+					OrphanDetectionInterval: 5 * time.Second,
+					ConfigFiles:             []string{"${file_path}", "/does/not/exist.yaml"},
+				},
+				Storage: Storage{
+					Directory: "/etc/opamp-supervisor/storage",
+				},
+			},
+			expectedError: "could not stat agent::config_files path:",
+		},
+		{
+			name: "Invalid packages health check timeout",
+			config: Supervisor{
+				Server: OpAMPServer{
+					Endpoint: "wss://localhost:9090/opamp",
+					Headers: http.Header{
+						"Header1": []string{"HeaderValue"},
+					},
+					TLSSetting: configtls.ClientConfig{
+						Insecure: true,
+					},
+				},
+				Agent: Agent{
+					Executable:              "${file_path}",
+					OrphanDetectionInterval: 5 * time.Second,
+				},
+				Capabilities: Capabilities{
+					AcceptsPackages: true,
+				},
+				Storage: Storage{
+					Directory: "/etc/opamp-supervisor/storage",
+				},
+				Packages: Packages{
+					HealthCheckTimeout: 0,
+				},
+			},
+			expectedError: "packages::health_check_timeout must be positive",
+		},
+		{
+			name: "Missing packages public key file",
+			config: Supervisor{
+				Server: OpAMPServer{
+					Endpoint: "wss://localhost:9090/opamp",
+					Headers: http.Header{
+						"Header1": []string{"HeaderValue"},
+					},
+					TLSSetting: configtls.ClientConfig{
+						Insecure: true,
+					},
+				},
+				Agent: Agent{
+					Executable:              "${file_path}",
+					OrphanDetectionInterval: 5 * time.Second,
+				},
+				Capabilities: Capabilities{
+					AcceptsPackages: true,
+				},
+				Storage: Storage{
+					Directory: "/etc/opamp-supervisor/storage",
+				},
+				Packages: Packages{
+					HealthCheckTimeout: 30 * time.Second,
+				},
+			},
+			expectedError: "packages::public_key_file must be set when accepting packages",
+		},
+		{
+			name: "Invalid packages public key file",
+			config: Supervisor{
+				Server: OpAMPServer{
+					Endpoint: "wss://localhost:9090/opamp",
+					Headers: http.Header{
+						"Header1": []string{"HeaderValue"},
+					},
+					TLSSetting: configtls.ClientConfig{
+						Insecure: true,
+					},
+				},
+				Agent: Agent{
+					Executable:              "${file_path}",
+					OrphanDetectionInterval: 5 * time.Second,
+				},
+				Capabilities: Capabilities{
+					AcceptsPackages: true,
+				},
+				Storage: Storage{
+					Directory: "/etc/opamp-supervisor/storage",
+				},
+				Packages: Packages{
+					PublicKeyFile:      "${file_path}",
+					HealthCheckTimeout: 30 * time.Second,
+				},
+			},
+			expectedError: "invalid packages::public_key_file: no PEM block found",
+		},
 	}
 
 	// create some fake files for validating agent config
@@ -241,6 +350,22 @@ func TestValidate(t *testing.T) {
 					}
 					return ""
 				})
+			for i, configFile := range tc.config.Agent.ConfigFiles {
+				tc.config.Agent.ConfigFiles[i] = os.Expand(configFile,
+					func(s string) string {
+						if s == "file_path" {
+							return filePath
+						}
+						return ""
+					})
+			}
+			tc.config.Packages.PublicKeyFile = os.Expand(tc.config.Packages.PublicKeyFile,
+				func(s string) string {
+					if s == "file_path" {
+						return filePath
+					}
+					return ""
+				})
 
 			err := tc.config.Validate()
 
@@ -282,6 +407,7 @@ func TestCapabilities_SupportedCapabilities(t *testing.T) {
 				ReportsOwnMetrics:              true,
 				ReportsHealth:                  true,
 				ReportsRemoteConfig:            true,
+				AcceptsPackages:                true,
 			},
 			expectedAgentCapabilities: protobufs.AgentCapabilities_AgentCapabilities_ReportsStatus |
 				protobufs.AgentCapabilities_AgentCapabilities_ReportsEffectiveConfig |
@@ -290,7 +416,9 @@ func TestCapabilities_SupportedCapabilities(t *testing.T) {
 				protobufs.AgentCapabilities_AgentCapabilities_AcceptsRemoteConfig |
 				protobufs.AgentCapabilities_AgentCapabilities_ReportsRemoteConfig |
 				protobufs.AgentCapabilities_AgentCapabilities_AcceptsRestartCommand |
-				protobufs.AgentCapabilities_AgentCapabilities_AcceptsOpAMPConnectionSettings,
+				protobufs.AgentCapabilities_AgentCapabilities_AcceptsOpAMPConnectionSettings |
+				protobufs.AgentCapabilities_AgentCapabilities_AcceptsPackages |
+				protobufs.AgentCapabilities_AgentCapabilities_ReportsPackageStatuses,
 		},
 	}
 
@@ -300,3 +428,24 @@ func TestCapabilities_SupportedCapabilities(t *testing.T) {
 		})
 	}
 }
+
+func TestPackages_PublicKey(t *testing.T) {
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+
+	keyFile := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+
+	packages := Packages{PublicKeyFile: keyFile, HealthCheckTimeout: 30 * time.Second}
+	require.NoError(t, packages.Validate())
+
+	key, err := packages.PublicKey()
+	require.NoError(t, err)
+	require.Equal(t, publicKey, key)
+
+	key, err = Packages{}.PublicKey()
+	require.NoError(t, err)
+	require.Nil(t, key)
+}
@@ -0,0 +1,440 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	packagesStateFilePath = "packages_state.yaml"
+
+	stagedExecutableSuffix = ".staged"
+	backupExecutableSuffix = ".bak"
+)
+
+var _ types.PackagesStateProvider = (*packageManager)(nil)
+
+// packagesState represents the persistent state of the packages installed by the supervisor
+type packagesState struct {
+	AllPackagesHash []byte                   `yaml:"all_packages_hash"`
+	Packages        map[string]*packageState `yaml:"packages"`
+	// Proto-encoded PackageStatuses last reported to the server.
+	LastReportedStatuses []byte `yaml:"last_reported_statuses"`
+	// Hex-encoded hashes of the packages whose installation failed, mapped to the failure reason.
+	// They are not installed again even if offered by the server.
+	FailedHashes map[string]string `yaml:"failed_hashes"`
+}
+
+type packageState struct {
+	Type        protobufs.PackageType `yaml:"type"`
+	Hash        []byte                `yaml:"hash"`
+	Version     string                `yaml:"version"`
+	ContentHash []byte                `yaml:"content_hash"`
+}
+
+// stagedPackage is a verified package, ready to be swapped with the agent executable
+type stagedPackage struct {
+	name    string
+	path    string
+	offered *protobufs.PackageAvailable
+	// Statuses of the available packages, reported as the installation progresses.
+	statuses *protobufs.PackageStatuses
+}
+
+// packageManager downloads, verifies and installs the Collector executable updates offered
+// by the OpAMP server. It keeps track of the installed packages on disk, and implements the
+// PackagesStateProvider used by the OpAMP client to report the package statuses.
+type packageManager struct {
+	stateFile       string
+	executable      string
+	publicKey       ed25519.PublicKey
+	allowLocalFiles bool
+	httpClient      *http.Client
+
+	mu    sync.Mutex
+	state *packagesState
+}
+
+func newPackageManager(stateFile, executable string, publicKey ed25519.PublicKey, allowLocalFiles bool) (*packageManager, error) {
+	state := &packagesState{}
+	by, err := os.ReadFile(stateFile)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, err
+	default:
+		if err = yaml.Unmarshal(by, state); err != nil {
+			return nil, fmt.Errorf("cannot parse %s: %w", stateFile, err)
+		}
+	}
+	if state.Packages == nil {
+		state.Packages = map[string]*packageState{}
+	}
+	if state.FailedHashes == nil {
+		state.FailedHashes = map[string]string{}
+	}
+
+	p := &packageManager{
+		stateFile:       stateFile,
+		executable:      executable,
+		publicKey:       publicKey,
+		allowLocalFiles: allowLocalFiles,
+		httpClient:      http.DefaultClient,
+		state:           state,
+	}
+	if err = p.recoverInterruptedUpdate(); err != nil {
+		return nil, fmt.Errorf("cannot recover from an interrupted update: %w", err)
+	}
+	return p, nil
+}
+
+// recoverInterruptedUpdate restores the agent executable backed up by an update that was
+// neither committed nor rolled back, e.g. because the supervisor crashed in the meantime.
+// The new executable was not verified to be healthy, so the previous one is restored.
+func (p *packageManager) recoverInterruptedUpdate() error {
+	backup := p.executable + backupExecutableSuffix
+	if _, err := os.Stat(backup); err == nil {
+		if err = os.Rename(backup, p.executable); err != nil {
+			return err
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if err := os.Remove(p.executable + stagedExecutableSuffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Must be called holding mu.
+func (p *packageManager) writeState() error {
+	by, err := yaml.Marshal(p.state)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(p.stateFile, by, 0600)
+}
+
+func (p *packageManager) AllPackagesHash() ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.state.AllPackagesHash, nil
+}
+
+func (p *packageManager) SetAllPackagesHash(hash []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.state.AllPackagesHash = hash
+	return p.writeState()
+}
+
+func (p *packageManager) Packages() ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	names := make([]string, 0, len(p.state.Packages))
+	for name := range p.state.Packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (p *packageManager) PackageState(packageName string) (types.PackageState, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pkg, ok := p.state.Packages[packageName]
+	if !ok {
+		return types.PackageState{}, nil
+	}
+	return types.PackageState{
+		Exists:  true,
+		Type:    pkg.Type,
+		Hash:    pkg.Hash,
+		Version: pkg.Version,
+	}, nil
+}
+
+func (p *packageManager) SetPackageState(packageName string, state types.PackageState) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !state.Exists {
+		delete(p.state.Packages, packageName)
+		return p.writeState()
+	}
+	pkg, ok := p.state.Packages[packageName]
+	if !ok {
+		pkg = &packageState{}
+		p.state.Packages[packageName] = pkg
+	}
+	pkg.Type = state.Type
+	pkg.Hash = state.Hash
+	pkg.Version = state.Version
+	return p.writeState()
+}
+
+func (p *packageManager) CreatePackage(packageName string, typ protobufs.PackageType) error {
+	if typ != protobufs.PackageType_PackageType_TopLevel {
+		return errors.New("only the top-level package is supported")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.state.Packages[packageName] = &packageState{Type: typ}
+	return p.writeState()
+}
+
+func (p *packageManager) FileContentHash(packageName string) ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pkg, ok := p.state.Packages[packageName]
+	if !ok {
+		return nil, nil
+	}
+	return pkg.ContentHash, nil
+}
+
+// UpdateContent stages the content of the package, the agent executable being swapped
+// by the supervisor once it is able to restart the agent.
+func (p *packageManager) UpdateContent(_ context.Context, _ string, data io.Reader, contentHash []byte) error {
+	_, err := p.writeStaged(data, contentHash, nil)
+	return err
+}
+
+func (p *packageManager) DeletePackage(packageName string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.state.Packages, packageName)
+	return p.writeState()
+}
+
+func (p *packageManager) LastReportedStatuses() (*protobufs.PackageStatuses, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	statuses := &protobufs.PackageStatuses{}
+	if len(p.state.LastReportedStatuses) == 0 {
+		return statuses, nil
+	}
+	if err := proto.Unmarshal(p.state.LastReportedStatuses, statuses); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+func (p *packageManager) SetLastReportedStatuses(statuses *protobufs.PackageStatuses) error {
+	by, err := proto.Marshal(statuses)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.state.LastReportedStatuses = by
+	return p.writeState()
+}
+
+// failureReason returns why the installation of the package with the given hash failed
+// previously, if it did.
+func (p *packageManager) failureReason(hash []byte) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	reason, ok := p.state.FailedHashes[hex.EncodeToString(hash)]
+	return reason, ok
+}
+
+func (p *packageManager) markFailed(hash []byte, reason string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.state.FailedHashes[hex.EncodeToString(hash)] = reason
+	return p.writeState()
+}
+
+// stage reads the offered package file, verifies its hash and signature, and writes it next to
+// the agent executable so that it can be swapped atomically.
+func (p *packageManager) stage(ctx context.Context, name string, offered *protobufs.PackageAvailable) (*stagedPackage, error) {
+	file := offered.GetFile()
+	if file == nil {
+		return nil, errors.New("the package has no file")
+	}
+
+	r, err := p.openFile(ctx, file.DownloadUrl)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	path, err := p.writeStaged(r, file.ContentHash, file.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stagedPackage{
+		name:    name,
+		path:    path,
+		offered: offered,
+	}, nil
+}
+
+func (p *packageManager) openFile(ctx context.Context, downloadURL string) (io.ReadCloser, error) {
+	u, err := url.Parse(downloadURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid download URL: %w", err)
+	}
+
+	switch u.Scheme {
+	case "file":
+		if !p.allowLocalFiles {
+			return nil, errors.New("local package files are not allowed")
+		}
+		return os.Open(u.Path)
+	case "http", "https":
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := p.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("cannot download the package: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("cannot download the package: server returned %d", resp.StatusCode)
+		}
+		return resp.Body, nil
+	default:
+		return nil, fmt.Errorf("unsupported download URL scheme %q", u.Scheme)
+	}
+}
+
+// writeStaged writes the content to the staged executable, and verifies that it has the expected
+// SHA-256 hash, and that the signature is the Ed25519 signature of the hash. The content hash is
+// offered by the same server as the package, so packages are rejected if no public key is configured.
+func (p *packageManager) writeStaged(r io.Reader, contentHash, signature []byte) (path string, err error) {
+	if len(contentHash) == 0 {
+		return "", errors.New("the package has no content hash")
+	}
+	if p.publicKey == nil {
+		return "", errors.New("no public key to verify the package signature with")
+	}
+	if !ed25519.Verify(p.publicKey, contentHash, signature) {
+		return "", errors.New("invalid package signature")
+	}
+
+	path = p.executable + stagedExecutableSuffix
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0700)
+	if err != nil {
+		return "", fmt.Errorf("cannot create the staged executable: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(path)
+		}
+	}()
+
+	hasher := sha256.New()
+	_, err = io.Copy(io.MultiWriter(f, hasher), r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot write the staged executable: %w", err)
+	}
+
+	if actual := hasher.Sum(nil); !bytes.Equal(actual, contentHash) {
+		return "", fmt.Errorf("package content hash mismatch: expected %x, got %x", contentHash, actual)
+	}
+
+	return path, nil
+}
+
+// swap replaces the agent executable with the staged one, keeping the current executable
+// as a backup for rolling back. The executable is replaced with a single rename, so that
+// there is always an agent executable even if the supervisor crashes during the swap.
+func (p *packageManager) swap(staged *stagedPackage) error {
+	backup := p.executable + backupExecutableSuffix
+	if err := os.Remove(backup); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("cannot remove the previous backup of the agent executable: %w", err)
+	}
+	if err := linkOrCopy(p.executable, backup); err != nil {
+		return fmt.Errorf("cannot back up the agent executable: %w", err)
+	}
+	if err := os.Rename(staged.path, p.executable); err != nil {
+		if removeErr := os.Remove(backup); removeErr != nil {
+			err = errors.Join(err, removeErr)
+		}
+		return fmt.Errorf("cannot replace the agent executable: %w", err)
+	}
+	return nil
+}
+
+// linkOrCopy creates a hard link to the file, or copies it if hard links are not supported.
+func linkOrCopy(src, dst string) (err error) {
+	if os.Link(src, dst) == nil {
+		return nil
+	}
+
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(dst)
+		}
+	}()
+	_, err = io.Copy(out, in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// rollback restores the agent executable backed up by swap.
+func (p *packageManager) rollback() error {
+	return os.Rename(p.executable+backupExecutableSuffix, p.executable)
+}
+
+// commit records the staged package as installed, and removes the backup of the previous executable.
+func (p *packageManager) commit(staged *stagedPackage) error {
+	if err := os.Remove(p.executable + backupExecutableSuffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.state.Packages[staged.name] = &packageState{
+		Type:        staged.offered.Type,
+		Hash:        staged.offered.Hash,
+		Version:     staged.offered.Version,
+		ContentHash: staged.offered.GetFile().GetContentHash(),
+	}
+	return p.writeState()
+}
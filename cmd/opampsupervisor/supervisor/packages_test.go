@@ -0,0 +1,320 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPublicKey and testPrivateKey are the key pair the test packages are signed with.
+var testPublicKey, testPrivateKey, _ = ed25519.GenerateKey(rand.Reader)
+
+func newTestPackageManager(t *testing.T, publicKey ed25519.PublicKey, allowLocalFiles bool) *packageManager {
+	dir := t.TempDir()
+	executable := filepath.Join(dir, "otelcol")
+	require.NoError(t, os.WriteFile(executable, []byte("old collector"), 0600))
+
+	p, err := newPackageManager(filepath.Join(dir, packagesStateFilePath), executable, publicKey, allowLocalFiles)
+	require.NoError(t, err)
+	return p
+}
+
+func writePackageFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "package")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+func TestPackageManagerStage(t *testing.T) {
+	content := "new collector"
+	contentHash := sha256.Sum256([]byte(content))
+
+	testCases := []struct {
+		name            string
+		publicKey       ed25519.PublicKey
+		allowLocalFiles bool
+		file            *protobufs.DownloadableFile
+		expectedError   string
+	}{
+		{
+			name:            "Valid package",
+			publicKey:       testPublicKey,
+			allowLocalFiles: true,
+			file: &protobufs.DownloadableFile{
+				DownloadUrl: writePackageFile(t, content),
+				ContentHash: contentHash[:],
+				Signature:   ed25519.Sign(testPrivateKey, contentHash[:]),
+			},
+		},
+		{
+			name:            "No public key",
+			allowLocalFiles: true,
+			file: &protobufs.DownloadableFile{
+				DownloadUrl: writePackageFile(t, content),
+				ContentHash: contentHash[:],
+				Signature:   ed25519.Sign(testPrivateKey, contentHash[:]),
+			},
+			expectedError: "no public key to verify the package signature with",
+		},
+		{
+			name:            "Missing signature",
+			publicKey:       testPublicKey,
+			allowLocalFiles: true,
+			file: &protobufs.DownloadableFile{
+				DownloadUrl: writePackageFile(t, content),
+				ContentHash: contentHash[:],
+			},
+			expectedError: "invalid package signature",
+		},
+		{
+			name:            "Invalid signature",
+			publicKey:       testPublicKey,
+			allowLocalFiles: true,
+			file: &protobufs.DownloadableFile{
+				DownloadUrl: writePackageFile(t, content),
+				ContentHash: contentHash[:],
+				Signature:   []byte("not a signature"),
+			},
+			expectedError: "invalid package signature",
+		},
+		{
+			name:            "Hash mismatch",
+			publicKey:       testPublicKey,
+			allowLocalFiles: true,
+			file: &protobufs.DownloadableFile{
+				DownloadUrl: writePackageFile(t, "tampered collector"),
+				ContentHash: contentHash[:],
+				Signature:   ed25519.Sign(testPrivateKey, contentHash[:]),
+			},
+			expectedError: "package content hash mismatch",
+		},
+		{
+			name:            "Missing hash",
+			publicKey:       testPublicKey,
+			allowLocalFiles: true,
+			file: &protobufs.DownloadableFile{
+				DownloadUrl: writePackageFile(t, content),
+			},
+			expectedError: "the package has no content hash",
+		},
+		{
+			name:      "Local files not allowed",
+			publicKey: testPublicKey,
+			file: &protobufs.DownloadableFile{
+				DownloadUrl: writePackageFile(t, content),
+				ContentHash: contentHash[:],
+			},
+			expectedError: "local package files are not allowed",
+		},
+		{
+			name:      "Unsupported scheme",
+			publicKey: testPublicKey,
+			file: &protobufs.DownloadableFile{
+				DownloadUrl: "ftp://example.com/otelcol",
+				ContentHash: contentHash[:],
+			},
+			expectedError: `unsupported download URL scheme "ftp"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := newTestPackageManager(t, tc.publicKey, tc.allowLocalFiles)
+
+			staged, err := p.stage(context.Background(), "", &protobufs.PackageAvailable{
+				Type: protobufs.PackageType_PackageType_TopLevel,
+				File: tc.file,
+			})
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				assert.NoFileExists(t, p.executable+stagedExecutableSuffix)
+				return
+			}
+
+			require.NoError(t, err)
+			by, err := os.ReadFile(staged.path)
+			require.NoError(t, err)
+			assert.Equal(t, content, string(by))
+		})
+	}
+}
+
+func TestPackageManagerStageHTTP(t *testing.T) {
+	content := "new collector"
+	contentHash := sha256.Sum256([]byte(content))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/otelcol" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	defer srv.Close()
+
+	p := newTestPackageManager(t, testPublicKey, false)
+	signature := ed25519.Sign(testPrivateKey, contentHash[:])
+
+	staged, err := p.stage(context.Background(), "", &protobufs.PackageAvailable{
+		File: &protobufs.DownloadableFile{DownloadUrl: srv.URL + "/otelcol", ContentHash: contentHash[:], Signature: signature},
+	})
+	require.NoError(t, err)
+	by, err := os.ReadFile(staged.path)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(by))
+
+	_, err = p.stage(context.Background(), "", &protobufs.PackageAvailable{
+		File: &protobufs.DownloadableFile{DownloadUrl: srv.URL + "/missing", ContentHash: contentHash[:], Signature: signature},
+	})
+	require.ErrorContains(t, err, "server returned 404")
+}
+
+func TestPackageManagerSwap(t *testing.T) {
+	content := "new collector"
+	contentHash := sha256.Sum256([]byte(content))
+	offered := &protobufs.PackageAvailable{
+		Type:    protobufs.PackageType_PackageType_TopLevel,
+		Version: "1.2.3",
+		Hash:    []byte("package hash"),
+		File: &protobufs.DownloadableFile{
+			DownloadUrl: writePackageFile(t, content),
+			ContentHash: contentHash[:],
+			Signature:   ed25519.Sign(testPrivateKey, contentHash[:]),
+		},
+	}
+
+	t.Run("Commit", func(t *testing.T) {
+		p := newTestPackageManager(t, testPublicKey, true)
+		staged, err := p.stage(context.Background(), "collector", offered)
+		require.NoError(t, err)
+
+		require.NoError(t, p.swap(staged))
+		requireFileContent(t, p.executable, content)
+		requireFileContent(t, p.executable+backupExecutableSuffix, "old collector")
+
+		require.NoError(t, p.commit(staged))
+		assert.NoFileExists(t, p.executable+backupExecutableSuffix)
+
+		state, err := p.PackageState("collector")
+		require.NoError(t, err)
+		assert.True(t, state.Exists)
+		assert.Equal(t, "1.2.3", state.Version)
+		assert.Equal(t, []byte("package hash"), state.Hash)
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		p := newTestPackageManager(t, testPublicKey, true)
+		staged, err := p.stage(context.Background(), "collector", offered)
+		require.NoError(t, err)
+
+		require.NoError(t, p.swap(staged))
+		require.NoError(t, p.rollback())
+		requireFileContent(t, p.executable, "old collector")
+		assert.NoFileExists(t, p.executable+backupExecutableSuffix)
+
+		require.NoError(t, p.markFailed(offered.Hash, "not healthy"))
+		reason, failed := p.failureReason(offered.Hash)
+		assert.True(t, failed)
+		assert.Equal(t, "not healthy", reason)
+
+		state, err := p.PackageState("collector")
+		require.NoError(t, err)
+		assert.False(t, state.Exists)
+	})
+
+	t.Run("Interrupted", func(t *testing.T) {
+		p := newTestPackageManager(t, testPublicKey, true)
+		staged, err := p.stage(context.Background(), "collector", offered)
+		require.NoError(t, err)
+		require.NoError(t, p.swap(staged))
+
+		// The supervisor crashed before committing or rolling back the update.
+		reloaded, err := newPackageManager(p.stateFile, p.executable, testPublicKey, true)
+		require.NoError(t, err)
+		requireFileContent(t, reloaded.executable, "old collector")
+		assert.NoFileExists(t, reloaded.executable+backupExecutableSuffix)
+
+		state, err := reloaded.PackageState("collector")
+		require.NoError(t, err)
+		assert.False(t, state.Exists)
+	})
+
+	t.Run("Leftover staged executable", func(t *testing.T) {
+		p := newTestPackageManager(t, testPublicKey, true)
+		_, err := p.stage(context.Background(), "collector", offered)
+		require.NoError(t, err)
+
+		reloaded, err := newPackageManager(p.stateFile, p.executable, testPublicKey, true)
+		require.NoError(t, err)
+		requireFileContent(t, reloaded.executable, "old collector")
+		assert.NoFileExists(t, reloaded.executable+stagedExecutableSuffix)
+	})
+}
+
+func TestLinkOrCopy(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src")
+	require.NoError(t, os.WriteFile(src, []byte("collector"), 0700))
+
+	require.NoError(t, linkOrCopy(src, filepath.Join(dir, "dst")))
+	requireFileContent(t, filepath.Join(dir, "dst"), "collector")
+
+	// Replacing the source doesn't change the backup.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "new"), []byte("new collector"), 0700))
+	require.NoError(t, os.Rename(filepath.Join(dir, "new"), src))
+	requireFileContent(t, filepath.Join(dir, "dst"), "collector")
+}
+
+func TestPackageManagerPersistence(t *testing.T) {
+	p := newTestPackageManager(t, nil, false)
+
+	statuses := &protobufs.PackageStatuses{
+		ServerProvidedAllPackagesHash: []byte("all packages hash"),
+		Packages: map[string]*protobufs.PackageStatus{
+			"collector": {
+				Name:                 "collector",
+				ServerOfferedVersion: "1.2.3",
+				Status:               protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed,
+				ErrorMessage:         "not healthy",
+			},
+		},
+	}
+	require.NoError(t, p.SetAllPackagesHash([]byte("all packages hash")))
+	require.NoError(t, p.SetLastReportedStatuses(statuses))
+	require.NoError(t, p.markFailed([]byte("package hash"), "not healthy"))
+
+	reloaded, err := newPackageManager(p.stateFile, p.executable, nil, false)
+	require.NoError(t, err)
+
+	hash, err := reloaded.AllPackagesHash()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("all packages hash"), hash)
+
+	lastStatuses, err := reloaded.LastReportedStatuses()
+	require.NoError(t, err)
+	assert.Equal(t, "not healthy", lastStatuses.Packages["collector"].ErrorMessage)
+	assert.Equal(t, protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed, lastStatuses.Packages["collector"].Status)
+
+	_, failed := reloaded.failureReason([]byte("package hash"))
+	assert.True(t, failed)
+}
+
+func requireFileContent(t *testing.T, path, expected string) {
+	by, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, expected, string(by))
+}
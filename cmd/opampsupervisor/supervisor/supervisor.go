@@ -12,8 +12,11 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
 	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"text/template"
@@ -59,6 +62,8 @@ var (
 	lastRecvOwnMetricsConfigFile = "last_recv_own_metrics_config.dat"
 )
 
+const configValidationTimeout = 30 * time.Second
+
 const (
 	persistentStateFilePath = "persistent_state.yaml"
 	agentConfigFilePath     = "effective.yaml"
@@ -129,6 +134,12 @@ type Supervisor struct {
 	// The OpAMP server to communicate with the Collector's OpAMP extension
 	opampServer     server.OpAMPServer
 	opampServerPort int
+
+	// Installs the Collector executable updates, if packages are accepted.
+	packageManager *packageManager
+	// A channel to indicate there is a staged package to install.
+	hasNewPackage           chan *stagedPackage
+	packageUpdateInProgress atomic.Bool
 }
 
 func NewSupervisor(logger *zap.Logger, configFile string) (*Supervisor, error) {
@@ -136,6 +147,7 @@ func NewSupervisor(logger *zap.Logger, configFile string) (*Supervisor, error) {
 		logger:                       logger,
 		pidProvider:                  defaultPIDProvider{},
 		hasNewConfig:                 make(chan struct{}, 1),
+		hasNewPackage:                make(chan *stagedPackage, 1),
 		agentConfigOwnMetricsSection: &atomic.Value{},
 		mergedConfig:                 &atomic.Value{},
 		connectedToOpAMPServer:       make(chan struct{}),
@@ -165,6 +177,23 @@ func NewSupervisor(logger *zap.Logger, configFile string) (*Supervisor, error) {
 		return nil, err
 	}
 
+	if s.config.Capabilities.AcceptsPackages {
+		publicKey, err := s.config.Packages.PublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("error loading the packages public key: %w", err)
+		}
+
+		s.packageManager, err = newPackageManager(
+			filepath.Join(s.config.Storage.Directory, packagesStateFilePath),
+			s.config.Agent.Executable,
+			publicKey,
+			s.config.Packages.AllowLocalFiles,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error loading the packages state: %w", err)
+		}
+	}
+
 	if err = s.getBootstrapInfo(); err != nil {
 		return nil, fmt.Errorf("could not get bootstrap info from the Collector: %w", err)
 	}
@@ -418,6 +447,9 @@ func (s *Supervisor) startOpAMPClient() error {
 		},
 		Capabilities: s.config.Capabilities.SupportedCapabilities(),
 	}
+	if s.packageManager != nil {
+		settings.PackagesStateProvider = s.packageManager
+	}
 	ad := s.agentDescription.Load().(*protobufs.AgentDescription)
 	if err = s.opampClient.SetAgentDescription(ad); err != nil {
 		return err
@@ -722,18 +754,22 @@ func (s *Supervisor) loadInitialMergedConfig() error {
 			err = proto.Unmarshal(lastRecvOwnMetricsConfig, set)
 			if err != nil {
 				s.logger.Error("Cannot parse last received own metrics config", zap.Error(err))
+			} else if section, err := s.ownMetricsSection(set); err != nil {
+				s.logger.Error("Could not setup own metrics", zap.Error(err))
 			} else {
-				s.setupOwnMetrics(context.Background(), set)
+				// The merged config is composed with it below.
+				s.agentConfigOwnMetricsSection.Store(section)
 			}
 		}
 	} else {
 		s.logger.Debug("Own metrics is not supported, will not attempt to load config from file")
 	}
 
-	_, err = s.composeMergedConfig(s.remoteConfig)
+	mergedConfig, err := s.composeMergedConfig(s.remoteConfig)
 	if err != nil {
 		return fmt.Errorf("could not compose initial merged config: %w", err)
 	}
+	s.storeMergedConfig(mergedConfig)
 
 	return nil
 }
@@ -761,50 +797,64 @@ func (s *Supervisor) createEffectiveConfigMsg() *protobufs.EffectiveConfig {
 }
 
 func (s *Supervisor) setupOwnMetrics(_ context.Context, settings *protobufs.TelemetryConnectionSettings) (configChanged bool) {
-	var cfg bytes.Buffer
-	if settings.DestinationEndpoint == "" {
-		// No destination. Disable metric collection.
-		s.logger.Debug("Disabling own metrics pipeline in the config")
-	} else {
-		s.logger.Debug("Enabling own metrics pipeline in the config")
-
-		port, err := s.findRandomPort()
+	section, err := s.ownMetricsSection(settings)
+	if err != nil {
+		s.logger.Error("Could not setup own metrics", zap.Error(err))
+		return false
+	}
+	prevSection := s.agentConfigOwnMetricsSection.Load()
+	s.agentConfigOwnMetricsSection.Store(section)
 
-		if err != nil {
-			s.logger.Error("Could not setup own metrics", zap.Error(err))
-			return
+	// Need to recalculate the Agent config so that the metric config is included in it.
+	configChanged, err = s.validateAndStoreMergedConfig(s.remoteConfig)
+	if err != nil {
+		s.logger.Error("Error composing merged config for own metrics. Ignoring agent self metrics config", zap.Error(err))
+		if prevSection == nil {
+			prevSection = ""
 		}
+		s.agentConfigOwnMetricsSection.Store(prevSection)
+		return false
+	}
 
-		err = s.ownTelemetryTemplate.Execute(
-			&cfg,
-			map[string]any{
-				"PrometheusPort":  port,
-				"MetricsEndpoint": settings.DestinationEndpoint,
-			},
-		)
-		if err != nil {
-			s.logger.Error("Could not setup own metrics", zap.Error(err))
-			return
-		}
+	return configChanged
+}
 
+// ownMetricsSection returns the config section of the own metrics pipeline, which is empty when
+// the settings have no destination.
+func (s *Supervisor) ownMetricsSection(settings *protobufs.TelemetryConnectionSettings) (string, error) {
+	var cfg bytes.Buffer
+	if settings.DestinationEndpoint == "" {
+		// No destination. Disable metric collection.
+		s.logger.Debug("Disabling own metrics pipeline in the config")
+		return "", nil
 	}
-	s.agentConfigOwnMetricsSection.Store(cfg.String())
+	s.logger.Debug("Enabling own metrics pipeline in the config")
 
-	// Need to recalculate the Agent config so that the metric config is included in it.
-	configChanged, err := s.composeMergedConfig(s.remoteConfig)
+	port, err := s.findRandomPort()
 	if err != nil {
-		s.logger.Error("Error composing merged config for own metrics. Ignoring agent self metrics config", zap.Error(err))
-		return
+		return "", err
 	}
 
-	return configChanged
+	err = s.ownTelemetryTemplate.Execute(
+		&cfg,
+		map[string]any{
+			"PrometheusPort":  port,
+			"MetricsEndpoint": settings.DestinationEndpoint,
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	return cfg.String(), nil
 }
 
 // composeMergedConfig composes the merged config from multiple sources:
 // 1) the remote config from OpAMP Server
-// 2) the own metrics config section
-// 3) the local override config that is hard-coded in the Supervisor.
-func (s *Supervisor) composeMergedConfig(config *protobufs.AgentRemoteConfig) (configChanged bool, err error) {
+// 2) the local config files, whose keys can't be overridden by the remote config
+// 3) the own metrics config section
+// 4) the local override config that is hard-coded in the Supervisor.
+// The merged config is returned without being stored, so that it can be validated first.
+func (s *Supervisor) composeMergedConfig(config *protobufs.AgentRemoteConfig) (mergedConfig string, err error) {
 	var k = koanf.New("::")
 
 	if c := config.GetConfig(); c != nil {
@@ -832,48 +882,143 @@ func (s *Supervisor) composeMergedConfig(config *protobufs.AgentRemoteConfig) (c
 			var k2 = koanf.New("::")
 			err = k2.Load(rawbytes.Provider(item.Body), yaml.Parser())
 			if err != nil {
-				return false, fmt.Errorf("cannot parse config named %s: %w", name, err)
+				return "", fmt.Errorf("cannot parse config named %s: %w", name, err)
 			}
 			err = k.Merge(k2)
 			if err != nil {
-				return false, fmt.Errorf("cannot merge config named %s: %w", name, err)
+				return "", fmt.Errorf("cannot merge config named %s: %w", name, err)
 			}
 		}
 	}
 
+	// Merge the local config files over the remote config.
+	local, err := s.loadLocalConfig()
+	if err != nil {
+		return "", err
+	}
+	if keys := overriddenKeys(k, local); len(keys) > 0 {
+		s.logger.Warn("The remote config sets keys of the local config files, keeping the local values", zap.Strings("keys", keys))
+	}
+	if err = k.Merge(local); err != nil {
+		return "", fmt.Errorf("cannot merge local config files: %w", err)
+	}
+
 	// Merge own metrics config.
 	ownMetricsCfg, ok := s.agentConfigOwnMetricsSection.Load().(string)
 	if ok {
 		if err = k.Load(rawbytes.Provider([]byte(ownMetricsCfg)), yaml.Parser(), koanf.WithMergeFunc(configMergeFunc)); err != nil {
-			return false, err
+			return "", err
 		}
 	}
 
 	// Merge local config last since it has the highest precedence.
 	if err = k.Load(rawbytes.Provider(s.composeExtraLocalConfig()), yaml.Parser(), koanf.WithMergeFunc(configMergeFunc)); err != nil {
-		return false, err
+		return "", err
 	}
 
 	if err = k.Load(rawbytes.Provider(s.composeOpAMPExtensionConfig()), yaml.Parser(), koanf.WithMergeFunc(configMergeFunc)); err != nil {
-		return false, err
+		return "", err
 	}
 
 	// The merged final result is our new merged config.
 	newMergedConfigBytes, err := k.Marshal(yaml.Parser())
 	if err != nil {
-		return false, err
+		return "", err
 	}
 
-	// Check if supervisor's merged config is changed.
-	newMergedConfig := string(newMergedConfigBytes)
-	configChanged = false
+	return string(newMergedConfigBytes), nil
+}
 
-	oldConfig := s.mergedConfig.Swap(newMergedConfig)
-	if oldConfig == nil || oldConfig.(string) != newMergedConfig {
+// storeMergedConfig stores the merged config, and returns whether it changed.
+func (s *Supervisor) storeMergedConfig(mergedConfig string) (configChanged bool) {
+	oldConfig := s.mergedConfig.Swap(mergedConfig)
+	if oldConfig == nil || oldConfig.(string) != mergedConfig {
 		s.logger.Debug("Merged config changed.")
 		configChanged = true
 	}
+	return configChanged
+}
+
+// validateAndStoreMergedConfig composes the merged config and validates it when it changed. The merged
+// config is only stored once it is valid, so that an invalid config is never written for the agent.
+func (s *Supervisor) validateAndStoreMergedConfig(config *protobufs.AgentRemoteConfig) (configChanged bool, err error) {
+	mergedConfig, err := s.composeMergedConfig(config)
+	if err != nil {
+		return false, err
+	}
+	if prev, ok := s.mergedConfig.Load().(string); ok && prev == mergedConfig {
+		return false, nil
+	}
+	if err = s.validateConfig(mergedConfig); err != nil {
+		return false, err
+	}
+	return s.storeMergedConfig(mergedConfig), nil
+}
+
+// loadLocalConfig merges the local config files in order, the keys of a file overriding the ones of the previous files.
+func (s *Supervisor) loadLocalConfig() (*koanf.Koanf, error) {
+	var k = koanf.New("::")
+	for _, configFile := range s.config.Agent.ConfigFiles {
+		if err := k.Load(file.Provider(configFile), yaml.Parser()); err != nil {
+			return nil, fmt.Errorf("cannot load local config file %s: %w", configFile, err)
+		}
+	}
+	return k, nil
+}
+
+// overriddenKeys returns the keys of the local config that the remote config sets to a different value.
+func overriddenKeys(remote, local *koanf.Koanf) []string {
+	var keys []string
+	for _, key := range local.Keys() {
+		if remote.Exists(key) && !reflect.DeepEqual(remote.Get(key), local.Get(key)) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// validateConfig runs the validate command of the agent on the config, so that an invalid config
+// is rejected before the agent is restarted with it.
+func (s *Supervisor) validateConfig(cfg string) error {
+	f, err := os.CreateTemp(s.config.Storage.Directory, "validate-*.yaml")
+	if err != nil {
+		return fmt.Errorf("cannot write the config to validate: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString(cfg)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("cannot write the config to validate: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), configValidationTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, s.config.Agent.Executable, "validate", "--config", f.Name()).CombinedOutput() // #nosec G204
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("invalid config: %w: %s", err, msg)
+		}
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	return nil
+}
+
+// applyRemoteConfig composes and validates the merged config with the remote config. If the merged
+// config is invalid, the previous remote and merged configs are kept, so that the agent keeps running.
+func (s *Supervisor) applyRemoteConfig(config *protobufs.AgentRemoteConfig) (configChanged bool, err error) {
+	configChanged, err = s.validateAndStoreMergedConfig(config)
+	if err != nil {
+		return false, err
+	}
+	s.remoteConfig = config
 
+	if err = s.saveLastReceivedConfig(config); err != nil {
+		s.logger.Error("Could not save last received remote config", zap.Error(err))
+	}
 	return configChanged, nil
 }
 
@@ -982,6 +1127,10 @@ func (s *Supervisor) runAgentProcess() {
 			s.stopAgentApplyConfig()
 			s.startAgent()
 
+		case pkg := <-s.hasNewPackage:
+			restartTimer.Stop()
+			s.installPackage(pkg)
+
 		case <-s.commander.Exited():
 			// the agent process exit is expected for restart command and will not attempt to restart
 			if s.agentRestarting.Load() {
@@ -1106,16 +1255,12 @@ func (s *Supervisor) saveLastReceivedOwnTelemetrySettings(set *protobufs.Telemet
 func (s *Supervisor) onMessage(ctx context.Context, msg *types.MessageData) {
 	configChanged := false
 	if msg.RemoteConfig != nil {
-		if err := s.saveLastReceivedConfig(msg.RemoteConfig); err != nil {
-			s.logger.Error("Could not save last received remote config", zap.Error(err))
-		}
-		s.remoteConfig = msg.RemoteConfig
-		s.logger.Debug("Received remote config from server", zap.String("hash", fmt.Sprintf("%x", s.remoteConfig.ConfigHash)))
+		s.logger.Debug("Received remote config from server", zap.String("hash", fmt.Sprintf("%x", msg.RemoteConfig.ConfigHash)))
 
 		var err error
-		configChanged, err = s.composeMergedConfig(s.remoteConfig)
+		configChanged, err = s.applyRemoteConfig(msg.RemoteConfig)
 		if err != nil {
-			s.logger.Error("Error composing merged config. Reporting failed remote config status.", zap.Error(err))
+			s.logger.Error("Error applying the remote config. Reporting failed remote config status.", zap.Error(err))
 			err = s.opampClient.SetRemoteConfigStatus(&protobufs.RemoteConfigStatus{
 				LastRemoteConfigHash: msg.RemoteConfig.ConfigHash,
 				Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
@@ -1142,6 +1287,10 @@ func (s *Supervisor) onMessage(ctx context.Context, msg *types.MessageData) {
 		configChanged = s.setupOwnMetrics(ctx, msg.OwnMetricsConnSettings) || configChanged
 	}
 
+	if msg.PackagesAvailable != nil {
+		s.onPackagesAvailable(msg.PackagesAvailable)
+	}
+
 	if msg.AgentIdentification != nil {
 		newInstanceID, err := uuid.FromBytes(msg.AgentIdentification.NewInstanceUid)
 		if err != nil {
@@ -1180,6 +1329,202 @@ func (s *Supervisor) onMessage(ctx context.Context, msg *types.MessageData) {
 	}
 }
 
+// onPackagesAvailable stages the Collector executable offered as the top-level package, if it is
+// not installed yet. The staged executable is then installed by the agent process goroutine.
+func (s *Supervisor) onPackagesAvailable(available *protobufs.PackagesAvailable) {
+	if s.packageManager == nil {
+		s.logger.Debug("Packages are not accepted, ignoring available packages")
+		return
+	}
+
+	allPackagesHash, err := s.packageManager.AllPackagesHash()
+	if err != nil {
+		s.logger.Error("Could not read the packages hash", zap.Error(err))
+		return
+	}
+	if bytes.Equal(allPackagesHash, available.AllPackagesHash) {
+		s.logger.Debug("Available packages are already installed")
+		return
+	}
+
+	if !s.packageUpdateInProgress.CompareAndSwap(false, true) {
+		s.logger.Info("A package update is already in progress, ignoring available packages")
+		return
+	}
+
+	statuses := &protobufs.PackageStatuses{
+		Packages:                      make(map[string]*protobufs.PackageStatus, len(available.Packages)),
+		ServerProvidedAllPackagesHash: available.AllPackagesHash,
+	}
+
+	var staging *protobufs.PackageStatus
+	var offered *protobufs.PackageAvailable
+	for name, pkg := range available.Packages {
+		status := &protobufs.PackageStatus{
+			Name:                 name,
+			ServerOfferedVersion: pkg.Version,
+			ServerOfferedHash:    pkg.Hash,
+		}
+		statuses.Packages[name] = status
+
+		if pkg.Type != protobufs.PackageType_PackageType_TopLevel {
+			status.Status = protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed
+			status.ErrorMessage = "only the top-level package is supported"
+			continue
+		}
+
+		state, err := s.packageManager.PackageState(name)
+		if err != nil {
+			s.logger.Error("Could not read the package state", zap.String("package", name), zap.Error(err))
+		}
+		status.AgentHasVersion = state.Version
+		status.AgentHasHash = state.Hash
+
+		switch reason, failed := s.packageManager.failureReason(pkg.Hash); {
+		case bytes.Equal(state.Hash, pkg.Hash):
+			status.Status = protobufs.PackageStatusEnum_PackageStatusEnum_Installed
+		case failed:
+			status.Status = protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed
+			status.ErrorMessage = fmt.Sprintf("the installation of this package failed previously: %s", reason)
+		case staging != nil:
+			status.Status = protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed
+			status.ErrorMessage = "only one top-level package is supported"
+		default:
+			status.Status = protobufs.PackageStatusEnum_PackageStatusEnum_Installing
+			staging = status
+			offered = pkg
+		}
+	}
+
+	s.reportPackageStatuses(statuses)
+
+	if staging == nil {
+		s.finishPackageUpdate(statuses)
+		return
+	}
+
+	go func() {
+		s.logger.Info("Downloading the Collector package", zap.String("package", staging.Name), zap.String("version", offered.Version))
+		pkg, err := s.packageManager.stage(context.Background(), staging.Name, offered)
+		if err != nil {
+			s.logger.Error("Could not stage the Collector package", zap.String("package", staging.Name), zap.Error(err))
+			s.failPackage(statuses, staging, err)
+			return
+		}
+
+		pkg.statuses = statuses
+		select {
+		case s.hasNewPackage <- pkg:
+		case <-s.doneChan:
+		}
+	}()
+}
+
+// installPackage swaps the agent executable with the staged one and restarts the agent. The
+// update is rolled back if the agent doesn't become healthy in time.
+func (s *Supervisor) installPackage(pkg *stagedPackage) {
+	statuses := pkg.statuses
+	status := statuses.Packages[pkg.name]
+
+	s.logger.Info("Installing the Collector package", zap.String("package", pkg.name), zap.String("version", pkg.offered.Version))
+	if err := s.commander.Stop(context.Background()); err != nil {
+		s.logger.Error("Could not stop agent process", zap.Error(err))
+	}
+
+	if err := s.packageManager.swap(pkg); err != nil {
+		s.logger.Error("Could not install the Collector package", zap.Error(err))
+		s.startAgent()
+		s.failPackage(statuses, status, err)
+		return
+	}
+
+	s.startAgent()
+	if err := s.waitForAgentHealthy(s.config.Packages.HealthCheckTimeout); err != nil {
+		s.logger.Error("The updated agent is not healthy, rolling back", zap.String("package", pkg.name), zap.Error(err))
+		if stopErr := s.commander.Stop(context.Background()); stopErr != nil {
+			s.logger.Error("Could not stop agent process", zap.Error(stopErr))
+		}
+		if rollbackErr := s.packageManager.rollback(); rollbackErr != nil {
+			s.logger.Error("Could not roll back the agent executable", zap.Error(rollbackErr))
+		}
+		s.startAgent()
+
+		if markErr := s.packageManager.markFailed(pkg.offered.Hash, err.Error()); markErr != nil {
+			s.logger.Error("Could not persist the failed package", zap.Error(markErr))
+		}
+		s.failPackage(statuses, status, fmt.Errorf("the updated agent is not healthy: %w", err))
+		return
+	}
+
+	if err := s.packageManager.commit(pkg); err != nil {
+		s.logger.Error("Could not persist the installed package", zap.Error(err))
+	}
+
+	s.logger.Info("Collector package installed", zap.String("package", pkg.name), zap.String("version", pkg.offered.Version))
+	status.Status = protobufs.PackageStatusEnum_PackageStatusEnum_Installed
+	status.AgentHasVersion = pkg.offered.Version
+	status.AgentHasHash = pkg.offered.Hash
+	s.reportPackageStatuses(statuses)
+	s.finishPackageUpdate(statuses)
+
+	// The updated Collector reports a new AgentDescription, e.g. with its new version.
+	if err := s.opampClient.SetAgentDescription(s.agentDescription.Load().(*protobufs.AgentDescription)); err != nil {
+		s.logger.Error("Failed to send agent description to OpAMP server", zap.Error(err))
+	}
+}
+
+// waitForAgentHealthy polls the agent health check until it succeeds, or the timeout expires.
+func (s *Supervisor) waitForAgentHealthy(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		if !s.commander.IsRunning() {
+			return errors.New("the agent is not running")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		err := s.healthChecker.Check(ctx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return err
+		}
+
+		select {
+		case <-time.After(time.Second):
+		case <-s.doneChan:
+			return errors.New("the supervisor is shutting down")
+		}
+	}
+}
+
+func (s *Supervisor) failPackage(statuses *protobufs.PackageStatuses, status *protobufs.PackageStatus, err error) {
+	status.Status = protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed
+	status.ErrorMessage = err.Error()
+	s.reportPackageStatuses(statuses)
+	s.finishPackageUpdate(statuses)
+}
+
+// finishPackageUpdate records the available packages as processed, so they are only processed
+// again if the server offers different packages.
+func (s *Supervisor) finishPackageUpdate(statuses *protobufs.PackageStatuses) {
+	if err := s.packageManager.SetAllPackagesHash(statuses.ServerProvidedAllPackagesHash); err != nil {
+		s.logger.Error("Could not persist the packages hash", zap.Error(err))
+	}
+	s.packageUpdateInProgress.Store(false)
+}
+
+func (s *Supervisor) reportPackageStatuses(statuses *protobufs.PackageStatuses) {
+	if err := s.packageManager.SetLastReportedStatuses(statuses); err != nil {
+		s.logger.Error("Could not persist the package statuses", zap.Error(err))
+	}
+	if err := s.opampClient.SetPackageStatuses(statuses); err != nil {
+		s.logger.Error("Could not report the package statuses to the OpAMP server", zap.Error(err))
+	}
+}
+
 func (s *Supervisor) persistentStateFile() string {
 	return filepath.Join(s.config.Storage.Directory, persistentStateFilePath)
 }
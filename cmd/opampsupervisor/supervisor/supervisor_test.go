@@ -7,10 +7,14 @@ import (
 	"bytes"
 	"context"
 	"os"
+	"path/filepath"
 	"sync/atomic"
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/rawbytes"
+	"github.com/knadh/koanf/v2"
 	"github.com/open-telemetry/opamp-go/client"
 	"github.com/open-telemetry/opamp-go/client/types"
 	"github.com/open-telemetry/opamp-go/protobufs"
@@ -68,7 +72,7 @@ service:
 	require.NoError(t, s.createTemplates())
 	require.NoError(t, s.loadInitialMergedConfig())
 
-	configChanged, err := s.composeMergedConfig(&protobufs.AgentRemoteConfig{
+	mergedConfig, err := s.composeMergedConfig(&protobufs.AgentRemoteConfig{
 		Config: &protobufs.AgentConfigMap{
 			ConfigMap: map[string]*protobufs.AgentConfigFile{
 				"": {
@@ -83,10 +87,72 @@ service:
 	require.NoError(t, err)
 	expectedConfig = bytes.ReplaceAll(expectedConfig, []byte("\r\n"), []byte("\n"))
 
-	require.True(t, configChanged)
+	require.Equal(t, string(expectedConfig), mergedConfig)
+	require.True(t, s.storeMergedConfig(mergedConfig))
+	require.False(t, s.storeMergedConfig(mergedConfig))
 	require.Equal(t, string(expectedConfig), s.mergedConfig.Load().(string))
 }
 
+func Test_composeEffectiveConfigLocalConfigFiles(t *testing.T) {
+	dir := t.TempDir()
+	baseConfig := filepath.Join(dir, "base.yaml")
+	require.NoError(t, os.WriteFile(baseConfig, []byte(`
+exporters:
+  file:
+    path: '/base/output.log'
+processors:
+  batch: {}`), 0600))
+	overrideConfig := filepath.Join(dir, "override.yaml")
+	require.NoError(t, os.WriteFile(overrideConfig, []byte(`
+exporters:
+  file:
+    path: '/local/output.log'`), 0600))
+
+	s := Supervisor{
+		logger:                       zap.NewNop(),
+		persistentState:              &persistentState{},
+		config:                       config.Supervisor{Agent: config.Agent{ConfigFiles: []string{baseConfig, overrideConfig}}},
+		pidProvider:                  staticPIDProvider(1234),
+		hasNewConfig:                 make(chan struct{}, 1),
+		agentConfigOwnMetricsSection: &atomic.Value{},
+		mergedConfig:                 &atomic.Value{},
+		agentHealthCheckEndpoint:     "localhost:8000",
+	}
+	agentDesc := &atomic.Value{}
+	agentDesc.Store(&protobufs.AgentDescription{})
+	s.agentDescription = agentDesc
+
+	require.NoError(t, s.createTemplates())
+
+	mergedConfig, err := s.composeMergedConfig(&protobufs.AgentRemoteConfig{
+		Config: &protobufs.AgentConfigMap{
+			ConfigMap: map[string]*protobufs.AgentConfigFile{
+				"": {
+					Body: []byte(`
+exporters:
+  file:
+    path: '/remote/output.log'
+    rotation:
+      max_megabytes: 10`),
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	k := koanf.New("::")
+	require.NoError(t, k.Load(rawbytes.Provider([]byte(mergedConfig)), yaml.Parser()))
+	require.Equal(t, "/local/output.log", k.String("exporters::file::path"))
+	require.Equal(t, 10, k.Int("exporters::file::rotation::max_megabytes"))
+	require.True(t, k.Exists("processors::batch"))
+
+	remote := koanf.New("::")
+	require.NoError(t, remote.Load(rawbytes.Provider([]byte("exporters: {file: {path: '/remote/output.log'}}\nprocessors: {batch: {}}")), yaml.Parser()))
+	local, err := s.loadLocalConfig()
+	require.NoError(t, err)
+	require.Equal(t, []string{"exporters::file::path"}, overriddenKeys(remote, local))
+}
+
 func Test_onMessage(t *testing.T) {
 	t.Run("AgentIdentification - New instance ID is valid", func(t *testing.T) {
 		agentDesc := &atomic.Value{}
@@ -140,6 +206,82 @@ func Test_onMessage(t *testing.T) {
 
 		require.Equal(t, testUUID, s.persistentState.InstanceID)
 	})
+
+	t.Run("RemoteConfig - Invalid config is not applied", func(t *testing.T) {
+		agentDesc := &atomic.Value{}
+		agentDesc.Store(&protobufs.AgentDescription{})
+		storageDir := t.TempDir()
+		s := Supervisor{
+			logger:      zap.NewNop(),
+			pidProvider: defaultPIDProvider{},
+			config: config.Supervisor{
+				Agent:   config.Agent{Executable: filepath.Join(storageDir, "missing-otelcol")},
+				Storage: config.Storage{Directory: storageDir},
+			},
+			hasNewConfig:                 make(chan struct{}, 1),
+			persistentState:              &persistentState{},
+			agentDescription:             agentDesc,
+			agentConfigOwnMetricsSection: &atomic.Value{},
+			mergedConfig:                 &atomic.Value{},
+			effectiveConfig:              &atomic.Value{},
+			agentHealthCheckEndpoint:     "localhost:8000",
+			opampClient:                  client.NewHTTP(newLoggerFromZap(zap.NewNop())),
+		}
+		require.NoError(t, s.createTemplates())
+		require.NoError(t, s.loadInitialMergedConfig())
+		initialConfig := s.mergedConfig.Load()
+
+		s.onMessage(context.Background(), &types.MessageData{
+			RemoteConfig: &protobufs.AgentRemoteConfig{
+				Config: &protobufs.AgentConfigMap{
+					ConfigMap: map[string]*protobufs.AgentConfigFile{
+						"": {Body: []byte("receivers: {unknown: {}}")},
+					},
+				},
+				ConfigHash: []byte("hash"),
+			},
+		})
+
+		require.Equal(t, initialConfig, s.mergedConfig.Load())
+		require.Nil(t, s.remoteConfig)
+		require.Empty(t, s.hasNewConfig)
+		require.NoFileExists(t, filepath.Join(storageDir, lastRecvRemoteConfigFile))
+	})
+
+	t.Run("OwnMetricsConnSettings - Invalid config is not applied", func(t *testing.T) {
+		agentDesc := &atomic.Value{}
+		agentDesc.Store(&protobufs.AgentDescription{})
+		storageDir := t.TempDir()
+		s := Supervisor{
+			logger:      zap.NewNop(),
+			pidProvider: defaultPIDProvider{},
+			config: config.Supervisor{
+				Agent:   config.Agent{Executable: filepath.Join(storageDir, "missing-otelcol")},
+				Storage: config.Storage{Directory: storageDir},
+			},
+			hasNewConfig:                 make(chan struct{}, 1),
+			persistentState:              &persistentState{},
+			agentDescription:             agentDesc,
+			agentConfigOwnMetricsSection: &atomic.Value{},
+			mergedConfig:                 &atomic.Value{},
+			effectiveConfig:              &atomic.Value{},
+			agentHealthCheckEndpoint:     "localhost:8000",
+			opampClient:                  client.NewHTTP(newLoggerFromZap(zap.NewNop())),
+		}
+		require.NoError(t, s.createTemplates())
+		require.NoError(t, s.loadInitialMergedConfig())
+		initialConfig := s.mergedConfig.Load()
+
+		s.onMessage(context.Background(), &types.MessageData{
+			OwnMetricsConnSettings: &protobufs.TelemetryConnectionSettings{
+				DestinationEndpoint: "http://localhost:4318",
+			},
+		})
+
+		require.Equal(t, initialConfig, s.mergedConfig.Load())
+		require.Empty(t, s.agentConfigOwnMetricsSection.Load())
+		require.Empty(t, s.hasNewConfig)
+	})
 }
 
 type staticPIDProvider int
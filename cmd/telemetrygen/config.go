@@ -11,24 +11,29 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/collector/component"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/logs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/metrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/replay"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/scenario"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/traces"
 )
 
 var (
-	tracesCfg  *traces.Config
-	metricsCfg *metrics.Config
-	logsCfg    *logs.Config
+	tracesCfg   *traces.Config
+	metricsCfg  *metrics.Config
+	logsCfg     *logs.Config
+	replayCfg   *replay.Config
+	scenarioCfg *scenario.Config
 )
 
 // rootCmd is the root command on which will be run children commands
 var rootCmd = &cobra.Command{
 	Use:     "telemetrygen",
 	Short:   "Telemetrygen simulates a client generating traces, metrics, and logs",
-	Example: "telemetrygen traces\ntelemetrygen metrics\ntelemetrygen logs",
+	Example: "telemetrygen traces\ntelemetrygen metrics\ntelemetrygen logs\ntelemetrygen replay --file recording.json\ntelemetrygen scenario --file scenario.yaml",
 }
 
 // tracesCmd is the command responsible for sending traces
@@ -61,8 +66,28 @@ var logsCmd = &cobra.Command{
 	},
 }
 
+// replayCmd is the command responsible for replaying recorded telemetry
+var replayCmd = &cobra.Command{
+	Use:     "replay",
+	Short:   fmt.Sprintf("Replays traces, metrics, and logs recorded in an OTLP JSON file. (Stability level: %s)", component.StabilityLevelDevelopment),
+	Example: "telemetrygen replay --file recording.json --speed 2 --duration 1m",
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return replay.Start(replayCfg)
+	},
+}
+
+// scenarioCmd is the command responsible for sending the traces of a scenario
+var scenarioCmd = &cobra.Command{
+	Use:     "scenario",
+	Short:   fmt.Sprintf("Simulates services generating the traces described in a YAML file. (Stability level: %s)", component.StabilityLevelDevelopment),
+	Example: "telemetrygen scenario --file scenario.yaml --rate 10 --duration 1m",
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return scenario.Start(scenarioCfg)
+	},
+}
+
 func init() {
-	rootCmd.AddCommand(tracesCmd, metricsCmd, logsCmd)
+	rootCmd.AddCommand(tracesCmd, metricsCmd, logsCmd, replayCmd, scenarioCmd)
 
 	tracesCfg = new(traces.Config)
 	tracesCfg.Flags(tracesCmd.Flags())
@@ -73,6 +98,12 @@ func init() {
 	logsCfg = new(logs.Config)
 	logsCfg.Flags(logsCmd.Flags())
 
+	replayCfg = new(replay.Config)
+	replayCfg.Flags(replayCmd.Flags())
+
+	scenarioCfg = new(scenario.Config)
+	scenarioCfg.Flags(scenarioCmd.Flags())
+
 	// Disabling completion command for end user
 	// https://github.com/spf13/cobra/blob/master/shell_completions.md
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
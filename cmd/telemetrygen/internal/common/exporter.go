@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	tracesHTTPPath  = "/v1/traces"
+	metricsHTTPPath = "/v1/metrics"
+	logsHTTPPath    = "/v1/logs"
+)
+
+// Exporter exports telemetry built with pdata over OTLP, for the telemetry that isn't generated
+// with the OpenTelemetry SDK. Over HTTP, the URL path of the config is used when set, and the
+// default URL path of each signal otherwise.
+type Exporter struct {
+	cfg        *Config
+	httpClient *http.Client
+	clientConn *grpc.ClientConn
+}
+
+// NewExporter creates an Exporter using the OTLP settings of the config.
+func NewExporter(cfg *Config) (*Exporter, error) {
+	if cfg.UseHTTP {
+		if cfg.Insecure {
+			return &Exporter{cfg: cfg, httpClient: http.DefaultClient}, nil
+		}
+		creds, err := GetTLSCredentialsForHTTPExporter(cfg.CaFile, cfg.ClientAuth)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get TLS credentials: %w", err)
+		}
+		return &Exporter{
+			cfg:        cfg,
+			httpClient: &http.Client{Transport: &http.Transport{TLSClientConfig: creds}},
+		}, nil
+	}
+
+	var clientConn *grpc.ClientConn
+	var err error
+	if cfg.Insecure {
+		clientConn, err = grpc.NewClient(cfg.Endpoint(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		creds, err := GetTLSCredentialsForGRPCExporter(cfg.CaFile, cfg.ClientAuth)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get TLS credentials: %w", err)
+		}
+		clientConn, err = grpc.NewClient(cfg.Endpoint(), grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Exporter{cfg: cfg, clientConn: clientConn}, nil
+}
+
+// ExportTraces exports the traces.
+func (e *Exporter) ExportTraces(ctx context.Context, td ptrace.Traces) error {
+	req := ptraceotlp.NewExportRequestFromTraces(td)
+	if e.clientConn != nil {
+		_, err := ptraceotlp.NewGRPCClient(e.clientConn).Export(e.grpcContext(ctx), req)
+		return err
+	}
+	body, err := req.MarshalProto()
+	if err != nil {
+		return fmt.Errorf("failed to marshal traces to protobuf: %w", err)
+	}
+	return e.post(ctx, tracesHTTPPath, body)
+}
+
+// ExportMetrics exports the metrics.
+func (e *Exporter) ExportMetrics(ctx context.Context, md pmetric.Metrics) error {
+	req := pmetricotlp.NewExportRequestFromMetrics(md)
+	if e.clientConn != nil {
+		_, err := pmetricotlp.NewGRPCClient(e.clientConn).Export(e.grpcContext(ctx), req)
+		return err
+	}
+	body, err := req.MarshalProto()
+	if err != nil {
+		return fmt.Errorf("failed to marshal metrics to protobuf: %w", err)
+	}
+	return e.post(ctx, metricsHTTPPath, body)
+}
+
+// ExportLogs exports the logs.
+func (e *Exporter) ExportLogs(ctx context.Context, ld plog.Logs) error {
+	req := plogotlp.NewExportRequestFromLogs(ld)
+	if e.clientConn != nil {
+		_, err := plogotlp.NewGRPCClient(e.clientConn).Export(e.grpcContext(ctx), req)
+		return err
+	}
+	body, err := req.MarshalProto()
+	if err != nil {
+		return fmt.Errorf("failed to marshal logs to protobuf: %w", err)
+	}
+	return e.post(ctx, logsHTTPPath, body)
+}
+
+// Shutdown closes the connection of the exporter.
+func (e *Exporter) Shutdown() error {
+	if e.clientConn != nil {
+		return e.clientConn.Close()
+	}
+	return nil
+}
+
+func (e *Exporter) grpcContext(ctx context.Context) context.Context {
+	md := metadata.New(map[string]string{})
+	for k, v := range e.cfg.Headers {
+		md.Set(k, v)
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+func (e *Exporter) post(ctx context.Context, path string, body []byte) error {
+	if e.cfg.HTTPPath != "" {
+		path = e.cfg.HTTPPath
+	}
+	scheme := "https"
+	if e.cfg.Insecure {
+		scheme = "http"
+	}
+	url := fmt.Sprintf("%s://%s%s", scheme, e.cfg.Endpoint(), path)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	for k, v := range e.cfg.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+	resp, err := e.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to execute HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var respData bytes.Buffer
+		_, _ = io.Copy(&respData, resp.Body)
+		return fmt.Errorf("request to %s failed with status %s (%s)", path, resp.Status, respData.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestExporterHTTPPath(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		assert.Equal(t, "value", r.Header.Get("key"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	for _, tt := range []struct {
+		name     string
+		httpPath string
+		expected []string
+	}{
+		{
+			name:     "default paths",
+			expected: []string{"/v1/traces", "/v1/metrics", "/v1/logs"},
+		},
+		{
+			name:     "custom path",
+			httpPath: "/custom",
+			expected: []string{"/custom", "/custom", "/custom"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			paths = nil
+			e, err := NewExporter(&Config{
+				CustomEndpoint: strings.TrimPrefix(srv.URL, "http://"),
+				Insecure:       true,
+				UseHTTP:        true,
+				HTTPPath:       tt.httpPath,
+				Headers:        KeyValue{"key": "value"},
+			})
+			require.NoError(t, err)
+
+			require.NoError(t, e.ExportTraces(context.Background(), ptrace.NewTraces()))
+			require.NoError(t, e.ExportMetrics(context.Background(), pmetric.NewMetrics()))
+			require.NoError(t, e.ExportLogs(context.Background(), plog.NewLogs()))
+			require.NoError(t, e.Shutdown())
+			assert.Equal(t, tt.expected, paths)
+		})
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// seriesAttributeKey is the attribute distinguishing the time series of a metric with an attribute
+// cardinality greater than 1.
+const seriesAttributeKey = "series"
+
+// sampler returns a value of the distribution.
+type sampler func(r *rand.Rand) float64
+
+// newSampler returns the sampler of the configured distribution. The values are never negative, the
+// values of the normal distribution below zero being clamped to zero.
+func newSampler(c *Config) sampler {
+	switch c.Distribution {
+	case distributionUniform:
+		return func(r *rand.Rand) float64 {
+			return c.DistributionMin + r.Float64()*(c.DistributionMax-c.DistributionMin)
+		}
+	case distributionExponential:
+		return func(r *rand.Rand) float64 {
+			return r.ExpFloat64() * c.DistributionMean
+		}
+	default:
+		return func(r *rand.Rand) float64 {
+			return math.Max(0, c.DistributionMean+r.NormFloat64()*c.DistributionStdDev)
+		}
+	}
+}
+
+// series is the state of a time series of the generated metric.
+type series struct {
+	attrs attribute.Set
+	// start is the start time of the next data point, the start of the test for the cumulative
+	// temporality, or the time of the previous data point for the delta temporality.
+	start time.Time
+
+	// Aggregated observations of the histograms and summaries.
+	count     uint64
+	sum       float64
+	min       float64
+	max       float64
+	buckets   []uint64         // counts of the explicit buckets
+	zeroCount uint64           // count of the exponential zero bucket
+	positive  map[int32]uint64 // counts of the positive exponential buckets, by index
+	samples   []float64        // observations of the current interval, for the summary quantiles
+}
+
+// newSeries returns the time series of the metric, each series having a distinct `series` attribute
+// if there is more than one.
+func newSeries(cardinality int, signalAttrs []attribute.KeyValue, start time.Time) []*series {
+	if cardinality < 1 {
+		cardinality = 1
+	}
+	all := make([]*series, cardinality)
+	for i := range all {
+		attrs := signalAttrs
+		if cardinality > 1 {
+			attrs = append(append([]attribute.KeyValue{}, signalAttrs...), attribute.String(seriesAttributeKey, strconv.Itoa(i)))
+		}
+		all[i] = &series{
+			attrs:    attribute.NewSet(attrs...),
+			start:    start,
+			positive: map[int32]uint64{},
+		}
+	}
+	return all
+}
+
+// reset clears the aggregated observations, for the delta temporality.
+func (s *series) reset() {
+	s.count = 0
+	s.sum = 0
+	s.buckets = nil
+	s.zeroCount = 0
+	s.positive = map[int32]uint64{}
+}
+
+func (s *series) observe(v float64) {
+	if s.count == 0 || v < s.min {
+		s.min = v
+	}
+	if s.count == 0 || v > s.max {
+		s.max = v
+	}
+	s.count++
+	s.sum += v
+}
+
+func (s *series) observeHistogram(v float64, bounds []float64) {
+	s.observe(v)
+	if s.buckets == nil {
+		s.buckets = make([]uint64, len(bounds)+1)
+	}
+	// A bucket counts the values greater than the previous bound, up to its bound included.
+	s.buckets[sort.SearchFloat64s(bounds, v)]++
+}
+
+func (s *series) observeExponentialHistogram(v float64, scale int32) {
+	s.observe(v)
+	if v == 0 {
+		s.zeroCount++
+		return
+	}
+	s.positive[exponentialBucketIndex(v, scale)]++
+}
+
+// exponentialBucketIndex returns the index of the bucket of the positive value, the bucket of index i
+// counting the values greater than base^i, up to base^(i+1) included, with base = 2^(2^-scale).
+// It follows the mapping of the specification, the exact powers of two being special-cased since
+// the logarithm isn't exact for them.
+func exponentialBucketIndex(v float64, scale int32) int32 {
+	// v = frac * 2^exp, with frac in [0.5, 1).
+	frac, exp := math.Frexp(v)
+	if scale <= 0 {
+		if frac == 0.5 {
+			return int32(exp-2) >> -scale
+		}
+		return int32(exp-1) >> -scale
+	}
+	if frac == 0.5 {
+		return int32(exp-1)<<scale - 1
+	}
+	return int32(math.Floor(math.Log(v) * math.Log2E * math.Exp2(float64(scale))))
+}
+
+// histogramExemplars returns the exemplars of a histogram data point, with the value of an
+// observation of the data point.
+func histogramExemplars(exemplars []metricdata.Exemplar[int64], v float64) []metricdata.Exemplar[float64] {
+	if len(exemplars) == 0 {
+		return nil
+	}
+	all := make([]metricdata.Exemplar[float64], len(exemplars))
+	for i, e := range exemplars {
+		all[i] = metricdata.Exemplar[float64]{
+			FilteredAttributes: e.FilteredAttributes,
+			Time:               e.Time,
+			Value:              v,
+			SpanID:             e.SpanID,
+			TraceID:            e.TraceID,
+		}
+	}
+	return all
+}
+
+func (s *series) observeSummary(v float64) {
+	s.observe(v)
+	s.samples = append(s.samples, v)
+}
+
+func (s *series) histogramDataPoint(now time.Time, bounds []float64) metricdata.HistogramDataPoint[float64] {
+	dp := metricdata.HistogramDataPoint[float64]{
+		Attributes:   s.attrs,
+		StartTime:    s.start,
+		Time:         now,
+		Count:        s.count,
+		Bounds:       bounds,
+		BucketCounts: append([]uint64{}, s.buckets...),
+		Sum:          s.sum,
+	}
+	if dp.BucketCounts == nil {
+		dp.BucketCounts = make([]uint64, len(bounds)+1)
+	}
+	if s.count > 0 {
+		dp.Min = metricdata.NewExtrema(s.min)
+		dp.Max = metricdata.NewExtrema(s.max)
+	}
+	return dp
+}
+
+func (s *series) exponentialHistogramDataPoint(now time.Time, scale int32) metricdata.ExponentialHistogramDataPoint[float64] {
+	dp := metricdata.ExponentialHistogramDataPoint[float64]{
+		Attributes: s.attrs,
+		StartTime:  s.start,
+		Time:       now,
+		Count:      s.count,
+		Sum:        s.sum,
+		Scale:      scale,
+		ZeroCount:  s.zeroCount,
+	}
+	if s.count > 0 {
+		dp.Min = metricdata.NewExtrema(s.min)
+		dp.Max = metricdata.NewExtrema(s.max)
+	}
+	if len(s.positive) > 0 {
+		first, last := int32(math.MaxInt32), int32(math.MinInt32)
+		for index := range s.positive {
+			first = min(first, index)
+			last = max(last, index)
+		}
+		dp.PositiveBucket.Offset = first
+		dp.PositiveBucket.Counts = make([]uint64, last-first+1)
+		for index, count := range s.positive {
+			dp.PositiveBucket.Counts[index-first] = count
+		}
+	}
+	return dp
+}
+
+// summaryDataPoint returns the summary of the observations, the quantiles being computed from the
+// observations of the current interval only.
+func (s *series) summaryDataPoint(now time.Time, quantiles []float64) metricdata.SummaryDataPoint {
+	dp := metricdata.SummaryDataPoint{
+		Attributes: s.attrs,
+		StartTime:  s.start,
+		Time:       now,
+		Count:      s.count,
+		Sum:        s.sum,
+	}
+	if len(s.samples) > 0 {
+		sort.Float64s(s.samples)
+		for _, q := range quantiles {
+			// Nearest-rank quantile.
+			rank := int(math.Ceil(q*float64(len(s.samples)))) - 1
+			dp.QuantileValues = append(dp.QuantileValues, metricdata.QuantileValue{
+				Quantile: q,
+				Value:    s.samples[max(rank, 0)],
+			})
+		}
+	}
+	s.samples = s.samples[:0]
+	return dp
+}
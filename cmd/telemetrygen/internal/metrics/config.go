@@ -4,6 +4,10 @@
 package metrics
 
 import (
+	"errors"
+	"fmt"
+	"sort"
+
 	"github.com/spf13/pflag"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
@@ -17,6 +21,21 @@ type Config struct {
 	MetricType metricType
 	SpanID     string
 	TraceID    string
+
+	Temporality          temporality
+	AttributeCardinality int
+
+	// Distribution of the values observed by the histograms and summaries.
+	Distribution       distribution
+	DistributionMean   float64
+	DistributionStdDev float64
+	DistributionMin    float64
+	DistributionMax    float64
+	Observations       int
+
+	HistogramBounds           []float64
+	ExponentialHistogramScale int32
+	SummaryQuantiles          []float64
 }
 
 // Flags registers config flags.
@@ -24,13 +43,28 @@ func (c *Config) Flags(fs *pflag.FlagSet) {
 	// Use Gauge as default metric type.
 	c.MetricName = "gen"
 	c.MetricType = metricTypeGauge
+	c.Temporality = temporalityCumulative
+	c.Distribution = distributionNormal
 
 	c.CommonFlags(fs)
 
 	fs.StringVar(&c.HTTPPath, "otlp-http-url-path", "/v1/metrics", "Which URL path to write to")
 
-	fs.Var(&c.MetricType, "metric-type", "Metric type enum. must be one of 'Gauge' or 'Sum'")
+	fs.Var(&c.MetricType, "metric-type", "Metric type enum. must be one of 'Gauge', 'Sum', 'Histogram', 'ExponentialHistogram' or 'Summary'")
 	fs.IntVar(&c.NumMetrics, "metrics", 1, "Number of metrics to generate in each worker (ignored if duration is provided)")
+	fs.Var(&c.Temporality, "temporality", "Temporality of the Sum, Histogram and ExponentialHistogram metrics, one of 'cumulative' or 'delta'")
+	fs.IntVar(&c.AttributeCardinality, "attribute-cardinality", 1, "Number of time series of the metric, each data point having a distinct value of the 'series' attribute when greater than 1")
+
+	fs.Var(&c.Distribution, "distribution", "Distribution of the values observed by the Histogram, ExponentialHistogram and Summary metrics, one of 'normal', 'uniform' or 'exponential'")
+	fs.Float64Var(&c.DistributionMean, "distribution-mean", 100, "Mean of the normal and exponential distributions")
+	fs.Float64Var(&c.DistributionStdDev, "distribution-stddev", 25, "Standard deviation of the normal distribution")
+	fs.Float64Var(&c.DistributionMin, "distribution-min", 0, "Minimum of the uniform distribution")
+	fs.Float64Var(&c.DistributionMax, "distribution-max", 200, "Maximum of the uniform distribution")
+	fs.IntVar(&c.Observations, "observations", 100, "Number of values observed for each Histogram, ExponentialHistogram and Summary data point")
+
+	fs.Float64SliceVar(&c.HistogramBounds, "histogram-bounds", []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}, "Bucket boundaries of the Histogram metric")
+	fs.Int32Var(&c.ExponentialHistogramScale, "exponential-histogram-scale", 3, "Scale of the ExponentialHistogram metric, from -10 to 20")
+	fs.Float64SliceVar(&c.SummaryQuantiles, "summary-quantiles", []float64{0.5, 0.9, 0.99}, "Quantiles of the Summary metric")
 
 	fs.StringVar(&c.TraceID, "trace-id", "", "TraceID to use as exemplar")
 	fs.StringVar(&c.SpanID, "span-id", "", "SpanID to use as exemplar")
@@ -51,5 +85,65 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.MetricType == metricTypeSummary && (c.TraceID != "" || c.SpanID != "") {
+		return errors.New("`trace-id` and `span-id` aren't supported for summaries, which have no exemplars")
+	}
+
+	if c.AttributeCardinality < 1 {
+		return errors.New("`attribute-cardinality` must be at least 1")
+	}
+
+	switch c.MetricType {
+	case metricTypeHistogram, metricTypeExponentialHistogram, metricTypeSummary:
+		if err := c.validateDistribution(); err != nil {
+			return err
+		}
+	}
+
+	if !sort.Float64sAreSorted(c.HistogramBounds) {
+		return errors.New("`histogram-bounds` must be sorted")
+	}
+	for i := 1; i < len(c.HistogramBounds); i++ {
+		if c.HistogramBounds[i] == c.HistogramBounds[i-1] {
+			return fmt.Errorf("`histogram-bounds` has duplicate bound %v", c.HistogramBounds[i])
+		}
+	}
+
+	if c.ExponentialHistogramScale < -10 || c.ExponentialHistogramScale > 20 {
+		return errors.New("`exponential-histogram-scale` must be between -10 and 20")
+	}
+
+	for i, q := range c.SummaryQuantiles {
+		if q < 0 || q > 1 {
+			return fmt.Errorf("`summary-quantiles` must be between 0 and 1, got %v", q)
+		}
+		if i > 0 && q <= c.SummaryQuantiles[i-1] {
+			return errors.New("`summary-quantiles` must be strictly increasing")
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) validateDistribution() error {
+	if c.Observations < 1 {
+		return errors.New("`observations` must be at least 1")
+	}
+
+	switch c.Distribution {
+	case distributionNormal:
+		if c.DistributionStdDev < 0 {
+			return errors.New("`distribution-stddev` must not be negative")
+		}
+	case distributionUniform:
+		if c.DistributionMin < 0 || c.DistributionMax < c.DistributionMin {
+			return errors.New("`distribution-min` must not be negative nor greater than `distribution-max`")
+		}
+	case distributionExponential:
+		if c.DistributionMean <= 0 {
+			return errors.New("`distribution-mean` must be positive for the exponential distribution")
+		}
+	}
+
 	return nil
 }
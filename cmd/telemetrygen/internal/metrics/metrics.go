@@ -31,6 +31,11 @@ func Start(cfg *Config) error {
 	}
 	logger.Info("starting the metrics generator with configuration", zap.Any("config", cfg))
 
+	if err = cfg.Validate(); err != nil {
+		logger.Error("failed to validate the parameters for the test scenario.", zap.Error(err))
+		return err
+	}
+
 	expFunc := func() (sdkmetric.Exporter, error) {
 		var exp sdkmetric.Exporter
 		if cfg.UseHTTP {
@@ -99,6 +104,13 @@ func Run(c *Config, exp func() (sdkmetric.Exporter, error), logger *zap.Logger)
 			metricType:     c.MetricType,
 			exemplars:      exemplarsFromConfig(c),
 			limitPerSecond: limit,
+			temporality:    c.Temporality.metricdata(),
+			cardinality:    c.AttributeCardinality,
+			sample:         newSampler(c),
+			observations:   c.Observations,
+			bounds:         c.HistogramBounds,
+			scale:          c.ExponentialHistogramScale,
+			quantiles:      c.SummaryQuantiles,
 			totalDuration:  c.TotalDuration,
 			running:        running,
 			wg:             &wg,
@@ -79,3 +79,107 @@ func Test_exemplarsFromConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate(t *testing.T) {
+	validConfig := func() *Config {
+		return &Config{
+			MetricType:                metricTypeHistogram,
+			AttributeCardinality:      1,
+			Distribution:              distributionNormal,
+			DistributionMean:          100,
+			DistributionStdDev:        25,
+			Observations:              100,
+			HistogramBounds:           []float64{0, 10, 100},
+			ExponentialHistogramScale: 3,
+			SummaryQuantiles:          []float64{0.5, 0.99},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		err    string
+	}{
+		{
+			name:   "valid",
+			modify: func(_ *Config) {},
+		},
+		{
+			name:   "no attribute cardinality",
+			modify: func(c *Config) { c.AttributeCardinality = 0 },
+			err:    "`attribute-cardinality` must be at least 1",
+		},
+		{
+			name:   "no observations",
+			modify: func(c *Config) { c.Observations = 0 },
+			err:    "`observations` must be at least 1",
+		},
+		{
+			name: "exemplars for summaries",
+			modify: func(c *Config) {
+				c.MetricType = metricTypeSummary
+				c.TraceID = "ae87dadd90e9935a4bc9660628efd569"
+			},
+			err: "`trace-id` and `span-id` aren't supported for summaries, which have no exemplars",
+		},
+		{
+			name:   "observations ignored for gauges",
+			modify: func(c *Config) { c.MetricType = metricTypeGauge; c.Observations = 0 },
+		},
+		{
+			name: "invalid uniform distribution",
+			modify: func(c *Config) {
+				c.Distribution = distributionUniform
+				c.DistributionMin = 10
+				c.DistributionMax = 5
+			},
+			err: "`distribution-min` must not be negative nor greater than `distribution-max`",
+		},
+		{
+			name: "invalid exponential distribution",
+			modify: func(c *Config) {
+				c.Distribution = distributionExponential
+				c.DistributionMean = 0
+			},
+			err: "`distribution-mean` must be positive for the exponential distribution",
+		},
+		{
+			name:   "unsorted histogram bounds",
+			modify: func(c *Config) { c.HistogramBounds = []float64{10, 0} },
+			err:    "`histogram-bounds` must be sorted",
+		},
+		{
+			name:   "duplicate histogram bounds",
+			modify: func(c *Config) { c.HistogramBounds = []float64{0, 10, 10} },
+			err:    "`histogram-bounds` has duplicate bound 10",
+		},
+		{
+			name:   "invalid exponential histogram scale",
+			modify: func(c *Config) { c.ExponentialHistogramScale = 21 },
+			err:    "`exponential-histogram-scale` must be between -10 and 20",
+		},
+		{
+			name:   "invalid summary quantile",
+			modify: func(c *Config) { c.SummaryQuantiles = []float64{0.5, 1.5} },
+			err:    "`summary-quantiles` must be between 0 and 1, got 1.5",
+		},
+		{
+			name:   "unsorted summary quantiles",
+			modify: func(c *Config) { c.SummaryQuantiles = []float64{0.9, 0.5} },
+			err:    "`summary-quantiles` must be strictly increasing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validConfig()
+			tt.modify(c)
+			err := c.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
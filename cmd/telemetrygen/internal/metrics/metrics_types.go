@@ -5,13 +5,18 @@ package metrics
 
 import (
 	"errors"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
 
 type metricType string
 
 const (
-	metricTypeGauge = "Gauge"
-	metricTypeSum   = "Sum"
+	metricTypeGauge                = "Gauge"
+	metricTypeSum                  = "Sum"
+	metricTypeHistogram            = "Histogram"
+	metricTypeExponentialHistogram = "ExponentialHistogram"
+	metricTypeSummary              = "Summary"
 )
 
 // String is used both by fmt.Print and by Cobra in help text
@@ -22,11 +27,11 @@ func (e *metricType) String() string {
 // Set must have pointer receiver so it doesn't change the value of a copy
 func (e *metricType) Set(v string) error {
 	switch v {
-	case "Gauge", "Sum":
+	case metricTypeGauge, metricTypeSum, metricTypeHistogram, metricTypeExponentialHistogram, metricTypeSummary:
 		*e = metricType(v)
 		return nil
 	default:
-		return errors.New(`must be one of "Gauge" or "Sum"`)
+		return errors.New(`must be one of "Gauge", "Sum", "Histogram", "ExponentialHistogram" or "Summary"`)
 	}
 }
 
@@ -34,3 +39,68 @@ func (e *metricType) Set(v string) error {
 func (e *metricType) Type() string {
 	return "metricType"
 }
+
+type temporality string
+
+const (
+	temporalityCumulative = "cumulative"
+	temporalityDelta      = "delta"
+)
+
+// String is used both by fmt.Print and by Cobra in help text
+func (t *temporality) String() string {
+	return string(*t)
+}
+
+// Set must have pointer receiver so it doesn't change the value of a copy
+func (t *temporality) Set(v string) error {
+	switch v {
+	case temporalityCumulative, temporalityDelta:
+		*t = temporality(v)
+		return nil
+	default:
+		return errors.New(`must be one of "cumulative" or "delta"`)
+	}
+}
+
+// Type is only used in help text
+func (t *temporality) Type() string {
+	return "temporality"
+}
+
+// metricdata returns the temporality of the generated metrics, cumulative by default.
+func (t temporality) metricdata() metricdata.Temporality {
+	if t == temporalityDelta {
+		return metricdata.DeltaTemporality
+	}
+	return metricdata.CumulativeTemporality
+}
+
+type distribution string
+
+const (
+	distributionNormal      = "normal"
+	distributionUniform     = "uniform"
+	distributionExponential = "exponential"
+)
+
+// String is used both by fmt.Print and by Cobra in help text
+func (d *distribution) String() string {
+	return string(*d)
+}
+
+// Set must have pointer receiver so it doesn't change the value of a copy
+func (d *distribution) Set(v string) error {
+	switch v {
+	case distributionNormal, distributionUniform, distributionExponential:
+		*d = distribution(v)
+		return nil
+	default:
+		return errors.New(`must be one of "normal", "uniform" or "exponential"`)
+	}
+}
+
+// Type is only used in help text
+func (d *distribution) Type() string {
+	return "distribution"
+}
@@ -5,6 +5,7 @@ package metrics
 
 import (
 	"context"
+	"math/rand"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -25,6 +26,13 @@ type worker struct {
 	numMetrics     int                          // how many metrics the worker has to generate (only when duration==0)
 	totalDuration  time.Duration                // how long to run the test for (overrides `numMetrics`)
 	limitPerSecond rate.Limit                   // how many metrics per second to generate
+	temporality    metricdata.Temporality       // temporality of the sums and histograms
+	cardinality    int                          // how many time series to generate
+	sample         sampler                      // distribution of the values observed by the histograms and summaries
+	observations   int                          // how many values are observed for each histogram and summary data point
+	bounds         []float64                    // bucket boundaries of the histograms
+	scale          int32                        // scale of the exponential histograms
+	quantiles      []float64                    // quantiles of the summaries
 	wg             *sync.WaitGroup              // notify when done
 	logger         *zap.Logger                  // logger
 	index          int                          // worker index
@@ -46,46 +54,94 @@ func (w worker) simulateMetrics(res *resource.Resource, exporterFunc func() (sdk
 		}
 	}()
 
+	// #nosec G404 -- the observed values don't need a cryptographically secure random number generator
+	r := rand.New(rand.NewSource(time.Now().UnixNano() + int64(w.index)))
+	allSeries := newSeries(w.cardinality, signalAttrs, time.Now())
+
 	var i int64
 	for w.running.Load() {
+		now := time.Now()
 		var metrics []metricdata.Metrics
 
 		switch w.metricType {
 		case metricTypeGauge:
-			metrics = append(metrics, metricdata.Metrics{
-				Name: w.metricName,
-				Data: metricdata.Gauge[int64]{
-					DataPoints: []metricdata.DataPoint[int64]{
-						{
-							Time:       time.Now(),
-							Value:      i,
-							Attributes: attribute.NewSet(signalAttrs...),
-							Exemplars:  w.exemplars,
-						},
-					},
-				},
-			})
+			gauge := metricdata.Gauge[int64]{}
+			for _, s := range allSeries {
+				gauge.DataPoints = append(gauge.DataPoints, metricdata.DataPoint[int64]{
+					Time:       now,
+					Value:      i,
+					Attributes: s.attrs,
+					Exemplars:  w.exemplars,
+				})
+			}
+			metrics = append(metrics, metricdata.Metrics{Name: w.metricName, Data: gauge})
 		case metricTypeSum:
-			metrics = append(metrics, metricdata.Metrics{
-				Name: w.metricName,
-				Data: metricdata.Sum[int64]{
-					IsMonotonic: true,
-					Temporality: metricdata.CumulativeTemporality,
-					DataPoints: []metricdata.DataPoint[int64]{
-						{
-							StartTime:  time.Now().Add(-1 * time.Second),
-							Time:       time.Now(),
-							Value:      i,
-							Attributes: attribute.NewSet(signalAttrs...),
-							Exemplars:  w.exemplars,
-						},
-					},
-				},
-			})
+			// The sum is incremented by one on every export after the first one.
+			value := i
+			if w.temporality == metricdata.DeltaTemporality {
+				value = min(i, 1)
+			}
+			sum := metricdata.Sum[int64]{
+				IsMonotonic: true,
+				Temporality: w.temporality,
+			}
+			for _, s := range allSeries {
+				sum.DataPoints = append(sum.DataPoints, metricdata.DataPoint[int64]{
+					StartTime:  s.start,
+					Time:       now,
+					Value:      value,
+					Attributes: s.attrs,
+					Exemplars:  w.exemplars,
+				})
+			}
+			metrics = append(metrics, metricdata.Metrics{Name: w.metricName, Data: sum})
+		case metricTypeHistogram:
+			histogram := metricdata.Histogram[float64]{Temporality: w.temporality}
+			for _, s := range allSeries {
+				var v float64
+				for j := 0; j < w.observations; j++ {
+					v = w.sample(r)
+					s.observeHistogram(v, w.bounds)
+				}
+				dp := s.histogramDataPoint(now, w.bounds)
+				dp.Exemplars = histogramExemplars(w.exemplars, v)
+				histogram.DataPoints = append(histogram.DataPoints, dp)
+			}
+			metrics = append(metrics, metricdata.Metrics{Name: w.metricName, Data: histogram})
+		case metricTypeExponentialHistogram:
+			histogram := metricdata.ExponentialHistogram[float64]{Temporality: w.temporality}
+			for _, s := range allSeries {
+				var v float64
+				for j := 0; j < w.observations; j++ {
+					v = w.sample(r)
+					s.observeExponentialHistogram(v, w.scale)
+				}
+				dp := s.exponentialHistogramDataPoint(now, w.scale)
+				dp.Exemplars = histogramExemplars(w.exemplars, v)
+				histogram.DataPoints = append(histogram.DataPoints, dp)
+			}
+			metrics = append(metrics, metricdata.Metrics{Name: w.metricName, Data: histogram})
+		case metricTypeSummary:
+			// The count and sum of the summaries are cumulative, like in Prometheus.
+			summary := metricdata.Summary{}
+			for _, s := range allSeries {
+				for j := 0; j < w.observations; j++ {
+					s.observeSummary(w.sample(r))
+				}
+				summary.DataPoints = append(summary.DataPoints, s.summaryDataPoint(now, w.quantiles))
+			}
+			metrics = append(metrics, metricdata.Metrics{Name: w.metricName, Data: summary})
 		default:
 			w.logger.Fatal("unknown metric type")
 		}
 
+		if w.temporality == metricdata.DeltaTemporality && w.metricType != metricTypeSummary {
+			for _, s := range allSeries {
+				s.start = now
+				s.reset()
+			}
+		}
+
 		rm := metricdata.ResourceMetrics{
 			Resource:     res,
 			ScopeMetrics: []metricdata.ScopeMetrics{{Metrics: metrics}},
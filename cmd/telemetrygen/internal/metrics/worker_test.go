@@ -5,6 +5,8 @@ package metrics
 
 import (
 	"context"
+	"encoding/hex"
+	"strconv"
 	"testing"
 	"time"
 
@@ -300,6 +302,216 @@ func TestGaugeMultipleTelemetryAttr(t *testing.T) {
 	}
 }
 
+func TestAttributeCardinality(t *testing.T) {
+	cfg := configWithOneAttribute(metricTypeGauge, 1)
+	cfg.AttributeCardinality = 3
+	m := &mockExporter{}
+	expFunc := func() (sdkmetric.Exporter, error) {
+		return m, nil
+	}
+
+	require.NoError(t, Run(cfg, expFunc, zap.NewNop()))
+
+	require.Len(t, m.rms, 1)
+	dps := m.rms[0].ScopeMetrics[0].Metrics[0].Data.(metricdata.Gauge[int64]).DataPoints
+	require.Len(t, dps, 3)
+	for i, dp := range dps {
+		series, ok := dp.Attributes.Value(seriesAttributeKey)
+		require.True(t, ok)
+		assert.Equal(t, strconv.Itoa(i), series.AsString())
+		_, ok = dp.Attributes.Value(telemetryAttrKeyOne)
+		assert.True(t, ok)
+	}
+}
+
+func TestSumTemporality(t *testing.T) {
+	for _, tt := range []struct {
+		temporality temporality
+		expected    metricdata.Temporality
+		values      []int64
+	}{
+		{temporality: temporalityCumulative, expected: metricdata.CumulativeTemporality, values: []int64{0, 1, 2}},
+		{temporality: temporalityDelta, expected: metricdata.DeltaTemporality, values: []int64{0, 1, 1}},
+	} {
+		t.Run(string(tt.temporality), func(t *testing.T) {
+			cfg := configWithNoAttributes(metricTypeSum, 3)
+			cfg.Temporality = tt.temporality
+			m := &mockExporter{}
+			expFunc := func() (sdkmetric.Exporter, error) {
+				return m, nil
+			}
+
+			require.NoError(t, Run(cfg, expFunc, zap.NewNop()))
+
+			require.Len(t, m.rms, 3)
+			var sums []metricdata.Sum[int64]
+			for i, rm := range m.rms {
+				sum := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+				assert.Equal(t, tt.expected, sum.Temporality)
+				assert.Equal(t, tt.values[i], sum.DataPoints[0].Value)
+				sums = append(sums, sum)
+			}
+			if tt.temporality == temporalityDelta {
+				assert.Equal(t, sums[0].DataPoints[0].Time, sums[1].DataPoints[0].StartTime)
+			} else {
+				assert.Equal(t, sums[0].DataPoints[0].StartTime, sums[1].DataPoints[0].StartTime)
+			}
+		})
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	for _, tt := range []struct {
+		temporality temporality
+		counts      []uint64
+	}{
+		{temporality: temporalityCumulative, counts: []uint64{10, 20}},
+		{temporality: temporalityDelta, counts: []uint64{10, 10}},
+	} {
+		t.Run(string(tt.temporality), func(t *testing.T) {
+			cfg := configWithNoAttributes(metricTypeHistogram, 2)
+			cfg.Temporality = tt.temporality
+			cfg.Distribution = distributionUniform
+			cfg.DistributionMin = 0
+			cfg.DistributionMax = 50
+			cfg.Observations = 10
+			cfg.HistogramBounds = []float64{10, 100}
+			m := &mockExporter{}
+			expFunc := func() (sdkmetric.Exporter, error) {
+				return m, nil
+			}
+
+			require.NoError(t, Run(cfg, expFunc, zap.NewNop()))
+
+			require.Len(t, m.rms, 2)
+			for i, rm := range m.rms {
+				histogram := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[float64])
+				dp := histogram.DataPoints[0]
+				assert.Equal(t, tt.counts[i], dp.Count)
+				assert.Equal(t, []float64{10, 100}, dp.Bounds)
+				require.Len(t, dp.BucketCounts, 3)
+				assert.Equal(t, dp.Count, dp.BucketCounts[0]+dp.BucketCounts[1])
+				assert.Zero(t, dp.BucketCounts[2])
+				minValue, ok := dp.Min.Value()
+				require.True(t, ok)
+				maxValue, ok := dp.Max.Value()
+				require.True(t, ok)
+				assert.True(t, minValue >= 0 && maxValue <= 50)
+				assert.True(t, dp.Sum >= minValue*float64(dp.Count) && dp.Sum <= maxValue*float64(dp.Count))
+			}
+		})
+	}
+}
+
+func TestExponentialHistogram(t *testing.T) {
+	cfg := configWithNoAttributes(metricTypeExponentialHistogram, 2)
+	cfg.Distribution = distributionExponential
+	cfg.DistributionMean = 100
+	cfg.Observations = 50
+	cfg.ExponentialHistogramScale = 2
+	m := &mockExporter{}
+	expFunc := func() (sdkmetric.Exporter, error) {
+		return m, nil
+	}
+
+	require.NoError(t, Run(cfg, expFunc, zap.NewNop()))
+
+	require.Len(t, m.rms, 2)
+	dp := m.rms[1].ScopeMetrics[0].Metrics[0].Data.(metricdata.ExponentialHistogram[float64]).DataPoints[0]
+	assert.Equal(t, uint64(100), dp.Count)
+	assert.Equal(t, int32(2), dp.Scale)
+	total := dp.ZeroCount
+	for _, count := range dp.PositiveBucket.Counts {
+		total += count
+	}
+	assert.Equal(t, dp.Count, total)
+	maxValue, ok := dp.Max.Value()
+	require.True(t, ok)
+	assert.Equal(t, exponentialBucketIndex(maxValue, 2), dp.PositiveBucket.Offset+int32(len(dp.PositiveBucket.Counts))-1)
+}
+
+func TestSummary(t *testing.T) {
+	cfg := configWithNoAttributes(metricTypeSummary, 2)
+	cfg.Distribution = distributionNormal
+	cfg.DistributionMean = 100
+	cfg.DistributionStdDev = 10
+	cfg.Observations = 100
+	cfg.SummaryQuantiles = []float64{0, 0.5, 1}
+	m := &mockExporter{}
+	expFunc := func() (sdkmetric.Exporter, error) {
+		return m, nil
+	}
+
+	require.NoError(t, Run(cfg, expFunc, zap.NewNop()))
+
+	require.Len(t, m.rms, 2)
+	dp := m.rms[1].ScopeMetrics[0].Metrics[0].Data.(metricdata.Summary).DataPoints[0]
+	assert.Equal(t, uint64(200), dp.Count)
+	require.Len(t, dp.QuantileValues, 3)
+	assert.LessOrEqual(t, dp.QuantileValues[0].Value, dp.QuantileValues[1].Value)
+	assert.LessOrEqual(t, dp.QuantileValues[1].Value, dp.QuantileValues[2].Value)
+	assert.InDelta(t, 100, dp.QuantileValues[1].Value, 10)
+}
+
+func Test_exponentialBucketIndex(t *testing.T) {
+	for _, tt := range []struct {
+		value float64
+		scale int32
+		index int32
+	}{
+		// the exact powers of two are the upper bounds of their buckets
+		{value: 1, scale: 0, index: -1},
+		{value: 2, scale: 0, index: 0},
+		{value: 4, scale: 0, index: 1},
+		{value: 1, scale: 3, index: -1},
+		{value: 2, scale: 3, index: 7},
+		{value: 4, scale: 3, index: 15},
+		{value: 4, scale: -1, index: 0},
+		{value: 16, scale: -1, index: 1},
+		{value: 0.25, scale: -1, index: -2},
+		{value: 3, scale: 0, index: 1},
+		{value: 5, scale: -1, index: 1},
+		{value: 2, scale: 1, index: 1},
+		{value: 2.5, scale: 1, index: 2},
+		{value: 0.75, scale: 0, index: -1},
+	} {
+		assert.Equal(t, tt.index, exponentialBucketIndex(tt.value, tt.scale), "value %v at scale %d", tt.value, tt.scale)
+	}
+}
+
+func TestHistogramExemplars(t *testing.T) {
+	for _, metricType := range []metricType{metricTypeHistogram, metricTypeExponentialHistogram} {
+		t.Run(string(metricType), func(t *testing.T) {
+			cfg := configWithNoAttributes(metricType, 1)
+			cfg.Distribution = distributionUniform
+			cfg.DistributionMin = 10
+			cfg.DistributionMax = 20
+			cfg.Observations = 5
+			cfg.TraceID = "ae87dadd90e9935a4bc9660628efd569"
+			cfg.SpanID = "5828fa4960140870"
+			m := &mockExporter{}
+			expFunc := func() (sdkmetric.Exporter, error) {
+				return m, nil
+			}
+
+			require.NoError(t, Run(cfg, expFunc, zap.NewNop()))
+
+			require.Len(t, m.rms, 1)
+			var exemplars []metricdata.Exemplar[float64]
+			switch data := m.rms[0].ScopeMetrics[0].Metrics[0].Data.(type) {
+			case metricdata.Histogram[float64]:
+				exemplars = data.DataPoints[0].Exemplars
+			case metricdata.ExponentialHistogram[float64]:
+				exemplars = data.DataPoints[0].Exemplars
+			}
+			require.Len(t, exemplars, 1)
+			assert.Equal(t, "ae87dadd90e9935a4bc9660628efd569", hex.EncodeToString(exemplars[0].TraceID))
+			assert.Equal(t, "5828fa4960140870", hex.EncodeToString(exemplars[0].SpanID))
+			assert.True(t, exemplars[0].Value >= 10 && exemplars[0].Value <= 20)
+		})
+	}
+}
+
 func configWithNoAttributes(metric metricType, qty int) *Config {
 	return &Config{
 		Config: common.Config{
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package replay
+
+import (
+	"errors"
+
+	"github.com/spf13/pflag"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+// Config describes the replay of a recording.
+type Config struct {
+	common.Config
+	File              string
+	Speed             float64
+	RewriteIDs        bool
+	RewriteTimestamps bool
+}
+
+// Flags registers config flags.
+func (c *Config) Flags(fs *pflag.FlagSet) {
+	c.CommonFlags(fs)
+
+	fs.StringVar(&c.File, "file", "", "OTLP JSON file to replay, with one export request per line like the files written by the file exporter")
+	fs.Float64Var(&c.Speed, "speed", 1, "Speed of the replay relative to the recording, e.g. 2 replays it twice as fast. Zero replays it as fast as possible.")
+	fs.BoolVar(&c.RewriteIDs, "rewrite-ids", true, "Whether to replace the trace and span IDs with new random ones on each replay")
+	fs.BoolVar(&c.RewriteTimestamps, "rewrite-timestamps", true, "Whether to shift the timestamps to the time they are replayed at")
+}
+
+// Validate validates the replay parameters.
+func (c *Config) Validate() error {
+	if c.File == "" {
+		return errors.New("`file` must be set")
+	}
+	if c.Speed < 0 {
+		return errors.New("`speed` must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// record is a recorded export request, holding the telemetry of one signal.
+type record struct {
+	traces  *ptrace.Traces
+	metrics *pmetric.Metrics
+	logs    *plog.Logs
+	// start is the earliest timestamp of the telemetry, used to replay the records with their original timing.
+	// It is zero if the telemetry has no timestamps.
+	start pcommon.Timestamp
+}
+
+// loadRecording reads the export requests of an OTLP JSON file, one per line.
+func loadRecording(path string) ([]record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []record
+	r := bufio.NewReader(f)
+	for lineNum := 1; ; lineNum++ {
+		line, err := r.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			rec, parseErr := parseRecord(line)
+			if parseErr != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNum, parseErr)
+			}
+			records = append(records, rec)
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no telemetry found in %s", path)
+	}
+	return records, nil
+}
+
+func parseRecord(line []byte) (record, error) {
+	// The unmarshalers ignore the unknown fields, so the signal is detected from the top-level field.
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(line, &fields); err != nil {
+		return record{}, err
+	}
+
+	switch {
+	case fields["resourceSpans"] != nil:
+		td, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(line)
+		if err != nil {
+			return record{}, err
+		}
+		return record{traces: &td, start: tracesStart(td)}, nil
+	case fields["resourceMetrics"] != nil:
+		md, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(line)
+		if err != nil {
+			return record{}, err
+		}
+		return record{metrics: &md, start: metricsStart(md)}, nil
+	case fields["resourceLogs"] != nil:
+		ld, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(line)
+		if err != nil {
+			return record{}, err
+		}
+		return record{logs: &ld, start: logsStart(ld)}, nil
+	default:
+		return record{}, errors.New("expected resourceSpans, resourceMetrics or resourceLogs")
+	}
+}
+
+func earliest(start, ts pcommon.Timestamp) pcommon.Timestamp {
+	if ts != 0 && (start == 0 || ts < start) {
+		return ts
+	}
+	return start
+}
+
+func tracesStart(td ptrace.Traces) pcommon.Timestamp {
+	var start pcommon.Timestamp
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		ilss := td.ResourceSpans().At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				start = earliest(start, spans.At(k).StartTimestamp())
+			}
+		}
+	}
+	return start
+}
+
+// metricsStart uses the timestamps of the data points rather than their start timestamps,
+// which are the start of the process for cumulative metrics.
+func metricsStart(md pmetric.Metrics) pcommon.Timestamp {
+	var start pcommon.Timestamp
+	forEachMetric(md, func(m pmetric.Metric) {
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			for i := 0; i < m.Gauge().DataPoints().Len(); i++ {
+				start = earliest(start, m.Gauge().DataPoints().At(i).Timestamp())
+			}
+		case pmetric.MetricTypeSum:
+			for i := 0; i < m.Sum().DataPoints().Len(); i++ {
+				start = earliest(start, m.Sum().DataPoints().At(i).Timestamp())
+			}
+		case pmetric.MetricTypeHistogram:
+			for i := 0; i < m.Histogram().DataPoints().Len(); i++ {
+				start = earliest(start, m.Histogram().DataPoints().At(i).Timestamp())
+			}
+		case pmetric.MetricTypeExponentialHistogram:
+			for i := 0; i < m.ExponentialHistogram().DataPoints().Len(); i++ {
+				start = earliest(start, m.ExponentialHistogram().DataPoints().At(i).Timestamp())
+			}
+		case pmetric.MetricTypeSummary:
+			for i := 0; i < m.Summary().DataPoints().Len(); i++ {
+				start = earliest(start, m.Summary().DataPoints().At(i).Timestamp())
+			}
+		}
+	})
+	return start
+}
+
+func logsStart(ld plog.Logs) pcommon.Timestamp {
+	var start pcommon.Timestamp
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		sls := ld.ResourceLogs().At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				ts := lrs.At(k).Timestamp()
+				if ts == 0 {
+					ts = lrs.At(k).ObservedTimestamp()
+				}
+				start = earliest(start, ts)
+			}
+		}
+	}
+	return start
+}
+
+func forEachMetric(md pmetric.Metrics, f func(pmetric.Metric)) {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		sms := md.ResourceMetrics().At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				f(ms.At(k))
+			}
+		}
+	}
+}
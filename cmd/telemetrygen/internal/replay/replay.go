@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package replay
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+type exporter interface {
+	ExportTraces(context.Context, ptrace.Traces) error
+	ExportMetrics(context.Context, pmetric.Metrics) error
+	ExportLogs(context.Context, plog.Logs) error
+}
+
+// Start starts the replay of the recording
+func Start(cfg *Config) error {
+	logger, err := common.CreateLogger(cfg.SkipSettingGRPCLogger)
+	if err != nil {
+		return err
+	}
+
+	if err = cfg.Validate(); err != nil {
+		logger.Error("failed to validate the parameters for the replay.", zap.Error(err))
+		return err
+	}
+
+	recording, err := loadRecording(cfg.File)
+	if err != nil {
+		logger.Error("failed to load the recording.", zap.Error(err))
+		return err
+	}
+
+	e, err := common.NewExporter(&cfg.Config)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		logger.Info("stopping the exporter")
+		if tempError := e.Shutdown(); tempError != nil {
+			logger.Error("failed to stop the exporter", zap.Error(tempError))
+		}
+	}()
+
+	Run(cfg, recording, e, logger)
+	return nil
+}
+
+// Run replays the recording once in each worker, or in a loop if a duration is set.
+func Run(c *Config, recording []record, exp exporter, logger *zap.Logger) {
+	if c.Speed == 0 {
+		logger.Info("replay of the recording isn't being throttled")
+	} else {
+		logger.Info("replaying the recording", zap.Float64("speed", c.Speed))
+	}
+
+	recordingStart := recording[0].start
+	for _, rec := range recording {
+		recordingStart = earliest(recordingStart, rec.start)
+	}
+
+	wg := sync.WaitGroup{}
+
+	running := &atomic.Bool{}
+	running.Store(true)
+
+	for i := 0; i < c.WorkerCount; i++ {
+		wg.Add(1)
+		w := worker{
+			recording:         recording,
+			recordingStart:    recordingStart,
+			speed:             c.Speed,
+			rewriteIDs:        c.RewriteIDs,
+			rewriteTimestamps: c.RewriteTimestamps,
+			loop:              c.TotalDuration > 0,
+			exporter:          exp,
+			running:           running,
+			wg:                &wg,
+			logger:            logger.With(zap.Int("worker", i)),
+		}
+
+		go w.replay()
+	}
+	if c.TotalDuration > 0 {
+		time.Sleep(c.TotalDuration)
+		running.Store(false)
+	}
+	wg.Wait()
+}
+
+type worker struct {
+	recording         []record
+	recordingStart    pcommon.Timestamp // earliest timestamp of the recording
+	speed             float64           // speed of the replay relative to the recording, zero to not throttle it
+	rewriteIDs        bool              // whether to replace the trace and span IDs
+	rewriteTimestamps bool              // whether to shift the timestamps to the replay time
+	loop              bool              // whether to replay the recording until the test is stopped
+	exporter          exporter
+	running           *atomic.Bool    // pointer to shared flag that indicates it's time to stop the test
+	wg                *sync.WaitGroup // notify when done
+	logger            *zap.Logger
+}
+
+func (w worker) replay() {
+	defer w.wg.Done()
+
+	var replays, records int
+	for w.running.Load() {
+		records += w.replayOnce()
+		replays++
+		if !w.loop {
+			break
+		}
+	}
+	w.logger.Info("recording replayed", zap.Int("replays", replays), zap.Int("records", records))
+}
+
+// replayOnce sends the records with the same timing as in the recording, scaled by the speed.
+// Every replay uses new IDs so that the replayed traces are distinct.
+func (w worker) replayOnce() int {
+	replayStart := time.Now()
+	r := newRewriter(w.rewriteIDs)
+
+	var sent int
+	for _, rec := range w.recording {
+		if w.speed > 0 && rec.start != 0 {
+			offset := time.Duration(float64(rec.start-w.recordingStart) / w.speed)
+			w.sleepUntil(replayStart.Add(offset))
+		}
+		if !w.running.Load() {
+			break
+		}
+
+		if w.rewriteTimestamps && rec.start != 0 {
+			r.shift = time.Now().UnixNano() - int64(rec.start)
+		}
+		if err := w.export(rec, r); err != nil {
+			w.logger.Error("failed to export the record", zap.Error(err))
+			continue
+		}
+		sent++
+	}
+	return sent
+}
+
+func (w worker) sleepUntil(t time.Time) {
+	const step = 100 * time.Millisecond
+	for w.running.Load() {
+		d := time.Until(t)
+		if d <= 0 {
+			return
+		}
+		time.Sleep(min(d, step))
+	}
+}
+
+// export exports a copy of the record, so that the recording is kept intact for the next replays.
+func (w worker) export(rec record, r *rewriter) error {
+	ctx := context.Background()
+	switch {
+	case rec.traces != nil:
+		td := ptrace.NewTraces()
+		rec.traces.CopyTo(td)
+		r.traces(td)
+		return w.exporter.ExportTraces(ctx, td)
+	case rec.metrics != nil:
+		md := pmetric.NewMetrics()
+		rec.metrics.CopyTo(md)
+		r.metrics(md)
+		return w.exporter.ExportMetrics(ctx, md)
+	default:
+		ld := plog.NewLogs()
+		rec.logs.CopyTo(ld)
+		r.logs(ld)
+		return w.exporter.ExportLogs(ctx, ld)
+	}
+}
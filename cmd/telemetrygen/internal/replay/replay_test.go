@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package replay
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+var recordedTraceID = pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+
+type mockExporter struct {
+	mu      sync.Mutex
+	traces  []ptrace.Traces
+	metrics []pmetric.Metrics
+	logs    []plog.Logs
+}
+
+func (m *mockExporter) ExportTraces(_ context.Context, td ptrace.Traces) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.traces = append(m.traces, td)
+	return nil
+}
+
+func (m *mockExporter) ExportMetrics(_ context.Context, md pmetric.Metrics) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.metrics = append(m.metrics, md)
+	return nil
+}
+
+func (m *mockExporter) ExportLogs(_ context.Context, ld plog.Logs) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.logs = append(m.logs, ld)
+	return nil
+}
+
+func loadTestRecording(t *testing.T) []record {
+	recording, err := loadRecording(filepath.Join("testdata", "recording.json"))
+	require.NoError(t, err)
+	return recording
+}
+
+func TestLoadRecording(t *testing.T) {
+	recording := loadTestRecording(t)
+	require.Len(t, recording, 3)
+
+	require.NotNil(t, recording[0].traces)
+	assert.Equal(t, pcommon.Timestamp(1700000000000000000), recording[0].start)
+	require.NotNil(t, recording[1].metrics)
+	assert.Equal(t, pcommon.Timestamp(1700000000500000000), recording[1].start)
+	require.NotNil(t, recording[2].logs)
+	assert.Equal(t, pcommon.Timestamp(1700000001000000000), recording[2].start)
+
+	_, err := loadRecording(filepath.Join("testdata", "invalid.json"))
+	assert.EqualError(t, err, "line 1: expected resourceSpans, resourceMetrics or resourceLogs")
+
+	_, err = loadRecording(filepath.Join("testdata", "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestReplay(t *testing.T) {
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 2,
+		},
+		RewriteIDs:        true,
+		RewriteTimestamps: true,
+	}
+	exp := &mockExporter{}
+
+	before := time.Now()
+	Run(cfg, loadTestRecording(t), exp, zap.NewNop())
+
+	require.Len(t, exp.traces, 2)
+	require.Len(t, exp.metrics, 2)
+	require.Len(t, exp.logs, 2)
+
+	traceIDs := map[pcommon.TraceID]bool{}
+	for _, td := range exp.traces {
+		spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+		parent, child := spans.At(0), spans.At(1)
+		assert.NotEqual(t, recordedTraceID, parent.TraceID())
+		assert.Equal(t, parent.TraceID(), child.TraceID())
+		assert.Equal(t, parent.SpanID(), child.ParentSpanID())
+		assert.True(t, parent.StartTimestamp().AsTime().After(before))
+		assert.Equal(t, 200*time.Millisecond, parent.EndTimestamp().AsTime().Sub(parent.StartTimestamp().AsTime()))
+		assert.Equal(t, 50*time.Millisecond, child.StartTimestamp().AsTime().Sub(parent.StartTimestamp().AsTime()))
+		traceIDs[parent.TraceID()] = true
+	}
+	assert.Len(t, traceIDs, 2, "each replay should use new trace IDs")
+
+	for _, md := range exp.metrics {
+		dp := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+		assert.True(t, dp.Timestamp().AsTime().After(before))
+		assert.Equal(t, 1000500*time.Millisecond, dp.Timestamp().AsTime().Sub(dp.StartTimestamp().AsTime()))
+		assert.True(t, traceIDs[dp.Exemplars().At(0).TraceID()])
+	}
+
+	for _, ld := range exp.logs {
+		lr := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+		assert.True(t, lr.Timestamp().AsTime().After(before))
+		assert.True(t, traceIDs[lr.TraceID()])
+	}
+}
+
+func TestReplayWithoutRewriting(t *testing.T) {
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+		},
+	}
+	exp := &mockExporter{}
+	recording := loadTestRecording(t)
+
+	Run(cfg, recording, exp, zap.NewNop())
+
+	require.Len(t, exp.traces, 1)
+	assert.Equal(t, *recording[0].traces, exp.traces[0])
+	require.Len(t, exp.metrics, 1)
+	assert.Equal(t, *recording[1].metrics, exp.metrics[0])
+	require.Len(t, exp.logs, 1)
+	assert.Equal(t, *recording[2].logs, exp.logs[0])
+}
+
+func TestReplaySpeed(t *testing.T) {
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+		},
+		Speed:             4,
+		RewriteTimestamps: true,
+	}
+	exp := &mockExporter{}
+
+	start := time.Now()
+	Run(cfg, loadTestRecording(t), exp, zap.NewNop())
+
+	// The recording lasts one second.
+	assert.GreaterOrEqual(t, time.Since(start), 250*time.Millisecond)
+	require.Len(t, exp.logs, 1)
+	span := exp.traces[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	lr := exp.logs[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.GreaterOrEqual(t, lr.Timestamp().AsTime().Sub(span.StartTimestamp().AsTime()), 250*time.Millisecond)
+}
+
+func TestReplayDuration(t *testing.T) {
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount:   1,
+			TotalDuration: 500 * time.Millisecond,
+		},
+		Speed: 10,
+	}
+	exp := &mockExporter{}
+
+	Run(cfg, loadTestRecording(t), exp, zap.NewNop())
+
+	assert.Greater(t, len(exp.traces), 1, "the recording should be replayed in a loop")
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package replay
+
+import (
+	"crypto/rand"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// rewriter shifts the timestamps and replaces the IDs of the replayed telemetry. The same recorded ID is
+// replaced by the same new ID, so that the spans, span links, exemplars and logs of a trace stay related.
+type rewriter struct {
+	shift      int64 // nanoseconds added to the timestamps
+	rewriteIDs bool
+	traceIDs   map[pcommon.TraceID]pcommon.TraceID
+	spanIDs    map[pcommon.SpanID]pcommon.SpanID
+}
+
+func newRewriter(rewriteIDs bool) *rewriter {
+	return &rewriter{
+		rewriteIDs: rewriteIDs,
+		traceIDs:   map[pcommon.TraceID]pcommon.TraceID{},
+		spanIDs:    map[pcommon.SpanID]pcommon.SpanID{},
+	}
+}
+
+func (r *rewriter) timestamp(ts pcommon.Timestamp) pcommon.Timestamp {
+	if ts == 0 {
+		return ts
+	}
+	return pcommon.Timestamp(int64(ts) + r.shift)
+}
+
+func (r *rewriter) traceID(id pcommon.TraceID) pcommon.TraceID {
+	if !r.rewriteIDs || id.IsEmpty() {
+		return id
+	}
+	newID, ok := r.traceIDs[id]
+	if !ok {
+		_, _ = rand.Read(newID[:])
+		r.traceIDs[id] = newID
+	}
+	return newID
+}
+
+func (r *rewriter) spanID(id pcommon.SpanID) pcommon.SpanID {
+	if !r.rewriteIDs || id.IsEmpty() {
+		return id
+	}
+	newID, ok := r.spanIDs[id]
+	if !ok {
+		_, _ = rand.Read(newID[:])
+		r.spanIDs[id] = newID
+	}
+	return newID
+}
+
+func (r *rewriter) traces(td ptrace.Traces) {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		ilss := td.ResourceSpans().At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				span.SetTraceID(r.traceID(span.TraceID()))
+				span.SetSpanID(r.spanID(span.SpanID()))
+				span.SetParentSpanID(r.spanID(span.ParentSpanID()))
+				span.SetStartTimestamp(r.timestamp(span.StartTimestamp()))
+				span.SetEndTimestamp(r.timestamp(span.EndTimestamp()))
+				for l := 0; l < span.Events().Len(); l++ {
+					event := span.Events().At(l)
+					event.SetTimestamp(r.timestamp(event.Timestamp()))
+				}
+				for l := 0; l < span.Links().Len(); l++ {
+					link := span.Links().At(l)
+					link.SetTraceID(r.traceID(link.TraceID()))
+					link.SetSpanID(r.spanID(link.SpanID()))
+				}
+			}
+		}
+	}
+}
+
+func (r *rewriter) metrics(md pmetric.Metrics) {
+	forEachMetric(md, func(m pmetric.Metric) {
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			r.numberDataPoints(m.Gauge().DataPoints())
+		case pmetric.MetricTypeSum:
+			r.numberDataPoints(m.Sum().DataPoints())
+		case pmetric.MetricTypeHistogram:
+			for i := 0; i < m.Histogram().DataPoints().Len(); i++ {
+				dp := m.Histogram().DataPoints().At(i)
+				dp.SetStartTimestamp(r.timestamp(dp.StartTimestamp()))
+				dp.SetTimestamp(r.timestamp(dp.Timestamp()))
+				r.exemplars(dp.Exemplars())
+			}
+		case pmetric.MetricTypeExponentialHistogram:
+			for i := 0; i < m.ExponentialHistogram().DataPoints().Len(); i++ {
+				dp := m.ExponentialHistogram().DataPoints().At(i)
+				dp.SetStartTimestamp(r.timestamp(dp.StartTimestamp()))
+				dp.SetTimestamp(r.timestamp(dp.Timestamp()))
+				r.exemplars(dp.Exemplars())
+			}
+		case pmetric.MetricTypeSummary:
+			for i := 0; i < m.Summary().DataPoints().Len(); i++ {
+				dp := m.Summary().DataPoints().At(i)
+				dp.SetStartTimestamp(r.timestamp(dp.StartTimestamp()))
+				dp.SetTimestamp(r.timestamp(dp.Timestamp()))
+			}
+		}
+	})
+}
+
+func (r *rewriter) numberDataPoints(dps pmetric.NumberDataPointSlice) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		dp.SetStartTimestamp(r.timestamp(dp.StartTimestamp()))
+		dp.SetTimestamp(r.timestamp(dp.Timestamp()))
+		r.exemplars(dp.Exemplars())
+	}
+}
+
+func (r *rewriter) exemplars(exemplars pmetric.ExemplarSlice) {
+	for i := 0; i < exemplars.Len(); i++ {
+		exemplar := exemplars.At(i)
+		exemplar.SetTimestamp(r.timestamp(exemplar.Timestamp()))
+		exemplar.SetTraceID(r.traceID(exemplar.TraceID()))
+		exemplar.SetSpanID(r.spanID(exemplar.SpanID()))
+	}
+}
+
+func (r *rewriter) logs(ld plog.Logs) {
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		sls := ld.ResourceLogs().At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				lr.SetTimestamp(r.timestamp(lr.Timestamp()))
+				lr.SetObservedTimestamp(r.timestamp(lr.ObservedTimestamp()))
+				lr.SetTraceID(r.traceID(lr.TraceID()))
+				lr.SetSpanID(r.spanID(lr.SpanID()))
+			}
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package scenario
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"gopkg.in/yaml.v3"
+)
+
+// Scenario describes the services of the simulated system and the traces going through them.
+type Scenario struct {
+	Services []Service `yaml:"services"`
+	Traces   []Trace   `yaml:"traces"`
+}
+
+// Service is a service of the simulated system, every service having its own resource.
+type Service struct {
+	Name               string         `yaml:"name"`
+	ResourceAttributes map[string]any `yaml:"resource_attributes"`
+}
+
+// Trace is a kind of trace generated by the scenario, such as the traces of a given endpoint.
+type Trace struct {
+	// Weight is the relative frequency of the trace among the traces of the scenario, 1 by default.
+	Weight int  `yaml:"weight"`
+	Root   Span `yaml:"root"`
+}
+
+// Span describes a span of a trace and its child spans.
+type Span struct {
+	Service    string         `yaml:"service"`
+	Name       string         `yaml:"name"`
+	Kind       string         `yaml:"kind"`
+	Duration   time.Duration  `yaml:"duration"`
+	Attributes map[string]any `yaml:"attributes"`
+	// ErrorRate is the probability of the span having an error status, between 0 and 1.
+	ErrorRate float64 `yaml:"error_rate"`
+	// Repeat is the number of times the span is generated in its parent, 1 by default.
+	Repeat int `yaml:"repeat"`
+	// Parallel starts all the child spans with the span, rather than one after the other.
+	Parallel bool   `yaml:"parallel"`
+	Children []Span `yaml:"children"`
+}
+
+var spanKinds = map[string]ptrace.SpanKind{
+	"":         ptrace.SpanKindInternal,
+	"internal": ptrace.SpanKindInternal,
+	"server":   ptrace.SpanKindServer,
+	"client":   ptrace.SpanKindClient,
+	"producer": ptrace.SpanKindProducer,
+	"consumer": ptrace.SpanKindConsumer,
+}
+
+// loadScenario reads and validates the scenario of the YAML file.
+func loadScenario(path string) (*Scenario, error) {
+	by, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Scenario{}
+	if err = yaml.Unmarshal(by, s); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", path, err)
+	}
+	if err = s.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Validate checks that the scenario is valid.
+func (s *Scenario) Validate() error {
+	if len(s.Services) == 0 {
+		return errors.New("no services defined")
+	}
+	if len(s.Traces) == 0 {
+		return errors.New("no traces defined")
+	}
+
+	services := make(map[string]bool, len(s.Services))
+	for _, svc := range s.Services {
+		if svc.Name == "" {
+			return errors.New("service without name")
+		}
+		if services[svc.Name] {
+			return fmt.Errorf("service %q defined more than once", svc.Name)
+		}
+		if err := pcommon.NewMap().FromRaw(svc.ResourceAttributes); err != nil {
+			return fmt.Errorf("service %q: invalid resource attributes: %w", svc.Name, err)
+		}
+		services[svc.Name] = true
+	}
+
+	for i, trace := range s.Traces {
+		if trace.Weight < 0 {
+			return fmt.Errorf("trace %d: weight must not be negative", i)
+		}
+		if err := trace.Root.validate(services); err != nil {
+			return fmt.Errorf("trace %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (s *Span) validate(services map[string]bool) error {
+	if s.Name == "" {
+		return errors.New("span without name")
+	}
+	if !services[s.Service] {
+		return fmt.Errorf("span %q: unknown service %q", s.Name, s.Service)
+	}
+	if _, ok := spanKinds[s.Kind]; !ok {
+		return fmt.Errorf("span %q: unknown kind %q", s.Name, s.Kind)
+	}
+	if s.Duration < 0 {
+		return fmt.Errorf("span %q: duration must not be negative", s.Name)
+	}
+	if s.ErrorRate < 0 || s.ErrorRate > 1 {
+		return fmt.Errorf("span %q: error_rate must be between 0 and 1", s.Name)
+	}
+	if s.Repeat < 0 {
+		return fmt.Errorf("span %q: repeat must not be negative", s.Name)
+	}
+	if err := pcommon.NewMap().FromRaw(s.Attributes); err != nil {
+		return fmt.Errorf("span %q: invalid attributes: %w", s.Name, err)
+	}
+	for i := range s.Children {
+		if err := s.Children[i].validate(services); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+type exporter interface {
+	ExportTraces(context.Context, ptrace.Traces) error
+}
+
+// Start starts the generation of the scenario traces
+func Start(cfg *Config) error {
+	logger, err := common.CreateLogger(cfg.SkipSettingGRPCLogger)
+	if err != nil {
+		return err
+	}
+
+	if err = cfg.Validate(); err != nil {
+		logger.Error("failed to validate the parameters for the test scenario.", zap.Error(err))
+		return err
+	}
+
+	s, err := loadScenario(cfg.File)
+	if err != nil {
+		logger.Error("failed to load the scenario.", zap.Error(err))
+		return err
+	}
+
+	e, err := common.NewExporter(&cfg.Config)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		logger.Info("stopping the exporter")
+		if tempError := e.Shutdown(); tempError != nil {
+			logger.Error("failed to stop the exporter", zap.Error(tempError))
+		}
+	}()
+
+	if err = Run(cfg, s, e, logger); err != nil {
+		logger.Error("failed to execute the test scenario.", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// Run executes the test scenario.
+func Run(c *Config, s *Scenario, exp exporter, logger *zap.Logger) error {
+	if c.TotalDuration > 0 {
+		c.NumTraces = 0
+	} else if c.NumTraces <= 0 {
+		return fmt.Errorf("either `traces` or `duration` must be greater than 0")
+	}
+
+	limit := rate.Limit(c.Rate)
+	if c.Rate == 0 {
+		limit = rate.Inf
+		logger.Info("generation of traces isn't being throttled")
+	} else {
+		logger.Info("generation of traces is limited", zap.Float64("per-second", float64(limit)))
+	}
+
+	wg := sync.WaitGroup{}
+
+	running := &atomic.Bool{}
+	running.Store(true)
+
+	for i := 0; i < c.WorkerCount; i++ {
+		wg.Add(1)
+		w := worker{
+			numTraces:      c.NumTraces,
+			limitPerSecond: limit,
+			// #nosec G404 -- the traces don't need a cryptographically secure random number generator
+			generator: newGenerator(s, c.ResourceAttributes, c.TelemetryAttributes, rand.New(rand.NewSource(time.Now().UnixNano()+int64(i)))),
+			exporter:  exp,
+			running:   running,
+			wg:        &wg,
+			logger:    logger.With(zap.Int("worker", i)),
+		}
+
+		go w.simulateTraces()
+	}
+	if c.TotalDuration > 0 {
+		time.Sleep(c.TotalDuration)
+		running.Store(false)
+	}
+	wg.Wait()
+	return nil
+}
@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package scenario
+
+import (
+	"context"
+	"math/rand"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+type mockExporter struct {
+	mu     sync.Mutex
+	traces []ptrace.Traces
+}
+
+func (m *mockExporter) ExportTraces(_ context.Context, td ptrace.Traces) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.traces = append(m.traces, td)
+	return nil
+}
+
+func TestLoadScenario(t *testing.T) {
+	s, err := loadScenario(filepath.Join("testdata", "scenario.yaml"))
+	require.NoError(t, err)
+	assert.Len(t, s.Services, 3)
+	require.Len(t, s.Traces, 2)
+	assert.Equal(t, 3, s.Traces[0].Weight)
+	assert.Equal(t, 20*time.Millisecond, s.Traces[0].Root.Duration)
+	assert.Equal(t, 0.05, s.Traces[1].Root.Children[0].Children[0].ErrorRate)
+
+	_, err = loadScenario(filepath.Join("testdata", "invalid.yaml"))
+	assert.ErrorContains(t, err, `trace 0: span "GET /": unknown service "backend"`)
+}
+
+func TestValidateScenario(t *testing.T) {
+	services := []Service{{Name: "frontend"}}
+	tests := []struct {
+		name     string
+		scenario Scenario
+		err      string
+	}{
+		{
+			name:     "No services",
+			scenario: Scenario{Traces: []Trace{{Root: Span{Service: "frontend", Name: "GET /"}}}},
+			err:      "no services defined",
+		},
+		{
+			name:     "No traces",
+			scenario: Scenario{Services: services},
+			err:      "no traces defined",
+		},
+		{
+			name: "Duplicate service",
+			scenario: Scenario{
+				Services: []Service{{Name: "frontend"}, {Name: "frontend"}},
+				Traces:   []Trace{{Root: Span{Service: "frontend", Name: "GET /"}}},
+			},
+			err: `service "frontend" defined more than once`,
+		},
+		{
+			name: "Unknown kind",
+			scenario: Scenario{
+				Services: services,
+				Traces:   []Trace{{Root: Span{Service: "frontend", Name: "GET /", Kind: "remote"}}},
+			},
+			err: `trace 0: span "GET /": unknown kind "remote"`,
+		},
+		{
+			name: "Invalid error rate",
+			scenario: Scenario{
+				Services: services,
+				Traces: []Trace{{Root: Span{Service: "frontend", Name: "GET /", Children: []Span{
+					{Service: "frontend", Name: "query", ErrorRate: 1.5},
+				}}}},
+			},
+			err: `trace 0: span "query": error_rate must be between 0 and 1`,
+		},
+		{
+			name: "Negative weight",
+			scenario: Scenario{
+				Services: services,
+				Traces:   []Trace{{Weight: -1, Root: Span{Service: "frontend", Name: "GET /"}}},
+			},
+			err: "trace 0: weight must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.scenario.Validate(), tt.err)
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	s, err := loadScenario(filepath.Join("testdata", "scenario.yaml"))
+	require.NoError(t, err)
+	// Only keep the checkout trace, and make its errors deterministic.
+	s.Traces = s.Traces[1:]
+	s.Traces[0].Root.Children[0].Children[0].ErrorRate = 1
+
+	g := newGenerator(s, map[string]string{"k8s.cluster.name": "test"}, map[string]string{"load.test": "true"}, rand.New(rand.NewSource(1)))
+	start := time.Now()
+	td := g.generate(start)
+
+	spans := map[string]ptrace.Span{}
+	spansPerService := map[string]int{}
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		service, ok := rs.Resource().Attributes().Get("service.name")
+		require.True(t, ok)
+		cluster, ok := rs.Resource().Attributes().Get("k8s.cluster.name")
+		require.True(t, ok)
+		assert.Equal(t, "test", cluster.Str())
+
+		ss := rs.ScopeSpans().At(0)
+		assert.Equal(t, scopeName, ss.Scope().Name())
+		spansPerService[service.Str()] = ss.Spans().Len()
+		for j := 0; j < ss.Spans().Len(); j++ {
+			span := ss.Spans().At(j)
+			spans[span.Name()] = span
+			loadTest, ok := span.Attributes().Get("load.test")
+			require.True(t, ok)
+			assert.Equal(t, "true", loadTest.Str())
+		}
+	}
+	assert.Equal(t, map[string]int{"frontend": 2, "checkout": 1, "postgres": 3}, spansPerService)
+
+	root := spans["POST /checkout"]
+	client := spans["POST checkout"]
+	placeOrder := spans["PlaceOrder"]
+	assert.True(t, root.ParentSpanID().IsEmpty())
+	assert.Equal(t, root.SpanID(), client.ParentSpanID())
+	assert.Equal(t, client.SpanID(), placeOrder.ParentSpanID())
+	assert.Equal(t, root.TraceID(), placeOrder.TraceID())
+	assert.Equal(t, ptrace.SpanKindServer, placeOrder.Kind())
+
+	// The root span lasts as long as its longest child.
+	assert.Equal(t, 100*time.Millisecond, root.EndTimestamp().AsTime().Sub(root.StartTimestamp().AsTime()))
+	assert.Equal(t, 90*time.Millisecond, placeOrder.EndTimestamp().AsTime().Sub(placeOrder.StartTimestamp().AsTime()))
+	assert.Equal(t, ptrace.StatusCodeError, placeOrder.Status().Code())
+	assert.Equal(t, ptrace.StatusCodeUnset, root.Status().Code())
+
+	// The repeated spans are sequential.
+	var inserts []ptrace.Span
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		if service, _ := rs.Resource().Attributes().Get("service.name"); service.Str() == "postgres" {
+			dbSystem, ok := rs.Resource().Attributes().Get("db.system")
+			require.True(t, ok)
+			assert.Equal(t, "postgresql", dbSystem.Str())
+			for j := 0; j < rs.ScopeSpans().At(0).Spans().Len(); j++ {
+				inserts = append(inserts, rs.ScopeSpans().At(0).Spans().At(j))
+			}
+		}
+	}
+	require.Len(t, inserts, 3)
+	for i, insert := range inserts {
+		assert.Equal(t, placeOrder.SpanID(), insert.ParentSpanID())
+		assert.Equal(t, time.Duration(i)*10*time.Millisecond, insert.StartTimestamp().AsTime().Sub(placeOrder.StartTimestamp().AsTime()))
+	}
+}
+
+func TestGenerateParallel(t *testing.T) {
+	s := &Scenario{
+		Services: []Service{{Name: "frontend"}},
+		Traces: []Trace{{Root: Span{
+			Service:  "frontend",
+			Name:     "GET /",
+			Parallel: true,
+			Children: []Span{{Service: "frontend", Name: "fetch", Duration: 30 * time.Millisecond, Repeat: 3}},
+		}}},
+	}
+	require.NoError(t, s.Validate())
+
+	td := newGenerator(s, nil, nil, rand.New(rand.NewSource(1))).generate(time.Now())
+
+	spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	require.Equal(t, 4, spans.Len())
+	root := spans.At(0)
+	assert.Equal(t, 30*time.Millisecond, root.EndTimestamp().AsTime().Sub(root.StartTimestamp().AsTime()))
+	for i := 1; i < spans.Len(); i++ {
+		assert.Equal(t, root.StartTimestamp(), spans.At(i).StartTimestamp())
+	}
+}
+
+func TestGenerateWeights(t *testing.T) {
+	s, err := loadScenario(filepath.Join("testdata", "scenario.yaml"))
+	require.NoError(t, err)
+
+	g := newGenerator(s, nil, nil, rand.New(rand.NewSource(1)))
+	counts := map[int]int{}
+	for i := 0; i < 1000; i++ {
+		counts[g.generate(time.Now()).SpanCount()]++
+	}
+
+	// The cart traces have a single span, the checkout ones six, and are three times less frequent.
+	assert.Equal(t, 1000, counts[1]+counts[6])
+	assert.InDelta(t, 750, counts[1], 60)
+}
+
+func TestFixedNumberOfTraces(t *testing.T) {
+	s, err := loadScenario(filepath.Join("testdata", "scenario.yaml"))
+	require.NoError(t, err)
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 2,
+		},
+		NumTraces: 5,
+	}
+	exp := &mockExporter{}
+
+	require.NoError(t, Run(cfg, s, exp, zap.NewNop()))
+
+	assert.Len(t, exp.traces, 10)
+}
+
+func TestRateOfTraces(t *testing.T) {
+	s, err := loadScenario(filepath.Join("testdata", "scenario.yaml"))
+	require.NoError(t, err)
+	cfg := &Config{
+		Config: common.Config{
+			Rate:          10,
+			TotalDuration: time.Second / 2,
+			WorkerCount:   1,
+		},
+	}
+	exp := &mockExporter{}
+
+	require.NoError(t, Run(cfg, s, exp, zap.NewNop()))
+
+	// the first trace is sent right away, then one every 100ms
+	assert.True(t, len(exp.traces) >= 5 && len(exp.traces) <= 7, "there should have been 5 to 7 traces, had %d", len(exp.traces))
+}
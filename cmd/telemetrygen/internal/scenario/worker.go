@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package scenario
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	semconv "go.opentelemetry.io/collector/semconv/v1.13.0"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+const scopeName = "telemetrygen"
+
+// generator generates the traces of a scenario, picking the kind of each trace by weight.
+type generator struct {
+	scenario            *Scenario
+	services            map[string]Service
+	resourceAttributes  map[string]string // resource attributes of all the services
+	telemetryAttributes map[string]string // attributes of all the spans
+	totalWeight         int
+	rand                *rand.Rand
+}
+
+func newGenerator(s *Scenario, resourceAttributes, telemetryAttributes map[string]string, r *rand.Rand) *generator {
+	g := &generator{
+		scenario:            s,
+		services:            make(map[string]Service, len(s.Services)),
+		resourceAttributes:  resourceAttributes,
+		telemetryAttributes: telemetryAttributes,
+		rand:                r,
+	}
+	for _, svc := range s.Services {
+		g.services[svc.Name] = svc
+	}
+	for _, trace := range s.Traces {
+		g.totalWeight += weight(trace)
+	}
+	return g
+}
+
+func weight(trace Trace) int {
+	if trace.Weight == 0 {
+		return 1
+	}
+	return trace.Weight
+}
+
+func (g *generator) pickTrace() *Trace {
+	n := g.rand.Intn(g.totalWeight)
+	for i := range g.scenario.Traces {
+		n -= weight(g.scenario.Traces[i])
+		if n < 0 {
+			return &g.scenario.Traces[i]
+		}
+	}
+	return &g.scenario.Traces[len(g.scenario.Traces)-1]
+}
+
+// generate generates a trace starting at the given time, the spans of each service being in their own resource.
+func (g *generator) generate(start time.Time) ptrace.Traces {
+	td := ptrace.NewTraces()
+	b := &traceBuilder{
+		generator: g,
+		traces:    td,
+		scopes:    map[string]ptrace.SpanSlice{},
+	}
+	g.rand.Read(b.traceID[:])
+	b.span(&g.pickTrace().Root, pcommon.NewSpanIDEmpty(), start)
+	return td
+}
+
+type traceBuilder struct {
+	*generator
+	traces  ptrace.Traces
+	traceID pcommon.TraceID
+	scopes  map[string]ptrace.SpanSlice // spans of each service
+}
+
+func (b *traceBuilder) spans(service string) ptrace.SpanSlice {
+	spans, ok := b.scopes[service]
+	if !ok {
+		rs := b.traces.ResourceSpans().AppendEmpty()
+		attrs := rs.Resource().Attributes()
+		for k, v := range b.resourceAttributes {
+			attrs.PutStr(k, v)
+		}
+		attrs.PutStr(semconv.AttributeServiceName, service)
+		// The attributes were validated with the scenario.
+		_ = putAll(attrs, b.services[service].ResourceAttributes)
+
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.Scope().SetName(scopeName)
+		spans = ss.Spans()
+		b.scopes[service] = spans
+	}
+	return spans
+}
+
+// span generates the span and its children, and returns the end of the span.
+func (b *traceBuilder) span(s *Span, parentID pcommon.SpanID, start time.Time) time.Time {
+	span := b.spans(s.Service).AppendEmpty()
+	var spanID pcommon.SpanID
+	b.rand.Read(spanID[:])
+	span.SetTraceID(b.traceID)
+	span.SetSpanID(spanID)
+	span.SetParentSpanID(parentID)
+	span.SetName(s.Name)
+	span.SetKind(spanKinds[s.Kind])
+
+	end := start.Add(s.Duration)
+	childStart := start
+	for i := range s.Children {
+		child := &s.Children[i]
+		for j := 0; j < max(1, child.Repeat); j++ {
+			childEnd := b.span(child, spanID, childStart)
+			if !s.Parallel {
+				childStart = childEnd
+			}
+			if childEnd.After(end) {
+				end = childEnd
+			}
+		}
+	}
+
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(end))
+	for k, v := range b.telemetryAttributes {
+		span.Attributes().PutStr(k, v)
+	}
+	_ = putAll(span.Attributes(), s.Attributes)
+	if s.ErrorRate > 0 && b.rand.Float64() < s.ErrorRate {
+		span.Status().SetCode(ptrace.StatusCodeError)
+		span.Status().SetMessage("simulated error")
+	}
+	return end
+}
+
+// putAll puts the raw values in the map, keeping its other entries.
+func putAll(m pcommon.Map, raw map[string]any) error {
+	for k, v := range raw {
+		if err := m.PutEmpty(k).FromRaw(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type worker struct {
+	running        *atomic.Bool    // pointer to shared flag that indicates it's time to stop the test
+	numTraces      int             // how many traces the worker has to generate (only when duration==0)
+	limitPerSecond rate.Limit      // how many traces per second to generate
+	generator      *generator      // generator of the scenario traces
+	exporter       exporter        // exporter of the generated traces
+	wg             *sync.WaitGroup // notify when done
+	logger         *zap.Logger
+}
+
+func (w worker) simulateTraces() {
+	defer w.wg.Done()
+
+	limiter := rate.NewLimiter(w.limitPerSecond, 1)
+	var i int
+	for w.running.Load() {
+		if err := limiter.Wait(context.Background()); err != nil {
+			w.logger.Fatal("limiter wait failed, retry", zap.Error(err))
+		}
+
+		if err := w.exporter.ExportTraces(context.Background(), w.generator.generate(time.Now())); err != nil {
+			w.logger.Error("failed to export the traces", zap.Error(err))
+		}
+
+		i++
+		if w.numTraces != 0 && i >= w.numTraces {
+			break
+		}
+	}
+	w.logger.Info("traces generated", zap.Int("traces", i))
+}
@@ -44,6 +44,11 @@ type MetricInfo struct {
 	Description string            `mapstructure:"description"`
 	Conditions  []string          `mapstructure:"conditions"`
 	Attributes  []AttributeConfig `mapstructure:"attributes"`
+	// Value is an OTTL value expression. If set, a histogram of the value of each matching
+	// item is emitted instead of a count.
+	Value string `mapstructure:"value"`
+	// Buckets are the explicit bucket boundaries of the histogram, if Value is set.
+	Buckets []float64 `mapstructure:"buckets"`
 }
 
 type AttributeConfig struct {
@@ -59,6 +64,14 @@ func (c *Config) Validate() error {
 		if _, err := filterottl.NewBoolExprForSpan(info.Conditions, filterottl.StandardSpanFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
 			return fmt.Errorf("spans condition: metric %q: %w", name, err)
 		}
+		if err := info.validateHistogram(); err != nil {
+			return fmt.Errorf("spans histogram: metric %q: %w", name, err)
+		}
+		if info.Value != "" {
+			if _, err := newValueExprForSpan(info.Value, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+				return fmt.Errorf("spans value: metric %q: %w", name, err)
+			}
+		}
 		if err := info.validateAttributes(); err != nil {
 			return fmt.Errorf("spans attributes: metric %q: %w", name, err)
 		}
@@ -70,6 +83,14 @@ func (c *Config) Validate() error {
 		if _, err := filterottl.NewBoolExprForSpanEvent(info.Conditions, filterottl.StandardSpanEventFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
 			return fmt.Errorf("spanevents condition: metric %q: %w", name, err)
 		}
+		if err := info.validateHistogram(); err != nil {
+			return fmt.Errorf("spanevents histogram: metric %q: %w", name, err)
+		}
+		if info.Value != "" {
+			if _, err := newValueExprForSpanEvent(info.Value, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+				return fmt.Errorf("spanevents value: metric %q: %w", name, err)
+			}
+		}
 		if err := info.validateAttributes(); err != nil {
 			return fmt.Errorf("spanevents attributes: metric %q: %w", name, err)
 		}
@@ -81,6 +102,14 @@ func (c *Config) Validate() error {
 		if _, err := filterottl.NewBoolExprForMetric(info.Conditions, filterottl.StandardMetricFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
 			return fmt.Errorf("metrics condition: metric %q: %w", name, err)
 		}
+		if err := info.validateHistogram(); err != nil {
+			return fmt.Errorf("metrics histogram: metric %q: %w", name, err)
+		}
+		if info.Value != "" {
+			if _, err := newValueExprForMetric(info.Value, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+				return fmt.Errorf("metrics value: metric %q: %w", name, err)
+			}
+		}
 		if len(info.Attributes) > 0 {
 			return fmt.Errorf("metrics attributes not supported: metric %q", name)
 		}
@@ -93,6 +122,14 @@ func (c *Config) Validate() error {
 		if _, err := filterottl.NewBoolExprForDataPoint(info.Conditions, filterottl.StandardDataPointFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
 			return fmt.Errorf("datapoints condition: metric %q: %w", name, err)
 		}
+		if err := info.validateHistogram(); err != nil {
+			return fmt.Errorf("datapoints histogram: metric %q: %w", name, err)
+		}
+		if info.Value != "" {
+			if _, err := newValueExprForDataPoint(info.Value, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+				return fmt.Errorf("datapoints value: metric %q: %w", name, err)
+			}
+		}
 		if err := info.validateAttributes(); err != nil {
 			return fmt.Errorf("spans attributes: metric %q: %w", name, err)
 		}
@@ -104,6 +141,14 @@ func (c *Config) Validate() error {
 		if _, err := filterottl.NewBoolExprForLog(info.Conditions, filterottl.StandardLogFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
 			return fmt.Errorf("logs condition: metric %q: %w", name, err)
 		}
+		if err := info.validateHistogram(); err != nil {
+			return fmt.Errorf("logs histogram: metric %q: %w", name, err)
+		}
+		if info.Value != "" {
+			if _, err := newValueExprForLog(info.Value, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+				return fmt.Errorf("logs value: metric %q: %w", name, err)
+			}
+		}
 		if err := info.validateAttributes(); err != nil {
 			return fmt.Errorf("logs attributes: metric %q: %w", name, err)
 		}
@@ -111,6 +156,23 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+func (i *MetricInfo) validateHistogram() error {
+	if i.Value == "" {
+		if len(i.Buckets) > 0 {
+			return fmt.Errorf("buckets require a value")
+		}
+		return nil
+	}
+	return validateBuckets(i.Buckets)
+}
+
+func (i *MetricInfo) histogramBuckets() []float64 {
+	if len(i.Buckets) == 0 {
+		return defaultHistogramBuckets
+	}
+	return i.Buckets
+}
+
 func (i *MetricInfo) validateAttributes() error {
 	for _, attr := range i.Attributes {
 		if attr.Key == "" {
@@ -510,6 +510,40 @@ func TestConfigErrors(t *testing.T) {
 			},
 			expect: fmt.Sprintf("logs condition: metric %q: unable to parse OTTL condition", defaultMetricNameLogs),
 		},
+		{
+			name: "invalid_value_span",
+			input: &Config{
+				Spans: map[string]MetricInfo{
+					"span.duration": {
+						Value: "invalid value",
+					},
+				},
+			},
+			expect: `spans value: metric "span.duration": unable to parse OTTL value`,
+		},
+		{
+			name: "buckets_without_value_log",
+			input: &Config{
+				Logs: map[string]MetricInfo{
+					"log.body.size": {
+						Buckets: []float64{10, 100},
+					},
+				},
+			},
+			expect: `logs histogram: metric "log.body.size": buckets require a value`,
+		},
+		{
+			name: "unsorted_buckets_log",
+			input: &Config{
+				Logs: map[string]MetricInfo{
+					"log.body.size": {
+						Value:   "Len(body)",
+						Buckets: []float64{100, 10},
+					},
+				},
+			},
+			expect: `logs histogram: metric "log.body.size": buckets must be sorted in increasing order`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -656,6 +656,23 @@ func TestLogsToMetrics(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "histogram",
+			cfg: &Config{
+				Logs: map[string]MetricInfo{
+					"log.body.size.by_attr": {
+						Description: "Log body size by attribute",
+						Attributes: []AttributeConfig{
+							{
+								Key: "log.required",
+							},
+						},
+						Value:   "Len(body)",
+						Buckets: []float64{10, 20, 50},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
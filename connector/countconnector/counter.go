@@ -6,6 +6,7 @@ package countconnector // import "github.com/open-telemetry/opentelemetry-collec
 import (
 	"context"
 	"errors"
+	"sort"
 	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -33,6 +34,10 @@ type counter[K any] struct {
 type attrCounter struct {
 	attrs pcommon.Map
 	count uint64
+
+	// sum and bucketCounts are only set for histograms.
+	sum          float64
+	bucketCounts []uint64
 }
 
 func (c *counter[K]) update(ctx context.Context, attrs pcommon.Map, tCtx K) error {
@@ -74,20 +79,54 @@ func (c *counter[K]) update(ctx context.Context, attrs pcommon.Map, tCtx K) erro
 
 		// No conditions, so match all.
 		if md.condition == nil {
-			multiError = errors.Join(multiError, c.increment(name, countAttrs))
+			multiError = errors.Join(multiError, c.record(ctx, name, md, countAttrs, tCtx))
 			continue
 		}
 
 		if match, err := md.condition.Eval(ctx, tCtx); err != nil {
 			multiError = errors.Join(multiError, err)
 		} else if match {
-			multiError = errors.Join(multiError, c.increment(name, countAttrs))
+			multiError = errors.Join(multiError, c.record(ctx, name, md, countAttrs, tCtx))
 		}
 	}
 	return multiError
 }
 
+// record counts a matching item, or observes its value if the metric is a histogram.
+func (c *counter[K]) record(ctx context.Context, metricName string, md metricDef[K], attrs pcommon.Map, tCtx K) error {
+	if md.value == nil {
+		return c.increment(metricName, attrs)
+	}
+
+	value, ok, err := md.value.Eval(ctx, tCtx)
+	if err != nil {
+		return err
+	}
+	// Items without a value are not observed.
+	if !ok {
+		return nil
+	}
+	return c.observe(metricName, attrs, md.buckets, value)
+}
+
 func (c *counter[K]) increment(metricName string, attrs pcommon.Map) error {
+	c.attrCounter(metricName, attrs).count++
+	return nil
+}
+
+func (c *counter[K]) observe(metricName string, attrs pcommon.Map, buckets []float64, value float64) error {
+	ac := c.attrCounter(metricName, attrs)
+	if ac.bucketCounts == nil {
+		ac.bucketCounts = make([]uint64, len(buckets)+1)
+	}
+	ac.count++
+	ac.sum += value
+	// The upper bound of a bucket is inclusive.
+	ac.bucketCounts[sort.SearchFloat64s(buckets, value)]++
+	return nil
+}
+
+func (c *counter[K]) attrCounter(metricName string, attrs pcommon.Map) *attrCounter {
 	if _, ok := c.counts[metricName]; !ok {
 		c.counts[metricName] = make(map[[16]byte]*attrCounter)
 	}
@@ -100,9 +139,7 @@ func (c *counter[K]) increment(metricName string, attrs pcommon.Map) error {
 	if _, ok := c.counts[metricName][key]; !ok {
 		c.counts[metricName][key] = &attrCounter{attrs: attrs}
 	}
-
-	c.counts[metricName][key].count++
-	return nil
+	return c.counts[metricName][key]
 }
 
 func (c *counter[K]) appendMetricsTo(metricSlice pmetric.MetricSlice) {
@@ -113,6 +150,10 @@ func (c *counter[K]) appendMetricsTo(metricSlice pmetric.MetricSlice) {
 		countMetric := metricSlice.AppendEmpty()
 		countMetric.SetName(name)
 		countMetric.SetDescription(md.desc)
+		if md.value != nil {
+			c.appendHistogramTo(countMetric, name, md.buckets)
+			continue
+		}
 		sum := countMetric.SetEmptySum()
 		// The delta value is always positive, so a value accumulated downstream is monotonic
 		sum.SetIsMonotonic(true)
@@ -126,3 +167,17 @@ func (c *counter[K]) appendMetricsTo(metricSlice pmetric.MetricSlice) {
 		}
 	}
 }
+
+func (c *counter[K]) appendHistogramTo(metric pmetric.Metric, name string, buckets []float64) {
+	histogram := metric.SetEmptyHistogram()
+	histogram.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	for _, dpHistogram := range c.counts[name] {
+		dp := histogram.DataPoints().AppendEmpty()
+		dpHistogram.attrs.CopyTo(dp.Attributes())
+		dp.SetCount(dpHistogram.count)
+		dp.SetSum(dpHistogram.sum)
+		dp.ExplicitBounds().FromRaw(buckets)
+		dp.BucketCounts().FromRaw(dpHistogram.bucketCounts)
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(c.timestamp))
+	}
+}
@@ -59,6 +59,11 @@ func createTracesToMetrics(
 			condition, _ := filterottl.NewBoolExprForSpan(info.Conditions, filterottl.StandardSpanFuncs(), ottl.PropagateError, set.TelemetrySettings)
 			md.condition = condition
 		}
+		if info.Value != "" {
+			// Error checked in Config.Validate()
+			md.value, _ = newValueExprForSpan(info.Value, set.TelemetrySettings)
+			md.buckets = info.histogramBuckets()
+		}
 		spanMetricDefs[name] = md
 	}
 
@@ -73,6 +78,11 @@ func createTracesToMetrics(
 			condition, _ := filterottl.NewBoolExprForSpanEvent(info.Conditions, filterottl.StandardSpanEventFuncs(), ottl.PropagateError, set.TelemetrySettings)
 			md.condition = condition
 		}
+		if info.Value != "" {
+			// Error checked in Config.Validate()
+			md.value, _ = newValueExprForSpanEvent(info.Value, set.TelemetrySettings)
+			md.buckets = info.histogramBuckets()
+		}
 		spanEventMetricDefs[name] = md
 	}
 
@@ -102,6 +112,11 @@ func createMetricsToMetrics(
 			condition, _ := filterottl.NewBoolExprForMetric(info.Conditions, filterottl.StandardMetricFuncs(), ottl.PropagateError, set.TelemetrySettings)
 			md.condition = condition
 		}
+		if info.Value != "" {
+			// Error checked in Config.Validate()
+			md.value, _ = newValueExprForMetric(info.Value, set.TelemetrySettings)
+			md.buckets = info.histogramBuckets()
+		}
 		metricMetricDefs[name] = md
 	}
 
@@ -116,6 +131,11 @@ func createMetricsToMetrics(
 			condition, _ := filterottl.NewBoolExprForDataPoint(info.Conditions, filterottl.StandardDataPointFuncs(), ottl.PropagateError, set.TelemetrySettings)
 			md.condition = condition
 		}
+		if info.Value != "" {
+			// Error checked in Config.Validate()
+			md.value, _ = newValueExprForDataPoint(info.Value, set.TelemetrySettings)
+			md.buckets = info.histogramBuckets()
+		}
 		dataPointMetricDefs[name] = md
 	}
 
@@ -146,6 +166,11 @@ func createLogsToMetrics(
 			condition, _ := filterottl.NewBoolExprForLog(info.Conditions, filterottl.StandardLogFuncs(), ottl.PropagateError, set.TelemetrySettings)
 			md.condition = condition
 		}
+		if info.Value != "" {
+			// Error checked in Config.Validate()
+			md.value, _ = newValueExprForLog(info.Value, set.TelemetrySettings)
+			md.buckets = info.histogramBuckets()
+		}
 		metricDefs[name] = md
 	}
 
@@ -159,4 +184,7 @@ type metricDef[K any] struct {
 	condition expr.BoolExpr[K]
 	desc      string
 	attrs     []AttributeConfig
+	// value is set for histograms, the metric being a count otherwise.
+	value   *valueExpr[K]
+	buckets []float64
 }
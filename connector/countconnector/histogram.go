@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package countconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/countconnector"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+)
+
+// observeFuncName is the name of the OTTL function wrapping the value expressions,
+// so they can be parsed and evaluated as statements.
+const observeFuncName = "observe"
+
+// defaultHistogramBuckets are the explicit bucket boundaries of the histograms
+// without configured buckets, same as the OpenTelemetry SDK default ones.
+var defaultHistogramBuckets = []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}
+
+type observeArguments[K any] struct {
+	Value ottl.FloatLikeGetter[K]
+}
+
+func newObserveFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory(observeFuncName, &observeArguments[K]{}, createObserveFunction[K])
+}
+
+func createObserveFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*observeArguments[K])
+	if !ok {
+		return nil, fmt.Errorf("observeFactory args must be of type *observeArguments[K]")
+	}
+
+	return func(ctx context.Context, tCtx K) (any, error) {
+		value, err := args.Value.Get(ctx, tCtx)
+		if err != nil || value == nil {
+			return nil, err
+		}
+		return *value, nil
+	}, nil
+}
+
+// valueExpr evaluates an OTTL value expression to the float64 value recorded in a histogram.
+type valueExpr[K any] struct {
+	statement *ottl.Statement[K]
+}
+
+// Eval returns the value of the expression, and false if the expression evaluates to nil.
+func (e *valueExpr[K]) Eval(ctx context.Context, tCtx K) (float64, bool, error) {
+	result, _, err := e.statement.Execute(ctx, tCtx)
+	if err != nil {
+		return 0, false, err
+	}
+	value, ok := result.(float64)
+	return value, ok, nil
+}
+
+func newValueExpr[K any](value string, functions map[string]ottl.Factory[K], newParser func(map[string]ottl.Factory[K], component.TelemetrySettings) (ottl.Parser[K], error), set component.TelemetrySettings) (*valueExpr[K], error) {
+	observeFactory := newObserveFactory[K]()
+	functions[observeFactory.Name()] = observeFactory
+	parser, err := newParser(functions, set)
+	if err != nil {
+		return nil, err
+	}
+	statement, err := parser.ParseStatement(fmt.Sprintf("%s(%s)", observeFuncName, value))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse OTTL value %q: %w", value, err)
+	}
+	return &valueExpr[K]{statement: statement}, nil
+}
+
+func newValueExprForSpan(value string, set component.TelemetrySettings) (*valueExpr[ottlspan.TransformContext], error) {
+	return newValueExpr(value, filterottl.StandardSpanFuncs(), func(functions map[string]ottl.Factory[ottlspan.TransformContext], set component.TelemetrySettings) (ottl.Parser[ottlspan.TransformContext], error) {
+		return ottlspan.NewParser(functions, set)
+	}, set)
+}
+
+func newValueExprForSpanEvent(value string, set component.TelemetrySettings) (*valueExpr[ottlspanevent.TransformContext], error) {
+	return newValueExpr(value, filterottl.StandardSpanEventFuncs(), func(functions map[string]ottl.Factory[ottlspanevent.TransformContext], set component.TelemetrySettings) (ottl.Parser[ottlspanevent.TransformContext], error) {
+		return ottlspanevent.NewParser(functions, set)
+	}, set)
+}
+
+func newValueExprForMetric(value string, set component.TelemetrySettings) (*valueExpr[ottlmetric.TransformContext], error) {
+	return newValueExpr(value, filterottl.StandardMetricFuncs(), func(functions map[string]ottl.Factory[ottlmetric.TransformContext], set component.TelemetrySettings) (ottl.Parser[ottlmetric.TransformContext], error) {
+		return ottlmetric.NewParser(functions, set)
+	}, set)
+}
+
+func newValueExprForDataPoint(value string, set component.TelemetrySettings) (*valueExpr[ottldatapoint.TransformContext], error) {
+	return newValueExpr(value, filterottl.StandardDataPointFuncs(), func(functions map[string]ottl.Factory[ottldatapoint.TransformContext], set component.TelemetrySettings) (ottl.Parser[ottldatapoint.TransformContext], error) {
+		return ottldatapoint.NewParser(functions, set)
+	}, set)
+}
+
+func newValueExprForLog(value string, set component.TelemetrySettings) (*valueExpr[ottllog.TransformContext], error) {
+	return newValueExpr(value, filterottl.StandardLogFuncs(), func(functions map[string]ottl.Factory[ottllog.TransformContext], set component.TelemetrySettings) (ottl.Parser[ottllog.TransformContext], error) {
+		return ottllog.NewParser(functions, set)
+	}, set)
+}
+
+func validateBuckets(buckets []float64) error {
+	if len(buckets) == 0 {
+		return nil
+	}
+	if !sort.Float64sAreSorted(buckets) {
+		return errors.New("buckets must be sorted in increasing order")
+	}
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i] == buckets[i-1] {
+			return fmt.Errorf("duplicate bucket %v", buckets[i])
+		}
+	}
+	return nil
+}
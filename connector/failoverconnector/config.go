@@ -13,6 +13,7 @@ import (
 var (
 	errNoPipelinePriority    = errors.New("No pipelines are defined in the priority list")
 	errInvalidRetryIntervals = errors.New("Retry interval must be positive, and retry_interval must be greater than retry_gap times the length of the priority list")
+	errInvalidCircuitBreaker = errors.New("Circuit breaker failure_threshold and open_duration must be positive")
 )
 
 type Config struct {
@@ -33,6 +34,29 @@ type Config struct {
 	// MaxRetry is the maximum retries per level, once this limit is hit for a level, even if the next pipeline level fails,
 	// it will not try to recover the level that exceeded the maximum retries
 	MaxRetries int `mapstructure:"max_retries"`
+
+	// CircuitBreaker replaces the retry_interval based recovery with a circuit breaker per priority level
+	CircuitBreaker CircuitBreakerConfig `mapstructure:"circuit_breaker"`
+
+	// HealthCheck is the ID of the healthcheckv2 extension reporting the component status of the collector.
+	// When set, the levels whose exporters report an error status are skipped, until these exporters report
+	// that they recovered. Like CircuitBreaker, it replaces the retry_interval based recovery
+	HealthCheck *component.ID `mapstructure:"health_check"`
+}
+
+// CircuitBreakerConfig configures the circuit breakers of the priority levels. The data is routed to the highest
+// priority level whose circuit is not open, moving to the next level on errors so that no data is dropped while
+// a level exists that accepts it.
+type CircuitBreakerConfig struct {
+	// Enabled turns on the circuit breakers, in place of retry_interval, retry_gap and max_retries
+	Enabled bool `mapstructure:"enabled"`
+
+	// FailureThreshold is the number of consecutive errors returned by a level opening its circuit
+	FailureThreshold int `mapstructure:"failure_threshold"`
+
+	// OpenDuration is how long the circuit of a level stays open, before the next data is sent to the
+	// level as a probe (half-open). The circuit closes if the probe succeeds, and opens again otherwise
+	OpenDuration time.Duration `mapstructure:"open_duration"`
 }
 
 // Validate needs to ensure RetryInterval > # elements in PriorityList * RetryGap
@@ -40,9 +64,23 @@ func (c *Config) Validate() error {
 	if len(c.PipelinePriority) == 0 {
 		return errNoPipelinePriority
 	}
+	if c.CircuitBreaker.Enabled {
+		if c.CircuitBreaker.FailureThreshold <= 0 || c.CircuitBreaker.OpenDuration <= 0 {
+			return errInvalidCircuitBreaker
+		}
+	}
+	if c.routesByPriority() {
+		return nil
+	}
 	retryTime := c.RetryGap * time.Duration(len(c.PipelinePriority))
 	if c.RetryGap <= 0 || c.RetryInterval <= 0 || c.RetryInterval <= retryTime {
 		return errInvalidRetryIntervals
 	}
 	return nil
 }
+
+// routesByPriority returns whether the data is routed to the highest priority level available, as decided by
+// the circuit breakers and the component status, instead of by the pipeline selector and its retries
+func (c *Config) routesByPriority() bool {
+	return c.CircuitBreaker.Enabled || c.HealthCheck != nil
+}
@@ -17,6 +17,7 @@ import (
 )
 
 func TestLoadConfig(t *testing.T) {
+	healthCheckID := component.MustNewID("healthcheckv2")
 	testcases := []struct {
 		id       component.ID
 		expected *Config
@@ -32,6 +33,10 @@ func TestLoadConfig(t *testing.T) {
 				RetryInterval: 10 * time.Minute,
 				RetryGap:      30 * time.Second,
 				MaxRetries:    10,
+				CircuitBreaker: CircuitBreakerConfig{
+					FailureThreshold: 3,
+					OpenDuration:     time.Minute,
+				},
 			},
 		},
 		{
@@ -55,6 +60,52 @@ func TestLoadConfig(t *testing.T) {
 				RetryInterval: 5 * time.Minute,
 				RetryGap:      time.Minute,
 				MaxRetries:    10,
+				CircuitBreaker: CircuitBreakerConfig{
+					FailureThreshold: 3,
+					OpenDuration:     time.Minute,
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "circuit_breaker"),
+			expected: &Config{
+				PipelinePriority: [][]component.ID{
+					{
+						component.NewIDWithName(component.DataTypeTraces, "first"),
+					},
+					{
+						component.NewIDWithName(component.DataTypeTraces, "second"),
+					},
+				},
+				RetryInterval: 10 * time.Minute,
+				RetryGap:      30 * time.Second,
+				MaxRetries:    10,
+				CircuitBreaker: CircuitBreakerConfig{
+					Enabled:          true,
+					FailureThreshold: 5,
+					OpenDuration:     30 * time.Second,
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "health_check"),
+			expected: &Config{
+				PipelinePriority: [][]component.ID{
+					{
+						component.NewIDWithName(component.DataTypeTraces, "first"),
+					},
+					{
+						component.NewIDWithName(component.DataTypeTraces, "second"),
+					},
+				},
+				RetryInterval: 10 * time.Minute,
+				RetryGap:      30 * time.Second,
+				MaxRetries:    10,
+				CircuitBreaker: CircuitBreakerConfig{
+					FailureThreshold: 3,
+					OpenDuration:     time.Minute,
+				},
+				HealthCheck: &healthCheckID,
 			},
 		},
 	}
@@ -93,6 +144,11 @@ func TestValidateConfig(t *testing.T) {
 			id:   component.NewIDWithName(metadata.Type, "invalid"),
 			err:  errInvalidRetryIntervals,
 		},
+		{
+			name: "invalid circuit breaker open_duration",
+			id:   component.NewIDWithName(metadata.Type, "invalid_circuit_breaker"),
+			err:  errInvalidCircuitBreaker,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -29,6 +29,10 @@ func createDefaultConfig() component.Config {
 		RetryGap:      30 * time.Second,
 		RetryInterval: 10 * time.Minute,
 		MaxRetries:    10,
+		CircuitBreaker: CircuitBreakerConfig{
+			FailureThreshold: 3,
+			OpenDuration:     time.Minute,
+		},
 	}
 }
 
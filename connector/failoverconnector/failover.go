@@ -4,11 +4,19 @@
 package failoverconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"strconv"
 	"sync"
+	"sync/atomic"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/state"
 )
 
@@ -21,6 +29,16 @@ type failoverRouter[C any] struct {
 	wg               *sync.WaitGroup
 	consumers        []C
 
+	// breakers holds the circuit breaker of each priority level, if enabled
+	breakers []*state.CircuitBreaker
+	// health tracks the component status of the exporters of each priority level, if health_check is set
+	health       *state.LevelHealth
+	unsubscribe  func()
+	telemetry    *metadata.TelemetryBuilder
+	logger       *zap.Logger
+	reportStatus func(*component.StatusEvent)
+	activeLevel  atomic.Int32
+
 	done chan struct{}
 }
 
@@ -29,7 +47,19 @@ var (
 	errConsumer        = errors.New("Error registering consumer")
 )
 
-func newFailoverRouter[C any](provider consumerProvider[C], cfg *Config) *failoverRouter[C] {
+// componentStatusSource is implemented by the extensions reporting the component status of the collector,
+// like healthcheckv2. fn is called with the latest status of every component, then with every status change,
+// until unsubscribe is called.
+type componentStatusSource interface {
+	SubscribeComponentStatus(fn func(*component.InstanceID, *component.StatusEvent)) (unsubscribe func())
+}
+
+func newFailoverRouter[C any](provider consumerProvider[C], cfg *Config, set component.TelemetrySettings) (*failoverRouter[C], error) {
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set)
+	if err != nil {
+		return nil, err
+	}
+
 	var wg sync.WaitGroup
 	done := make(chan struct{})
 	pSConstants := state.PSConstants{
@@ -38,15 +68,58 @@ func newFailoverRouter[C any](provider consumerProvider[C], cfg *Config) *failov
 		MaxRetries:    cfg.MaxRetries,
 	}
 
-	selector := state.NewPipelineSelector(len(cfg.PipelinePriority), pSConstants)
-	selector.Start(done, &wg)
-	return &failoverRouter[C]{
+	router := &failoverRouter[C]{
 		consumerProvider: provider,
 		cfg:              cfg,
-		pS:               selector,
+		telemetry:        telemetryBuilder,
+		logger:           set.Logger,
+		reportStatus:     set.ReportStatus,
 		done:             done,
 		wg:               &wg,
 	}
+	// The circuit breakers and the component status replace the pipeline selector, whose retries would
+	// change the active level as well.
+	if !cfg.routesByPriority() {
+		router.pS = state.NewPipelineSelector(len(cfg.PipelinePriority), pSConstants)
+		router.pS.Start(done, &wg)
+	}
+	if cfg.CircuitBreaker.Enabled {
+		router.breakers = make([]*state.CircuitBreaker, len(cfg.PipelinePriority))
+		for i := range router.breakers {
+			levelAttr := attribute.String("priority_level", strconv.Itoa(i))
+			router.breakers[i] = state.NewCircuitBreaker(cfg.CircuitBreaker.FailureThreshold, cfg.CircuitBreaker.OpenDuration, func(cs state.CircuitState) {
+				telemetryBuilder.ConnectorFailoverCircuitStateChanges.Add(context.Background(), 1,
+					metric.WithAttributes(levelAttr, attribute.String("state", cs.String())))
+			})
+		}
+	}
+	if cfg.HealthCheck != nil {
+		router.health = state.NewLevelHealth(cfg.PipelinePriority, func(level int, healthy bool) {
+			if healthy {
+				router.logger.Info("Priority level recovered according to the status of its exporters", zap.Int("priority_level", level))
+			} else {
+				router.logger.Warn("Priority level failing according to the status of its exporters", zap.Int("priority_level", level))
+			}
+		})
+	}
+	return router, nil
+}
+
+// Start subscribes to the component status reported by the health_check extension, if set
+func (f *failoverRouter[C]) Start(host component.Host) error {
+	if f.cfg.HealthCheck == nil {
+		return nil
+	}
+	ext, ok := host.GetExtensions()[*f.cfg.HealthCheck]
+	if !ok {
+		return fmt.Errorf("health check extension '%s' not found", f.cfg.HealthCheck)
+	}
+	source, ok := ext.(componentStatusSource)
+	if !ok {
+		return fmt.Errorf("extension '%s' does not report component status", f.cfg.HealthCheck)
+	}
+	f.unsubscribe = source.SubscribeComponentStatus(f.health.RecordStatus)
+	return nil
 }
 
 func (f *failoverRouter[C]) getCurrentConsumer() (C, chan bool, bool) {
@@ -58,6 +131,61 @@ func (f *failoverRouter[C]) getCurrentConsumer() (C, chan bool, bool) {
 	return f.consumers[pl], ch, true
 }
 
+// consumeByPriority sends the data to the highest priority level that is healthy and whose circuit lets
+// it through, moving to the next level on errors. It is used instead of the pipeline selector when the
+// circuit breakers or the component status are enabled. When the exporters of every level report an error
+// status, the levels are all tried anyway rather than dropping the data.
+func (f *failoverRouter[C]) consumeByPriority(ctx context.Context, consume func(C) error) error {
+	skipUnhealthy := f.health != nil && f.health.AnyHealthy()
+	for i, c := range f.consumers {
+		if skipUnhealthy && !f.health.Healthy(i) {
+			continue
+		}
+		if f.breakers != nil && !f.breakers[i].Allow() {
+			continue
+		}
+		if err := consume(c); err != nil {
+			if f.breakers != nil {
+				f.breakers[i].RecordFailure()
+			}
+			continue
+		}
+		if f.breakers != nil {
+			f.breakers[i].RecordSuccess()
+		}
+		f.reportActiveLevel(ctx, i)
+		return nil
+	}
+	f.reportActiveLevel(ctx, len(f.consumers))
+	return errNoValidPipeline
+}
+
+// reportStable reports the success of the level of ch to the pipeline selector
+func (f *failoverRouter[C]) reportStable(ctx context.Context, ch chan bool) {
+	ch <- true
+	f.reportActiveLevel(ctx, f.pS.ChannelIndex(ch))
+}
+
+// reportActiveLevel records the priority level the data was routed to, and reports a status event when
+// it changes: OK for the first level, and a recoverable error for the other ones. An index past the last
+// level means all the levels failed.
+func (f *failoverRouter[C]) reportActiveLevel(ctx context.Context, idx int) {
+	if idx < len(f.cfg.PipelinePriority) {
+		f.telemetry.ConnectorFailoverActivePriorityLevel.Record(ctx, int64(idx))
+	}
+	if int(f.activeLevel.Swap(int32(idx))) == idx || f.reportStatus == nil {
+		return
+	}
+	switch {
+	case idx == 0:
+		f.reportStatus(component.NewStatusEvent(component.StatusOK))
+	case idx < len(f.cfg.PipelinePriority):
+		f.reportStatus(component.NewRecoverableErrorEvent(fmt.Errorf("failed over to priority level %d", idx)))
+	default:
+		f.reportStatus(component.NewRecoverableErrorEvent(errNoValidPipeline))
+	}
+}
+
 func (f *failoverRouter[C]) registerConsumers() error {
 	consumers := make([]C, 0)
 	for _, pipelines := range f.cfg.PipelinePriority {
@@ -72,7 +200,12 @@ func (f *failoverRouter[C]) registerConsumers() error {
 }
 
 func (f *failoverRouter[C]) Shutdown() {
-	f.pS.RS.InvokeCancel()
+	if f.unsubscribe != nil {
+		f.unsubscribe()
+	}
+	if f.pS != nil {
+		f.pS.RS.InvokeCancel()
+	}
 
 	close(f.done)
 	f.wg.Wait()
@@ -10,10 +10,15 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/state"
 )
 
 func TestFailoverRecovery(t *testing.T) {
@@ -224,3 +229,177 @@ func resetConsumers(conn *tracesFailover, consumers ...consumer.Traces) {
 	}
 	conn.failover.pS.TestSetStableIndex(0)
 }
+
+func TestFailoverCircuitBreaker(t *testing.T) {
+	var sinkFirst, sinkSecond consumertest.TracesSink
+	tracesFirst := component.NewIDWithName(component.DataTypeTraces, "traces/first")
+	tracesSecond := component.NewIDWithName(component.DataTypeTraces, "traces/second")
+
+	cfg := &Config{
+		PipelinePriority: [][]component.ID{{tracesFirst}, {tracesSecond}},
+		CircuitBreaker: CircuitBreakerConfig{
+			Enabled:          true,
+			FailureThreshold: 1,
+			OpenDuration:     time.Minute,
+		},
+	}
+
+	router := connector.NewTracesRouter(map[component.ID]consumer.Traces{
+		tracesFirst:  &sinkFirst,
+		tracesSecond: &sinkSecond,
+	})
+
+	reader := sdkmetric.NewManualReader()
+	set := connectortest.NewNopSettings()
+	set.TelemetrySettings.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	var statuses []component.Status
+	set.TelemetrySettings.ReportStatus = func(ev *component.StatusEvent) {
+		statuses = append(statuses, ev.Status())
+	}
+
+	conn, err := NewFactory().CreateTracesToTraces(context.Background(), set, cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+
+	failoverConnector := conn.(*tracesFailover)
+	defer func() {
+		assert.NoError(t, failoverConnector.Shutdown(context.Background()))
+	}()
+	// the pipeline selector and its retries aren't used with the circuit breakers
+	require.Nil(t, failoverConnector.failover.pS)
+
+	now := time.Now()
+	for _, cb := range failoverConnector.failover.breakers {
+		cb.SetNowFunc(func() time.Time { return now })
+	}
+
+	tr := sampleTrace()
+
+	// the data rejected by the first level is sent to the second one
+	failoverConnector.failover.ModifyConsumerAtIndex(0, consumertest.NewErr(errTracesConsumer))
+	require.NoError(t, conn.ConsumeTraces(context.Background(), tr))
+	require.Equal(t, 1, sinkSecond.SpanCount())
+
+	// the first level isn't tried again until its circuit is half-open
+	failoverConnector.failover.ModifyConsumerAtIndex(0, &sinkFirst)
+	require.NoError(t, conn.ConsumeTraces(context.Background(), tr))
+	require.Equal(t, 0, sinkFirst.SpanCount())
+	require.Equal(t, 2, sinkSecond.SpanCount())
+
+	now = now.Add(time.Minute)
+	require.NoError(t, conn.ConsumeTraces(context.Background(), tr))
+	require.Equal(t, 1, sinkFirst.SpanCount())
+	require.Equal(t, state.CircuitClosed, failoverConnector.failover.breakers[0].State())
+
+	require.Equal(t, []component.Status{component.StatusRecoverableError, component.StatusOK}, statuses)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	metrics := map[string]metricdata.Metrics{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+
+	activeLevel := metrics["connector_failover_active_priority_level"].Data.(metricdata.Gauge[int64])
+	require.Len(t, activeLevel.DataPoints, 1)
+	assert.EqualValues(t, 0, activeLevel.DataPoints[0].Value)
+
+	stateChanges := metrics["connector_failover_circuit_state_changes"].Data.(metricdata.Sum[int64])
+	assert.Len(t, stateChanges.DataPoints, 3)
+}
+
+func TestFailoverComponentStatus(t *testing.T) {
+	var sinkFirst, sinkSecond consumertest.TracesSink
+	tracesFirst := component.NewIDWithName(component.DataTypeTraces, "traces/first")
+	tracesSecond := component.NewIDWithName(component.DataTypeTraces, "traces/second")
+	healthCheckID := component.MustNewID("healthcheckv2")
+
+	cfg := &Config{
+		PipelinePriority: [][]component.ID{{tracesFirst}, {tracesSecond}},
+		HealthCheck:      &healthCheckID,
+	}
+
+	router := connector.NewTracesRouter(map[component.ID]consumer.Traces{
+		tracesFirst:  &sinkFirst,
+		tracesSecond: &sinkSecond,
+	})
+
+	set := connectortest.NewNopSettings()
+	var statuses []component.Status
+	set.TelemetrySettings.ReportStatus = func(ev *component.StatusEvent) {
+		statuses = append(statuses, ev.Status())
+	}
+
+	conn, err := NewFactory().CreateTracesToTraces(context.Background(), set, cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+
+	require.ErrorContains(t, conn.Start(context.Background(), componenttest.NewNopHost()), "health check extension 'healthcheckv2' not found")
+
+	source := &testStatusSource{}
+	host := &testStatusHost{Host: componenttest.NewNopHost(), extensions: map[component.ID]component.Component{healthCheckID: source}}
+	require.NoError(t, conn.Start(context.Background(), host))
+	// the pipeline selector and its retries aren't used with the component status
+	require.Nil(t, conn.(*tracesFailover).failover.pS)
+
+	exporterFirst := &component.InstanceID{
+		ID:          component.MustNewIDWithName("otlp", "first"),
+		Kind:        component.KindExporter,
+		PipelineIDs: map[component.ID]struct{}{tracesFirst: {}},
+	}
+	exporterSecond := &component.InstanceID{
+		ID:          component.MustNewIDWithName("otlp", "second"),
+		Kind:        component.KindExporter,
+		PipelineIDs: map[component.ID]struct{}{tracesSecond: {}},
+	}
+	tr := sampleTrace()
+
+	require.NoError(t, conn.ConsumeTraces(context.Background(), tr))
+	require.Equal(t, 1, sinkFirst.SpanCount())
+
+	// the data is routed away from a level as soon as its exporters report an error
+	source.fn(exporterFirst, component.NewRecoverableErrorEvent(errTracesConsumer))
+	require.NoError(t, conn.ConsumeTraces(context.Background(), tr))
+	require.Equal(t, 1, sinkFirst.SpanCount())
+	require.Equal(t, 1, sinkSecond.SpanCount())
+
+	// the data isn't dropped when every level is reported failing
+	source.fn(exporterSecond, component.NewRecoverableErrorEvent(errTracesConsumer))
+	require.NoError(t, conn.ConsumeTraces(context.Background(), tr))
+	require.Equal(t, 2, sinkFirst.SpanCount())
+
+	// and is routed back to the level once its exporters recovered
+	source.fn(exporterFirst, component.NewStatusEvent(component.StatusOK))
+	source.fn(exporterSecond, component.NewStatusEvent(component.StatusOK))
+	require.NoError(t, conn.ConsumeTraces(context.Background(), tr))
+	require.Equal(t, 3, sinkFirst.SpanCount())
+	require.Equal(t, 1, sinkSecond.SpanCount())
+
+	require.Equal(t, []component.Status{component.StatusRecoverableError, component.StatusOK}, statuses)
+
+	require.NoError(t, conn.Shutdown(context.Background()))
+	require.Nil(t, source.fn)
+}
+
+type testStatusHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *testStatusHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+// testStatusSource stands for the healthcheckv2 extension
+type testStatusSource struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	fn func(*component.InstanceID, *component.StatusEvent)
+}
+
+func (s *testStatusSource) SubscribeComponentStatus(fn func(*component.InstanceID, *component.StatusEvent)) func() {
+	s.fn = fn
+	return func() {
+		s.fn = nil
+	}
+}
@@ -3,9 +3,14 @@
 package metadata
 
 import (
-	"go.opentelemetry.io/collector/component"
+	"errors"
+
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
 )
 
 func Meter(settings component.TelemetrySettings) metric.Meter {
@@ -15,3 +20,50 @@ func Meter(settings component.TelemetrySettings) metric.Meter {
 func Tracer(settings component.TelemetrySettings) trace.Tracer {
 	return settings.TracerProvider.Tracer("otelcol/failover")
 }
+
+// TelemetryBuilder provides an interface for components to report telemetry
+// as defined in metadata and user config.
+type TelemetryBuilder struct {
+	meter                                metric.Meter
+	ConnectorFailoverActivePriorityLevel metric.Int64Gauge
+	ConnectorFailoverCircuitStateChanges metric.Int64Counter
+	level                                configtelemetry.Level
+}
+
+// telemetryBuilderOption applies changes to default builder.
+type telemetryBuilderOption func(*TelemetryBuilder)
+
+// WithLevel sets the current telemetry level for the component.
+func WithLevel(lvl configtelemetry.Level) telemetryBuilderOption {
+	return func(builder *TelemetryBuilder) {
+		builder.level = lvl
+	}
+}
+
+// NewTelemetryBuilder provides a struct with methods to update all internal telemetry
+// for a component
+func NewTelemetryBuilder(settings component.TelemetrySettings, options ...telemetryBuilderOption) (*TelemetryBuilder, error) {
+	builder := TelemetryBuilder{level: configtelemetry.LevelBasic}
+	for _, op := range options {
+		op(&builder)
+	}
+	var err, errs error
+	if builder.level >= configtelemetry.LevelBasic {
+		builder.meter = Meter(settings)
+	} else {
+		builder.meter = noop.Meter{}
+	}
+	builder.ConnectorFailoverActivePriorityLevel, err = builder.meter.Int64Gauge(
+		"connector_failover_active_priority_level",
+		metric.WithDescription("Index, starting at 0, of the priority level the data was last routed to"),
+		metric.WithUnit("{level}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ConnectorFailoverCircuitStateChanges, err = builder.meter.Int64Counter(
+		"connector_failover_circuit_state_changes",
+		metric.WithDescription("Number of state changes of the circuit breakers of the priority levels"),
+		metric.WithUnit("{changes}"),
+	)
+	errs = errors.Join(errs, err)
+	return &builder, errs
+}
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/state"
+
+import (
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of the circuit breaker of a priority level
+type CircuitState int
+
+const (
+	// CircuitClosed lets the data through to the level
+	CircuitClosed CircuitState = iota
+	// CircuitOpen routes the data away from the level, until the open duration has passed
+	CircuitOpen
+	// CircuitHalfOpen lets a single probe through to the level, to decide whether it recovered
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half_open"
+	}
+	return "unknown"
+}
+
+// CircuitBreaker tracks the health of a single priority level. It opens after FailureThreshold consecutive
+// failures, and lets a single probe through once OpenDuration has passed: the circuit closes if the probe
+// succeeds, and opens again otherwise.
+type CircuitBreaker struct {
+	failureThreshold int
+	openDuration     time.Duration
+	now              func() time.Time
+	onStateChange    func(CircuitState)
+
+	lock          sync.Mutex
+	state         CircuitState
+	failures      int
+	openedAt      time.Time
+	probeInFlight bool
+}
+
+// NewCircuitBreaker returns a closed CircuitBreaker, onStateChange being called on every state change
+func NewCircuitBreaker(failureThreshold int, openDuration time.Duration, onStateChange func(CircuitState)) *CircuitBreaker {
+	return &CircuitBreaker{
+		failureThreshold: failureThreshold,
+		openDuration:     openDuration,
+		now:              time.Now,
+		onStateChange:    onStateChange,
+	}
+}
+
+// Allow returns whether data can be sent to the level. Once the open duration has passed, the circuit
+// moves to half-open, and only the first caller is allowed until the probe outcome is recorded.
+func (cb *CircuitBreaker) Allow() bool {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	switch cb.state {
+	case CircuitOpen:
+		if cb.now().Sub(cb.openedAt) < cb.openDuration {
+			return false
+		}
+		cb.setState(CircuitHalfOpen)
+		cb.probeInFlight = true
+		return true
+	case CircuitHalfOpen:
+		if cb.probeInFlight {
+			return false
+		}
+		cb.probeInFlight = true
+		return true
+	}
+	return true
+}
+
+// RecordSuccess closes the circuit
+func (cb *CircuitBreaker) RecordSuccess() {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	cb.failures = 0
+	cb.probeInFlight = false
+	if cb.state != CircuitClosed {
+		cb.setState(CircuitClosed)
+	}
+}
+
+// RecordFailure opens the circuit if the failure threshold is reached, or if the failure is a probe's
+func (cb *CircuitBreaker) RecordFailure() {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	cb.failures++
+	cb.probeInFlight = false
+	if cb.state == CircuitHalfOpen || (cb.state == CircuitClosed && cb.failures >= cb.failureThreshold) {
+		cb.openedAt = cb.now()
+		cb.setState(CircuitOpen)
+	}
+}
+
+// State returns the current state of the circuit
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	return cb.state
+}
+
+// Must be called holding lock.
+func (cb *CircuitBreaker) setState(state CircuitState) {
+	cb.state = state
+	if cb.onStateChange != nil {
+		cb.onStateChange(state)
+	}
+}
+
+// For Testing
+func (cb *CircuitBreaker) SetNowFunc(now func() time.Time) {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	cb.now = now
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var states []CircuitState
+	cb := NewCircuitBreaker(2, time.Minute, func(s CircuitState) {
+		states = append(states, s)
+	})
+	now := time.Now()
+	cb.SetNowFunc(func() time.Time { return now })
+
+	require.True(t, cb.Allow())
+	cb.RecordFailure()
+	require.Equal(t, CircuitClosed, cb.State())
+
+	require.True(t, cb.Allow())
+	cb.RecordFailure()
+	require.Equal(t, CircuitOpen, cb.State())
+	require.False(t, cb.Allow())
+
+	// a single probe is let through once the open duration has passed
+	now = now.Add(time.Minute)
+	require.True(t, cb.Allow())
+	require.Equal(t, CircuitHalfOpen, cb.State())
+	require.False(t, cb.Allow())
+
+	// a failed probe opens the circuit again
+	cb.RecordFailure()
+	require.Equal(t, CircuitOpen, cb.State())
+	require.False(t, cb.Allow())
+
+	// a successful probe closes the circuit
+	now = now.Add(time.Minute)
+	require.True(t, cb.Allow())
+	cb.RecordSuccess()
+	require.Equal(t, CircuitClosed, cb.State())
+	require.True(t, cb.Allow())
+
+	require.Equal(t, []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitOpen, CircuitHalfOpen, CircuitClosed}, states)
+}
+
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	cb := NewCircuitBreaker(2, time.Minute, nil)
+
+	cb.RecordFailure()
+	cb.RecordSuccess()
+	cb.RecordFailure()
+	require.Equal(t, CircuitClosed, cb.State())
+}
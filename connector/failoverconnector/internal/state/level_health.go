@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/state"
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// LevelHealth tracks the health of the priority levels from the component status reported by the exporters
+// of their pipelines. A level is unhealthy while any of these exporters reports an error status, and healthy
+// again once they all report a status that isn't an error.
+type LevelHealth struct {
+	pipelineLevels map[component.ID][]int
+	onChange       func(level int, healthy bool)
+	unhealthy      []atomic.Bool
+
+	lock sync.Mutex
+	// failing holds the exporters reporting an error status, per level
+	failing []map[component.ID]struct{}
+}
+
+// NewLevelHealth returns a LevelHealth with every level healthy, onChange being called whenever the health
+// of a level changes
+func NewLevelHealth(pipelinePriority [][]component.ID, onChange func(level int, healthy bool)) *LevelHealth {
+	lh := &LevelHealth{
+		pipelineLevels: make(map[component.ID][]int),
+		onChange:       onChange,
+		unhealthy:      make([]atomic.Bool, len(pipelinePriority)),
+		failing:        make([]map[component.ID]struct{}, len(pipelinePriority)),
+	}
+	for level, pipelines := range pipelinePriority {
+		for _, pipeline := range pipelines {
+			lh.pipelineLevels[pipeline] = append(lh.pipelineLevels[pipeline], level)
+		}
+		lh.failing[level] = make(map[component.ID]struct{})
+	}
+	return lh
+}
+
+// RecordStatus updates the health of the levels whose pipelines contain the source, if it is an exporter
+func (lh *LevelHealth) RecordStatus(source *component.InstanceID, event *component.StatusEvent) {
+	if source == nil || event == nil || source.Kind != component.KindExporter {
+		return
+	}
+	failing := component.StatusIsError(event.Status())
+
+	lh.lock.Lock()
+	defer lh.lock.Unlock()
+	for pipeline := range source.PipelineIDs {
+		for _, level := range lh.pipelineLevels[pipeline] {
+			if failing {
+				lh.failing[level][source.ID] = struct{}{}
+			} else {
+				delete(lh.failing[level], source.ID)
+			}
+			unhealthy := len(lh.failing[level]) > 0
+			if lh.unhealthy[level].Swap(unhealthy) != unhealthy && lh.onChange != nil {
+				lh.onChange(level, !unhealthy)
+			}
+		}
+	}
+}
+
+// Healthy returns whether no exporter of the level reports an error status
+func (lh *LevelHealth) Healthy(level int) bool {
+	return !lh.unhealthy[level].Load()
+}
+
+// AnyHealthy returns whether at least one level is healthy
+func (lh *LevelHealth) AnyHealthy() bool {
+	for i := range lh.unhealthy {
+		if !lh.unhealthy[i].Load() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestLevelHealth(t *testing.T) {
+	tracesFirst := component.NewIDWithName(component.DataTypeTraces, "first")
+	tracesAlsoFirst := component.NewIDWithName(component.DataTypeTraces, "also_first")
+	tracesSecond := component.NewIDWithName(component.DataTypeTraces, "second")
+
+	type change struct {
+		level   int
+		healthy bool
+	}
+	var changes []change
+	lh := NewLevelHealth([][]component.ID{{tracesFirst, tracesAlsoFirst}, {tracesSecond}}, func(level int, healthy bool) {
+		changes = append(changes, change{level, healthy})
+	})
+
+	exporterFirst := &component.InstanceID{
+		ID:          component.MustNewIDWithName("otlp", "first"),
+		Kind:        component.KindExporter,
+		PipelineIDs: map[component.ID]struct{}{tracesFirst: {}},
+	}
+	exporterShared := &component.InstanceID{
+		ID:          component.MustNewIDWithName("otlp", "shared"),
+		Kind:        component.KindExporter,
+		PipelineIDs: map[component.ID]struct{}{tracesAlsoFirst: {}, tracesSecond: {}},
+	}
+	processorFirst := &component.InstanceID{
+		ID:          component.MustNewID("batch"),
+		Kind:        component.KindProcessor,
+		PipelineIDs: map[component.ID]struct{}{tracesFirst: {}},
+	}
+	errStatus := component.NewRecoverableErrorEvent(errors.New("unavailable"))
+
+	require.True(t, lh.Healthy(0))
+	require.True(t, lh.Healthy(1))
+
+	// only the exporters are taken into account
+	lh.RecordStatus(processorFirst, errStatus)
+	require.True(t, lh.Healthy(0))
+
+	lh.RecordStatus(exporterFirst, errStatus)
+	require.False(t, lh.Healthy(0))
+	require.True(t, lh.Healthy(1))
+	require.True(t, lh.AnyHealthy())
+
+	// a level stays unhealthy until all of its exporters recovered
+	lh.RecordStatus(exporterShared, component.NewPermanentErrorEvent(errors.New("invalid")))
+	require.False(t, lh.Healthy(0))
+	require.False(t, lh.Healthy(1))
+	require.False(t, lh.AnyHealthy())
+
+	lh.RecordStatus(exporterFirst, component.NewStatusEvent(component.StatusOK))
+	require.False(t, lh.Healthy(0))
+
+	lh.RecordStatus(exporterShared, component.NewStatusEvent(component.StatusOK))
+	require.True(t, lh.Healthy(0))
+	require.True(t, lh.Healthy(1))
+
+	require.Len(t, changes, 4)
+	require.Equal(t, []change{{0, false}, {1, false}}, changes[:2])
+	require.ElementsMatch(t, []change{{0, true}, {1, true}}, changes[2:])
+}
@@ -216,15 +216,7 @@ func (p *PipelineSelector) ListenToChannels(done chan struct{}, wg *sync.WaitGro
 	}
 }
 
-func (p *PipelineSelector) SelectedPipeline() (int, chan bool) {
-	idx := p.loadCurrent()
-	if idx < len(p.chans) {
-		return idx, p.chans[idx]
-	}
-	return idx, nil
-}
-
-// For Testing
+// ChannelIndex returns the priority level of the channel returned by SelectedPipeline
 func (p *PipelineSelector) ChannelIndex(ch chan bool) int {
 	for i, ch1 := range p.chans {
 		if ch == ch1 {
@@ -234,6 +226,15 @@ func (p *PipelineSelector) ChannelIndex(ch chan bool) int {
 	return -1
 }
 
+func (p *PipelineSelector) SelectedPipeline() (int, chan bool) {
+	idx := p.loadCurrent()
+	if idx < len(p.chans) {
+		return idx, p.chans[idx]
+	}
+	return idx, nil
+}
+
+// For Testing
 func (p *PipelineSelector) TestStableIndex() int {
 	return p.loadStable()
 }
@@ -15,7 +15,6 @@ import (
 )
 
 type logsFailover struct {
-	component.StartFunc
 	component.ShutdownFunc
 
 	config   *Config
@@ -23,19 +22,29 @@ type logsFailover struct {
 	logger   *zap.Logger
 }
 
+// Start subscribes to the component status of the exporters of the priority levels, if health_check is set
+func (f *logsFailover) Start(_ context.Context, host component.Host) error {
+	return f.failover.Start(host)
+}
+
 func (f *logsFailover) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
 // ConsumeLogs will try to export to the current set priority level and handle failover in the case of an error
 func (f *logsFailover) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if f.failover.pS == nil {
+		return f.failover.consumeByPriority(ctx, func(c consumer.Logs) error {
+			return c.ConsumeLogs(ctx, ld)
+		})
+	}
 	tc, ch, ok := f.failover.getCurrentConsumer()
 	if !ok {
 		return errNoValidPipeline
 	}
 	err := tc.ConsumeLogs(ctx, ld)
 	if err == nil {
-		ch <- true
+		f.failover.reportStable(ctx, ch)
 		return nil
 	}
 	return f.FailoverLogs(ctx, ld)
@@ -49,9 +58,10 @@ func (f *logsFailover) FailoverLogs(ctx context.Context, ld plog.Logs) error {
 			ch <- false
 			continue
 		}
-		ch <- true
+		f.failover.reportStable(ctx, ch)
 		return nil
 	}
+	f.failover.reportActiveLevel(ctx, len(f.config.PipelinePriority))
 	f.logger.Error("All provided pipelines return errors, dropping data")
 	return errNoValidPipeline
 }
@@ -70,8 +80,11 @@ func newLogsToLogs(set connector.Settings, cfg component.Config, logs consumer.L
 		return nil, errors.New("consumer is not of type LogsRouter")
 	}
 
-	failover := newFailoverRouter[consumer.Logs](lr.Consumer, config)
-	err := failover.registerConsumers()
+	failover, err := newFailoverRouter[consumer.Logs](lr.Consumer, config, set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	err = failover.registerConsumers()
 	if err != nil {
 		return nil, err
 	}
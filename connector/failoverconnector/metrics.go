@@ -15,7 +15,6 @@ import (
 )
 
 type metricsFailover struct {
-	component.StartFunc
 	component.ShutdownFunc
 
 	config   *Config
@@ -23,19 +22,29 @@ type metricsFailover struct {
 	logger   *zap.Logger
 }
 
+// Start subscribes to the component status of the exporters of the priority levels, if health_check is set
+func (f *metricsFailover) Start(_ context.Context, host component.Host) error {
+	return f.failover.Start(host)
+}
+
 func (f *metricsFailover) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
 // ConsumeMetrics will try to export to the current set priority level and handle failover in the case of an error
 func (f *metricsFailover) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if f.failover.pS == nil {
+		return f.failover.consumeByPriority(ctx, func(c consumer.Metrics) error {
+			return c.ConsumeMetrics(ctx, md)
+		})
+	}
 	tc, ch, ok := f.failover.getCurrentConsumer()
 	if !ok {
 		return errNoValidPipeline
 	}
 	err := tc.ConsumeMetrics(ctx, md)
 	if err == nil {
-		ch <- true
+		f.failover.reportStable(ctx, ch)
 		return nil
 	}
 	return f.FailoverMetrics(ctx, md)
@@ -49,9 +58,10 @@ func (f *metricsFailover) FailoverMetrics(ctx context.Context, md pmetric.Metric
 			ch <- false
 			continue
 		}
-		ch <- true
+		f.failover.reportStable(ctx, ch)
 		return nil
 	}
+	f.failover.reportActiveLevel(ctx, len(f.config.PipelinePriority))
 	f.logger.Error("All provided pipelines return errors, dropping data")
 	return errNoValidPipeline
 }
@@ -70,8 +80,11 @@ func newMetricsToMetrics(set connector.Settings, cfg component.Config, metrics c
 		return nil, errors.New("consumer is not of type MetricsRouter")
 	}
 
-	failover := newFailoverRouter[consumer.Metrics](mr.Consumer, config)
-	err := failover.registerConsumers()
+	failover, err := newFailoverRouter[consumer.Metrics](mr.Consumer, config, set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	err = failover.registerConsumers()
 	if err != nil {
 		return nil, err
 	}
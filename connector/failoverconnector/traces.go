@@ -15,7 +15,6 @@ import (
 )
 
 type tracesFailover struct {
-	component.StartFunc
 	component.ShutdownFunc
 
 	config   *Config
@@ -23,19 +22,29 @@ type tracesFailover struct {
 	logger   *zap.Logger
 }
 
+// Start subscribes to the component status of the exporters of the priority levels, if health_check is set
+func (f *tracesFailover) Start(_ context.Context, host component.Host) error {
+	return f.failover.Start(host)
+}
+
 func (f *tracesFailover) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
 // ConsumeTraces will try to export to the current set priority level and handle failover in the case of an error
 func (f *tracesFailover) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if f.failover.pS == nil {
+		return f.failover.consumeByPriority(ctx, func(c consumer.Traces) error {
+			return c.ConsumeTraces(ctx, td)
+		})
+	}
 	tc, ch, ok := f.failover.getCurrentConsumer()
 	if !ok {
 		return errNoValidPipeline
 	}
 	err := tc.ConsumeTraces(ctx, td)
 	if err == nil {
-		ch <- true
+		f.failover.reportStable(ctx, ch)
 		return nil
 	}
 	return f.FailoverTraces(ctx, td)
@@ -49,9 +58,10 @@ func (f *tracesFailover) FailoverTraces(ctx context.Context, td ptrace.Traces) e
 			ch <- false
 			continue
 		}
-		ch <- true
+		f.failover.reportStable(ctx, ch)
 		return nil
 	}
+	f.failover.reportActiveLevel(ctx, len(f.config.PipelinePriority))
 	f.logger.Error("All provided pipelines return errors, dropping data")
 	return errNoValidPipeline
 }
@@ -70,8 +80,11 @@ func newTracesToTraces(set connector.Settings, cfg component.Config, traces cons
 		return nil, errors.New("consumer is not of type TracesRouter")
 	}
 
-	failover := newFailoverRouter[consumer.Traces](tr.Consumer, config)
-	err := failover.registerConsumers()
+	failover, err := newFailoverRouter[consumer.Traces](tr.Consumer, config, set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	err = failover.registerConsumers()
 	if err != nil {
 		return nil, err
 	}
@@ -3,5 +3,54 @@
 
 package roundrobinconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/roundrobinconnector"
 
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// maxWeight is the maximum weight of a pipeline, so that the sum of the weights can't overflow.
+const maxWeight = 1_000_000
+
 // Config for the connector
-type Config struct{}
+type Config struct {
+	// Weights of the pipelines, the data being split between them proportionally to their weight.
+	// The pipelines that aren't listed have a weight of 1.
+	Weights map[component.ID]int `mapstructure:"weights"`
+
+	// Sticky routes the data sharing the same key to the same pipeline, instead of cycling the pipelines.
+	Sticky StickyConfig `mapstructure:"sticky"`
+
+	// SkipOnBackpressure sends the data to the next pipeline when a pipeline returns a non-permanent error,
+	// e.g. because its exporter queue is full.
+	SkipOnBackpressure bool `mapstructure:"skip_on_backpressure"`
+}
+
+// StickyConfig defines the key the pipeline of the data is selected from.
+type StickyConfig struct {
+	// ResourceAttribute is the resource attribute whose value selects the pipeline of each resource.
+	ResourceAttribute string `mapstructure:"resource_attribute"`
+
+	// TraceID selects the pipeline of each span from its trace ID, only for traces.
+	TraceID bool `mapstructure:"trace_id"`
+}
+
+func (s StickyConfig) enabled() bool {
+	return s.ResourceAttribute != "" || s.TraceID
+}
+
+func (c *Config) Validate() error {
+	for id, weight := range c.Weights {
+		if weight <= 0 {
+			return fmt.Errorf("weights: pipeline %q: weight must be positive", id)
+		}
+		if weight > maxWeight {
+			return fmt.Errorf("weights: pipeline %q: weight must not exceed %d", id, maxWeight)
+		}
+	}
+	if c.Sticky.ResourceAttribute != "" && c.Sticky.TraceID {
+		return errors.New("sticky: only one of resource_attribute and trace_id can be set")
+	}
+	return nil
+}
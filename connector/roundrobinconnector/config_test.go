@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package roundrobinconnector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    *Config
+		errMsg string
+	}{
+		{
+			name: "default",
+			cfg:  &Config{},
+		},
+		{
+			name: "weights and sticky",
+			cfg: &Config{
+				Weights: map[component.ID]int{component.NewIDWithName(component.DataTypeTraces, "1"): 2},
+				Sticky:  StickyConfig{TraceID: true},
+			},
+		},
+		{
+			name: "zero weight",
+			cfg: &Config{
+				Weights: map[component.ID]int{component.NewIDWithName(component.DataTypeTraces, "1"): 0},
+			},
+			errMsg: `weights: pipeline "traces/1": weight must be positive`,
+		},
+		{
+			name: "too large weight",
+			cfg: &Config{
+				Weights: map[component.ID]int{component.NewIDWithName(component.DataTypeTraces, "1"): 1_000_000_000},
+			},
+			errMsg: `weights: pipeline "traces/1": weight must not exceed 1000000`,
+		},
+		{
+			name: "both sticky keys",
+			cfg: &Config{
+				Sticky: StickyConfig{ResourceAttribute: "host.name", TraceID: true},
+			},
+			errMsg: "sticky: only one of resource_attribute and trace_id can be set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
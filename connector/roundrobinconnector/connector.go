@@ -5,27 +5,34 @@ package roundrobinconnector // import "github.com/open-telemetry/opentelemetry-c
 
 import (
 	"context"
-	"sync/atomic"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
-func allConsumers[T any](r router[T]) ([]T, error) {
+// allConsumers returns the consumers of all the pipelines, and the pipeline IDs, sorted so that the
+// pipeline selected for a given sticky key doesn't change between restarts.
+func allConsumers[T any](r router[T]) ([]T, []component.ID, error) {
 	pipeIDs := r.PipelineIDs()
+	sort.Slice(pipeIDs, func(i, j int) bool { return pipeIDs[i].String() < pipeIDs[j].String() })
 	consumers := make([]T, len(pipeIDs))
 	for i, pipeID := range pipeIDs {
 		cons, err := r.Consumer(pipeID)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		consumers[i] = cons
 	}
-	return consumers, nil
+	return consumers, pipeIDs, nil
 }
 
 type router[T any] interface {
@@ -33,28 +40,126 @@ type router[T any] interface {
 	Consumer(pipelineIDs ...component.ID) (T, error)
 }
 
-func newLogs(nextConsumer consumer.Logs) (connector.Logs, error) {
-	nextConsumers, err := allConsumers[consumer.Logs](nextConsumer.(connector.LogsRouterAndConsumer))
+func newLogs(cfg *Config, nextConsumer consumer.Logs) (connector.Logs, error) {
+	if cfg.Sticky.TraceID {
+		return nil, errors.New("sticky: trace_id is only supported for traces")
+	}
+	nextConsumers, pipeIDs, err := allConsumers[consumer.Logs](nextConsumer.(connector.LogsRouterAndConsumer))
+	if err != nil {
+		return nil, err
+	}
+	rr, err := newRoundRobin(cfg, pipeIDs)
 	if err != nil {
 		return nil, err
 	}
-	return &roundRobin{nextLogs: nextConsumers}, nil
+	rr.nextLogs = nextConsumers
+	return rr, nil
 }
 
-func newMetrics(nextConsumer consumer.Metrics) (connector.Metrics, error) {
-	nextConsumers, err := allConsumers[consumer.Metrics](nextConsumer.(connector.MetricsRouterAndConsumer))
+func newMetrics(cfg *Config, nextConsumer consumer.Metrics) (connector.Metrics, error) {
+	if cfg.Sticky.TraceID {
+		return nil, errors.New("sticky: trace_id is only supported for traces")
+	}
+	nextConsumers, pipeIDs, err := allConsumers[consumer.Metrics](nextConsumer.(connector.MetricsRouterAndConsumer))
 	if err != nil {
 		return nil, err
 	}
-	return &roundRobin{nextMetrics: nextConsumers}, nil
+	rr, err := newRoundRobin(cfg, pipeIDs)
+	if err != nil {
+		return nil, err
+	}
+	rr.nextMetrics = nextConsumers
+	return rr, nil
 }
 
-func newTraces(nextConsumer consumer.Traces) (connector.Traces, error) {
-	nextConsumers, err := allConsumers[consumer.Traces](nextConsumer.(connector.TracesRouterAndConsumer))
+func newTraces(cfg *Config, nextConsumer consumer.Traces) (connector.Traces, error) {
+	nextConsumers, pipeIDs, err := allConsumers[consumer.Traces](nextConsumer.(connector.TracesRouterAndConsumer))
+	if err != nil {
+		return nil, err
+	}
+	rr, err := newRoundRobin(cfg, pipeIDs)
 	if err != nil {
 		return nil, err
 	}
-	return &roundRobin{nextTraces: nextConsumers}, nil
+	rr.nextTraces = nextConsumers
+	return rr, nil
+}
+
+func newRoundRobin(cfg *Config, pipeIDs []component.ID) (*roundRobin, error) {
+	weights := make([]int, len(pipeIDs))
+	for i, pipeID := range pipeIDs {
+		weights[i] = 1
+		if weight, ok := cfg.Weights[pipeID]; ok {
+			weights[i] = weight
+		}
+	}
+	for pipeID := range cfg.Weights {
+		if !containsID(pipeIDs, pipeID) {
+			return nil, fmt.Errorf("weights: pipeline %q is not connected to the connector", pipeID)
+		}
+	}
+	return &roundRobin{
+		selector:           newWeightedRoundRobin(weights),
+		sticky:             cfg.Sticky,
+		skipOnBackpressure: cfg.SkipOnBackpressure,
+	}, nil
+}
+
+func containsID(ids []component.ID, id component.ID) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+// weightedRoundRobin selects the indexes of the pipelines in turn, proportionally to their weight. The
+// selections are interleaved using the smooth weighted round-robin algorithm, one step per selection, so
+// that a pipeline with a high weight doesn't get bursts of data.
+type weightedRoundRobin struct {
+	weights []int
+	total   int
+	// cumulative holds the sum of the weights of the pipelines up to each of them included.
+	cumulative []int
+
+	mu      sync.Mutex
+	current []int
+}
+
+func newWeightedRoundRobin(weights []int) *weightedRoundRobin {
+	w := &weightedRoundRobin{
+		weights:    weights,
+		cumulative: make([]int, len(weights)),
+		current:    make([]int, len(weights)),
+	}
+	for i, weight := range weights {
+		w.total += weight
+		w.cumulative[i] = w.total
+	}
+	return w
+}
+
+// next returns the index of the next pipeline.
+func (w *weightedRoundRobin) next() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	best := 0
+	for i, weight := range w.weights {
+		w.current[i] += weight
+		if w.current[i] > w.current[best] {
+			best = i
+		}
+	}
+	w.current[best] -= w.total
+	return best
+}
+
+// forKey returns the index of the pipeline of the given key hash, each pipeline getting a share of
+// the hashes proportional to its weight.
+func (w *weightedRoundRobin) forKey(hash uint64) int {
+	k := int(hash % uint64(w.total))
+	return sort.Search(len(w.cumulative), func(i int) bool { return w.cumulative[i] > k })
 }
 
 // roundRobin is used to pass signals directly from one pipeline to one of the configured once in a round-robin mode.
@@ -63,24 +168,89 @@ func newTraces(nextConsumer consumer.Traces) (connector.Traces, error) {
 type roundRobin struct {
 	component.StartFunc
 	component.ShutdownFunc
-	nextConsumer atomic.Uint64
-	nextMetrics  []consumer.Metrics
-	nextLogs     []consumer.Logs
-	nextTraces   []consumer.Traces
+	nextMetrics []consumer.Metrics
+	nextLogs    []consumer.Logs
+	nextTraces  []consumer.Traces
+
+	selector           *weightedRoundRobin
+	sticky             StickyConfig
+	skipOnBackpressure bool
 }
 
 func (rr *roundRobin) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
+// next returns the index of the next consumer in the round-robin.
+func (rr *roundRobin) next() int {
+	return rr.selector.next()
+}
+
+// forKey returns the index of the consumer of the given sticky key hash.
+func (rr *roundRobin) forKey(hash uint64) int {
+	return rr.selector.forKey(hash)
+}
+
 func (rr *roundRobin) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
-	return rr.nextLogs[rr.nextConsumer.Add(1)%uint64(len(rr.nextLogs))].ConsumeLogs(ctx, ld)
+	if rr.sticky.ResourceAttribute != "" {
+		var errs error
+		for idx, batch := range splitLogsByResource(ld, rr.sticky.ResourceAttribute, rr.forKey) {
+			errs = errors.Join(errs, consumeAt(rr, rr.nextLogs, idx, func(c consumer.Logs) error {
+				return c.ConsumeLogs(ctx, batch)
+			}))
+		}
+		return errs
+	}
+	return consumeAt(rr, rr.nextLogs, rr.next(), func(c consumer.Logs) error {
+		return c.ConsumeLogs(ctx, ld)
+	})
 }
 
 func (rr *roundRobin) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
-	return rr.nextMetrics[rr.nextConsumer.Add(1)%uint64(len(rr.nextMetrics))].ConsumeMetrics(ctx, md)
+	if rr.sticky.ResourceAttribute != "" {
+		var errs error
+		for idx, batch := range splitMetricsByResource(md, rr.sticky.ResourceAttribute, rr.forKey) {
+			errs = errors.Join(errs, consumeAt(rr, rr.nextMetrics, idx, func(c consumer.Metrics) error {
+				return c.ConsumeMetrics(ctx, batch)
+			}))
+		}
+		return errs
+	}
+	return consumeAt(rr, rr.nextMetrics, rr.next(), func(c consumer.Metrics) error {
+		return c.ConsumeMetrics(ctx, md)
+	})
 }
 
 func (rr *roundRobin) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
-	return rr.nextTraces[rr.nextConsumer.Add(1)%uint64(len(rr.nextTraces))].ConsumeTraces(ctx, td)
+	if rr.sticky.enabled() {
+		var batches map[int]ptrace.Traces
+		if rr.sticky.TraceID {
+			batches = splitTracesByTraceID(td, rr.forKey)
+		} else {
+			batches = splitTracesByResource(td, rr.sticky.ResourceAttribute, rr.forKey)
+		}
+		var errs error
+		for idx, batch := range batches {
+			errs = errors.Join(errs, consumeAt(rr, rr.nextTraces, idx, func(c consumer.Traces) error {
+				return c.ConsumeTraces(ctx, batch)
+			}))
+		}
+		return errs
+	}
+	return consumeAt(rr, rr.nextTraces, rr.next(), func(c consumer.Traces) error {
+		return c.ConsumeTraces(ctx, td)
+	})
+}
+
+// consumeAt sends the data to the consumer at idx. If skipping on backpressure is enabled, the data is sent
+// to the following consumers in turn while the previous one returns a non-permanent error.
+func consumeAt[T any](rr *roundRobin, consumers []T, idx int, consume func(T) error) error {
+	err := consume(consumers[idx])
+	if !rr.skipOnBackpressure {
+		return err
+	}
+	for i := 1; i < len(consumers) && err != nil && !consumererror.IsPermanent(err); i++ {
+		err = consume(consumers[(idx+i)%len(consumers)])
+	}
+	return err
 }
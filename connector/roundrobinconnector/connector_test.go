@@ -5,16 +5,20 @@ package roundrobinconnector
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -138,3 +142,195 @@ func TestTracesRoundRobin(t *testing.T) {
 
 	assert.NoError(t, traces.Shutdown(ctx))
 }
+
+func TestLogsWeights(t *testing.T) {
+	f := NewFactory()
+	cfg := &Config{Weights: map[component.ID]int{component.NewIDWithName(component.DataTypeLogs, "0"): 3}}
+
+	ctx := context.Background()
+	sink1 := new(consumertest.LogsSink)
+	sink2 := new(consumertest.LogsSink)
+	logs, err := f.CreateLogsToLogs(ctx, connectortest.NewNopSettings(), cfg, connector.NewLogsRouter(newPipelineMap[consumer.Logs](component.DataTypeLogs, sink1, sink2)))
+	require.NoError(t, err)
+
+	for i := 0; i < 8; i++ {
+		assert.NoError(t, logs.ConsumeLogs(ctx, plog.NewLogs()))
+	}
+
+	assert.Equal(t, 6, len(sink1.AllLogs()))
+	assert.Equal(t, 2, len(sink2.AllLogs()))
+}
+
+func TestWeightedRoundRobin(t *testing.T) {
+	w := newWeightedRoundRobin([]int{5, 1, 1})
+	var selected []int
+	for i := 0; i < 7; i++ {
+		selected = append(selected, w.next())
+	}
+	// the selections of the pipeline with the highest weight are interleaved with the others
+	assert.Equal(t, []int{0, 0, 1, 0, 2, 0, 0}, selected)
+
+	assert.Equal(t, 0, w.forKey(4))
+	assert.Equal(t, 1, w.forKey(5))
+	assert.Equal(t, 2, w.forKey(13))
+}
+
+func TestWeightedRoundRobinLargeWeights(t *testing.T) {
+	// the selections are computed step by step, without allocating the whole sequence
+	w := newWeightedRoundRobin([]int{maxWeight, maxWeight - 1, 1})
+	counts := make([]int, 3)
+	for i := 0; i < 2*maxWeight; i++ {
+		counts[w.next()]++
+	}
+	assert.Equal(t, []int{maxWeight, maxWeight - 1, 1}, counts)
+}
+
+func TestWeightsUnknownPipeline(t *testing.T) {
+	f := NewFactory()
+	cfg := &Config{Weights: map[component.ID]int{component.NewIDWithName(component.DataTypeMetrics, "unknown"): 2}}
+
+	_, err := f.CreateMetricsToMetrics(context.Background(), connectortest.NewNopSettings(), cfg, connector.NewMetricsRouter(newPipelineMap[consumer.Metrics](component.DataTypeMetrics, consumertest.NewNop(), consumertest.NewNop())))
+	assert.EqualError(t, err, `weights: pipeline "metrics/unknown" is not connected to the connector`)
+}
+
+func TestMetricsStickyResourceAttribute(t *testing.T) {
+	f := NewFactory()
+	cfg := &Config{Sticky: StickyConfig{ResourceAttribute: "host.name"}}
+
+	ctx := context.Background()
+	sinks := []*consumertest.MetricsSink{new(consumertest.MetricsSink), new(consumertest.MetricsSink), new(consumertest.MetricsSink)}
+	metrics, err := f.CreateMetricsToMetrics(ctx, connectortest.NewNopSettings(), cfg, connector.NewMetricsRouter(newPipelineMap[consumer.Metrics](component.DataTypeMetrics, sinks[0], sinks[1], sinks[2])))
+	require.NoError(t, err)
+
+	hosts := []string{"host-a", "host-b", "host-c", "host-d"}
+	for i := 0; i < 3; i++ {
+		md := pmetric.NewMetrics()
+		for _, host := range hosts {
+			rm := md.ResourceMetrics().AppendEmpty()
+			rm.Resource().Attributes().PutStr("host.name", host)
+			rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("cpu")
+		}
+		assert.NoError(t, metrics.ConsumeMetrics(ctx, md))
+	}
+
+	total := 0
+	for _, host := range hosts {
+		matching := 0
+		for _, sink := range sinks {
+			count := 0
+			for _, md := range sink.AllMetrics() {
+				for j := 0; j < md.ResourceMetrics().Len(); j++ {
+					if v, _ := md.ResourceMetrics().At(j).Resource().Attributes().Get("host.name"); v.Str() == host {
+						count++
+					}
+				}
+			}
+			if count > 0 {
+				matching++
+				assert.Equal(t, 3, count)
+				total += count
+			}
+		}
+		assert.Equal(t, 1, matching, "host %q was sent to more than one pipeline", host)
+	}
+	assert.Equal(t, 12, total)
+}
+
+func TestTracesStickyTraceID(t *testing.T) {
+	f := NewFactory()
+	cfg := &Config{Sticky: StickyConfig{TraceID: true}}
+
+	ctx := context.Background()
+	sinks := []*consumertest.TracesSink{new(consumertest.TracesSink), new(consumertest.TracesSink), new(consumertest.TracesSink)}
+	traces, err := f.CreateTracesToTraces(ctx, connectortest.NewNopSettings(), cfg, connector.NewTracesRouter(newPipelineMap[consumer.Traces](component.DataTypeTraces, sinks[0], sinks[1], sinks[2])))
+	require.NoError(t, err)
+
+	traceIDs := []pcommon.TraceID{{1}, {2}, {3}, {4}, {5}}
+	for i := 0; i < 3; i++ {
+		td := ptrace.NewTraces()
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", "svc")
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.Scope().SetName("scope")
+		for _, traceID := range traceIDs {
+			ss.Spans().AppendEmpty().SetTraceID(traceID)
+		}
+		assert.NoError(t, traces.ConsumeTraces(ctx, td))
+	}
+
+	total := 0
+	for _, traceID := range traceIDs {
+		matching := 0
+		for _, sink := range sinks {
+			count := 0
+			for _, td := range sink.AllTraces() {
+				rs := td.ResourceSpans().At(0)
+				v, _ := rs.Resource().Attributes().Get("service.name")
+				assert.Equal(t, "svc", v.Str())
+				ss := rs.ScopeSpans().At(0)
+				assert.Equal(t, "scope", ss.Scope().Name())
+				for j := 0; j < ss.Spans().Len(); j++ {
+					if ss.Spans().At(j).TraceID() == traceID {
+						count++
+					}
+				}
+			}
+			if count > 0 {
+				matching++
+				assert.Equal(t, 3, count)
+				total += count
+			}
+		}
+		assert.Equal(t, 1, matching, "trace %v was sent to more than one pipeline", traceID)
+	}
+	assert.Equal(t, 15, total)
+}
+
+func TestStickyTraceIDNotSupported(t *testing.T) {
+	f := NewFactory()
+	cfg := &Config{Sticky: StickyConfig{TraceID: true}}
+
+	_, err := f.CreateLogsToLogs(context.Background(), connectortest.NewNopSettings(), cfg, connector.NewLogsRouter(newPipelineMap[consumer.Logs](component.DataTypeLogs, consumertest.NewNop())))
+	assert.EqualError(t, err, "sticky: trace_id is only supported for traces")
+}
+
+func TestSkipOnBackpressure(t *testing.T) {
+	f := NewFactory()
+	ctx := context.Background()
+
+	t.Run("skips non-permanent errors", func(t *testing.T) {
+		cfg := &Config{SkipOnBackpressure: true}
+		sink := new(consumertest.LogsSink)
+		logs, err := f.CreateLogsToLogs(ctx, connectortest.NewNopSettings(), cfg, connector.NewLogsRouter(newPipelineMap[consumer.Logs](component.DataTypeLogs, consumertest.NewErr(errors.New("queue is full")), sink)))
+		require.NoError(t, err)
+
+		for i := 0; i < 4; i++ {
+			assert.NoError(t, logs.ConsumeLogs(ctx, plog.NewLogs()))
+		}
+		assert.Equal(t, 4, len(sink.AllLogs()))
+	})
+
+	t.Run("returns permanent errors", func(t *testing.T) {
+		cfg := &Config{SkipOnBackpressure: true}
+		sink := new(consumertest.LogsSink)
+		logs, err := f.CreateLogsToLogs(ctx, connectortest.NewNopSettings(), cfg, connector.NewLogsRouter(newPipelineMap[consumer.Logs](component.DataTypeLogs, consumertest.NewErr(consumererror.NewPermanent(errors.New("bad data"))), sink)))
+		require.NoError(t, err)
+
+		var errs int
+		for i := 0; i < 4; i++ {
+			if logs.ConsumeLogs(ctx, plog.NewLogs()) != nil {
+				errs++
+			}
+		}
+		assert.Equal(t, 2, errs)
+		assert.Equal(t, 2, len(sink.AllLogs()))
+	})
+
+	t.Run("all pipelines fail", func(t *testing.T) {
+		cfg := &Config{SkipOnBackpressure: true}
+		logs, err := f.CreateLogsToLogs(ctx, connectortest.NewNopSettings(), cfg, connector.NewLogsRouter(newPipelineMap[consumer.Logs](component.DataTypeLogs, consumertest.NewErr(errors.New("queue is full")), consumertest.NewErr(errors.New("queue is full")))))
+		require.NoError(t, err)
+
+		assert.EqualError(t, logs.ConsumeLogs(ctx, plog.NewLogs()), "queue is full")
+	})
+}
@@ -35,28 +35,28 @@ func createDefaultConfig() component.Config {
 func createLogsToLogs(
 	_ context.Context,
 	_ connector.Settings,
-	_ component.Config,
+	cfg component.Config,
 	nextConsumer consumer.Logs,
 ) (connector.Logs, error) {
-	return newLogs(nextConsumer)
+	return newLogs(cfg.(*Config), nextConsumer)
 }
 
 // createMetricsToMetrics creates a metrics receiver based on provided config.
 func createMetricsToMetrics(
 	_ context.Context,
 	_ connector.Settings,
-	_ component.Config,
+	cfg component.Config,
 	nextConsumer consumer.Metrics,
 ) (connector.Metrics, error) {
-	return newMetrics(nextConsumer)
+	return newMetrics(cfg.(*Config), nextConsumer)
 }
 
 // createTracesToTraces creates a trace receiver based on provided config.
 func createTracesToTraces(
 	_ context.Context,
 	_ connector.Settings,
-	_ component.Config,
+	cfg component.Config,
 	nextConsumer consumer.Traces,
 ) (connector.Traces, error) {
-	return newTraces(nextConsumer)
+	return newTraces(cfg.(*Config), nextConsumer)
 }
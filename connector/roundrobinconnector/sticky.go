@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package roundrobinconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/roundrobinconnector"
+
+import (
+	"hash/fnv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// hashResourceAttribute hashes the value of the attribute of the resource, the resources without the
+// attribute all having the same hash.
+func hashResourceAttribute(res pcommon.Resource, attr string) uint64 {
+	h := fnv.New64a()
+	if v, ok := res.Attributes().Get(attr); ok {
+		_, _ = h.Write([]byte(v.AsString()))
+	}
+	return h.Sum64()
+}
+
+func hashTraceID(traceID pcommon.TraceID) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(traceID[:])
+	return h.Sum64()
+}
+
+// splitLogsByResource splits the logs by the index of the consumer selected by forKey for each resource.
+func splitLogsByResource(ld plog.Logs, attr string, forKey func(uint64) int) map[int]plog.Logs {
+	batches := make(map[int]plog.Logs)
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		idx := forKey(hashResourceAttribute(rl.Resource(), attr))
+		batch, ok := batches[idx]
+		if !ok {
+			batch = plog.NewLogs()
+			batches[idx] = batch
+		}
+		rl.CopyTo(batch.ResourceLogs().AppendEmpty())
+	}
+	return batches
+}
+
+// splitMetricsByResource splits the metrics by the index of the consumer selected by forKey for each resource.
+func splitMetricsByResource(md pmetric.Metrics, attr string, forKey func(uint64) int) map[int]pmetric.Metrics {
+	batches := make(map[int]pmetric.Metrics)
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		idx := forKey(hashResourceAttribute(rm.Resource(), attr))
+		batch, ok := batches[idx]
+		if !ok {
+			batch = pmetric.NewMetrics()
+			batches[idx] = batch
+		}
+		rm.CopyTo(batch.ResourceMetrics().AppendEmpty())
+	}
+	return batches
+}
+
+// splitTracesByResource splits the traces by the index of the consumer selected by forKey for each resource.
+func splitTracesByResource(td ptrace.Traces, attr string, forKey func(uint64) int) map[int]ptrace.Traces {
+	batches := make(map[int]ptrace.Traces)
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		idx := forKey(hashResourceAttribute(rs.Resource(), attr))
+		batch, ok := batches[idx]
+		if !ok {
+			batch = ptrace.NewTraces()
+			batches[idx] = batch
+		}
+		rs.CopyTo(batch.ResourceSpans().AppendEmpty())
+	}
+	return batches
+}
+
+// splitTracesByTraceID splits the traces by the index of the consumer selected by forKey for the trace ID
+// of each span, so that all the spans of a trace are sent to the same consumer.
+func splitTracesByTraceID(td ptrace.Traces, forKey func(uint64) int) map[int]ptrace.Traces {
+	batches := make(map[int]ptrace.Traces)
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		destResources := make(map[int]ptrace.ResourceSpans)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			destScopes := make(map[int]ptrace.ScopeSpans)
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+				idx := forKey(hashTraceID(span.TraceID()))
+				destScope, ok := destScopes[idx]
+				if !ok {
+					destResource, ok := destResources[idx]
+					if !ok {
+						batch, ok := batches[idx]
+						if !ok {
+							batch = ptrace.NewTraces()
+							batches[idx] = batch
+						}
+						destResource = batch.ResourceSpans().AppendEmpty()
+						rs.Resource().CopyTo(destResource.Resource())
+						destResource.SetSchemaUrl(rs.SchemaUrl())
+						destResources[idx] = destResource
+					}
+					destScope = destResource.ScopeSpans().AppendEmpty()
+					ss.Scope().CopyTo(destScope.Scope())
+					destScope.SetSchemaUrl(ss.SchemaUrl())
+					destScopes[idx] = destScope
+				}
+				span.CopyTo(destScope.Spans().AppendEmpty())
+			}
+		}
+	}
+	return batches
+}
@@ -31,6 +31,10 @@ const (
 	clientKind         = "client"
 	serverKind         = "server"
 	virtualNodeLabel   = "virtual_node"
+
+	// serverAddressAttribute is the attribute holding the name of the server a client span is calling,
+	// which isn't part of the semantic conventions version used by this package.
+	serverAddressAttribute = "server.address"
 )
 
 var (
@@ -42,7 +46,7 @@ var (
 	}
 
 	defaultPeerAttributes = []string{
-		semconv.AttributePeerService, semconv.AttributeDBName, semconv.AttributeDBSystem,
+		semconv.AttributePeerService, semconv.AttributeDBName, semconv.AttributeDBSystem, serverAddressAttribute,
 	}
 
 	defaultDatabaseNameAttribute = semconv.AttributeDBName
@@ -345,26 +349,38 @@ func (p *serviceGraphConnector) onExpire(e *store.Edge) {
 		zap.Stringer("trace_id", e.TraceID),
 	)
 
+	// Edges whose missing side is inferred as a virtual node are completed rather than counted as expired:
+	// the root server spans called by a user, and the client spans calling an uninstrumented peer.
+	if p.completeWithVirtualNode(e) {
+		p.telemetryBuilder.ConnectorServicegraphVirtualNodeEdges.Add(context.Background(), 1)
+		return
+	}
 	p.telemetryBuilder.ConnectorServicegraphExpiredEdges.Add(context.Background(), 1)
+}
 
-	if virtualNodeFeatureGate.IsEnabled() && len(p.config.VirtualNodePeerAttributes) > 0 {
-		e.ConnectionType = store.VirtualNode
-		if len(e.ClientService) == 0 && e.Key.SpanIDIsEmpty() {
-			e.ClientService = "user"
-			if p.config.VirtualNodeExtraLabel {
-				e.VirtualNodeLabel = store.ClientVirtualNode
-			}
-			p.onComplete(e)
-		}
+// completeWithVirtualNode completes the edge with a virtual node in place of its missing side, if enabled.
+func (p *serviceGraphConnector) completeWithVirtualNode(e *store.Edge) bool {
+	if !virtualNodeFeatureGate.IsEnabled() || len(p.config.VirtualNodePeerAttributes) == 0 {
+		return false
+	}
 
-		if len(e.ServerService) == 0 {
-			e.ServerService = p.getPeerHost(p.config.VirtualNodePeerAttributes, e.Peer)
-			if p.config.VirtualNodeExtraLabel {
-				e.VirtualNodeLabel = store.ServerVirtualNode
-			}
-			p.onComplete(e)
+	switch {
+	case len(e.ClientService) == 0 && e.Key.SpanIDIsEmpty():
+		e.ClientService = "user"
+		if p.config.VirtualNodeExtraLabel {
+			e.VirtualNodeLabel = store.ClientVirtualNode
+		}
+	case len(e.ServerService) == 0:
+		e.ServerService = p.getPeerHost(p.config.VirtualNodePeerAttributes, e.Peer)
+		if p.config.VirtualNodeExtraLabel {
+			e.VirtualNodeLabel = store.ServerVirtualNode
 		}
+	default:
+		return false
 	}
+	e.ConnectionType = store.VirtualNode
+	p.onComplete(e)
+	return true
 }
 
 func (p *serviceGraphConnector) aggregateMetricsForEdge(e *store.Edge) {
@@ -29,6 +29,7 @@ import (
 	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 	"go.uber.org/zap/zaptest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector/internal/store"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
 )
@@ -619,3 +620,65 @@ func TestVirtualNodeClientLabels(t *testing.T) {
 	)
 	require.NoError(t, err)
 }
+
+func TestVirtualNodeEdgesFromUnpairedClientSpans(t *testing.T) {
+	cfg := &Config{
+		Store: StoreConfig{
+			MaxItems: 10,
+			TTL:      time.Nanosecond,
+		},
+	}
+
+	reader := sdkmetric.NewManualReader()
+	set := setupTelemetry(reader)
+	conn, err := newConnector(set, cfg, newMockMetricsExporter())
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+	defer require.NoError(t, conn.Shutdown(context.Background()))
+
+	// A client span calling an uninstrumented database, whose server span is never received.
+	td := buildSampleTrace(t, "value")
+	spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	spans.RemoveIf(func(span ptrace.Span) bool { return span.Kind() == ptrace.SpanKindServer })
+	spans.At(0).Attributes().PutStr("server.address", "db.example.com")
+	require.NoError(t, conn.aggregateMetrics(context.Background(), td))
+
+	time.Sleep(time.Millisecond)
+	conn.store.Expire()
+	require.Equal(t, 0, conn.store.Len())
+
+	md, err := conn.buildMetrics()
+	require.NoError(t, err)
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Greater(t, metrics.Len(), 0)
+	requestTotal := metrics.At(0)
+	require.Equal(t, "traces_service_graph_request_total", requestTotal.Name())
+	require.Equal(t, 1, requestTotal.Sum().DataPoints().Len())
+	attrs := requestTotal.Sum().DataPoints().At(0).Attributes()
+	server, _ := attrs.Get("server")
+	assert.Equal(t, "db.example.com", server.Str())
+	connectionType, _ := attrs.Get("connection_type")
+	assert.Equal(t, string(store.VirtualNode), connectionType.Str())
+
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	got := map[string]metricdata.Metrics{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		got[m.Name] = m
+	}
+	assert.NotContains(t, got, "connector_servicegraph_expired_edges")
+	want := metricdata.Metrics{
+		Name:        "connector_servicegraph_virtual_node_edges",
+		Description: "Number of edges completed with a virtual node because their matching span was never received",
+		Unit:        "1",
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints: []metricdata.DataPoint[int64]{
+				{Value: 1},
+			},
+		},
+	}
+	metricdatatest.AssertEqual(t, want, got["connector_servicegraph_virtual_node_edges"], metricdatatest.IgnoreTimestamp())
+}
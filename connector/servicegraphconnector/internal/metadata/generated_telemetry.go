@@ -24,11 +24,12 @@ func Tracer(settings component.TelemetrySettings) trace.Tracer {
 // TelemetryBuilder provides an interface for components to report telemetry
 // as defined in metadata and user config.
 type TelemetryBuilder struct {
-	meter                             metric.Meter
-	ConnectorServicegraphDroppedSpans metric.Int64Counter
-	ConnectorServicegraphExpiredEdges metric.Int64Counter
-	ConnectorServicegraphTotalEdges   metric.Int64Counter
-	level                             configtelemetry.Level
+	meter                                 metric.Meter
+	ConnectorServicegraphDroppedSpans     metric.Int64Counter
+	ConnectorServicegraphExpiredEdges     metric.Int64Counter
+	ConnectorServicegraphTotalEdges       metric.Int64Counter
+	ConnectorServicegraphVirtualNodeEdges metric.Int64Counter
+	level                                 configtelemetry.Level
 }
 
 // telemetryBuilderOption applies changes to default builder.
@@ -72,5 +73,11 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...teleme
 		metric.WithUnit("1"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ConnectorServicegraphVirtualNodeEdges, err = builder.meter.Int64Counter(
+		"connector_servicegraph_virtual_node_edges",
+		metric.WithDescription("Number of edges completed with a virtual node because their matching span was never received"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
 	return &builder, errs
 }
@@ -96,6 +96,11 @@ type HistogramConfig struct {
 type ExemplarsConfig struct {
 	Enabled         bool `mapstructure:"enabled"`
 	MaxPerDataPoint *int `mapstructure:"max_per_data_point"`
+	// Reservoir is the strategy selecting the exemplars kept when a data point sees more spans than MaxPerDataPoint:
+	// first_seen (default), per_bucket or random.
+	Reservoir metrics.ExemplarReservoir `mapstructure:"reservoir"`
+	// SampledOnly restricts the exemplars to the spans whose trace flags have the sampled flag set.
+	SampledOnly bool `mapstructure:"sampled_only"`
 }
 
 type ExponentialHistogramConfig struct {
@@ -136,6 +141,10 @@ func (c Config) Validate() error {
 		return errors.New("use either `explicit` or `exponential` buckets histogram")
 	}
 
+	if c.Exemplars.Reservoir == metrics.ExemplarReservoirRandom && c.Exemplars.MaxPerDataPoint == nil {
+		return fmt.Errorf("invalid exemplars: max_per_data_point must be set when using the %q reservoir", c.Exemplars.Reservoir)
+	}
+
 	if c.MetricsFlushInterval < 0 {
 		return fmt.Errorf("invalid metrics_flush_interval: %v, the duration should be positive", c.MetricsFlushInterval)
 	}
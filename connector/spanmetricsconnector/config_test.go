@@ -116,6 +116,30 @@ func TestLoadConfig(t *testing.T) {
 				Exemplars:                ExemplarsConfig{Enabled: true, MaxPerDataPoint: &defaultMaxPerDatapoint},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "exemplars_random_reservoir"),
+			expected: &Config{
+				AggregationTemporality:   "AGGREGATION_TEMPORALITY_CUMULATIVE",
+				DimensionsCacheSize:      defaultDimensionsCacheSize,
+				ResourceMetricsCacheSize: defaultResourceMetricsCacheSize,
+				MetricsFlushInterval:     60 * time.Second,
+				Histogram:                HistogramConfig{Disable: false, Unit: defaultUnit},
+				Exemplars: ExemplarsConfig{
+					Enabled:         true,
+					MaxPerDataPoint: &defaultMaxPerDatapoint,
+					Reservoir:       metrics.ExemplarReservoirRandom,
+					SampledOnly:     true,
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_exemplars_reservoir"),
+			errorMessage: "unknown exemplar reservoir \"last_seen\"",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_exemplars_random_reservoir_without_max"),
+			errorMessage: "invalid exemplars: max_per_data_point must be set when using the \"random\" reservoir",
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "resource_metrics_key_attributes"),
 			expected: &Config{
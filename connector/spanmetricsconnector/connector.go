@@ -41,6 +41,9 @@ const (
 	metricNameEvents   = "events"
 
 	defaultUnit = metrics.Milliseconds
+
+	// traceFlagsSampled is the sampled flag of the W3C trace flags, held in the lower 8 bits of the span flags.
+	traceFlagsSampled = 0x01
 )
 
 type connectorImp struct {
@@ -166,7 +169,7 @@ func initHistogramMetrics(cfg Config) metrics.HistogramMetrics {
 		if cfg.Histogram.Exponential.MaxSize != 0 {
 			maxSize = cfg.Histogram.Exponential.MaxSize
 		}
-		return metrics.NewExponentialHistogramMetrics(maxSize, cfg.Exemplars.MaxPerDataPoint)
+		return metrics.NewExponentialHistogramMetrics(maxSize, cfg.Exemplars.MaxPerDataPoint, cfg.Exemplars.Reservoir)
 	}
 
 	var bounds []float64
@@ -184,7 +187,7 @@ func initHistogramMetrics(cfg Config) metrics.HistogramMetrics {
 		}
 	}
 
-	return metrics.NewExplicitHistogramMetrics(bounds, cfg.Exemplars.MaxPerDataPoint)
+	return metrics.NewExplicitHistogramMetrics(bounds, cfg.Exemplars.MaxPerDataPoint, cfg.Exemplars.Reservoir)
 }
 
 // unitDivider returns a unit divider to convert nanoseconds to milliseconds or seconds.
@@ -412,9 +415,7 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 				}
 				// aggregate sums metrics
 				s := sums.GetOrCreate(key, attributes)
-				if p.config.Exemplars.Enabled && !span.TraceID().IsEmpty() {
-					s.AddExemplar(span.TraceID(), span.SpanID(), duration)
-				}
+				p.addExemplar(span, duration, s)
 				s.Add(1)
 
 				// aggregate events metrics
@@ -436,9 +437,7 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 							p.metricKeyToDimensions.Add(eKey, eAttributes)
 						}
 						e := events.GetOrCreate(eKey, eAttributes)
-						if p.config.Exemplars.Enabled && !span.TraceID().IsEmpty() {
-							e.AddExemplar(span.TraceID(), span.SpanID(), duration)
-						}
+						p.addExemplar(span, duration, e)
 						e.Add(1)
 					}
 				}
@@ -447,15 +446,23 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 	}
 }
 
-func (p *connectorImp) addExemplar(span ptrace.Span, duration float64, h metrics.Histogram) {
+// exemplarRecorder is a metric data point recording exemplars.
+type exemplarRecorder interface {
+	AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64)
+}
+
+func (p *connectorImp) addExemplar(span ptrace.Span, duration float64, r exemplarRecorder) {
 	if !p.config.Exemplars.Enabled {
 		return
 	}
 	if span.TraceID().IsEmpty() {
 		return
 	}
+	if p.config.Exemplars.SampledOnly && span.Flags()&traceFlagsSampled == 0 {
+		return
+	}
 
-	h.AddExemplar(span.TraceID(), span.SpanID(), duration)
+	r.AddExemplar(span.TraceID(), span.SpanID(), duration)
 }
 
 type resourceKey [16]byte
@@ -479,8 +486,8 @@ func (p *connectorImp) getOrCreateResourceMetrics(attr pcommon.Map, startTimesta
 	if !ok {
 		v = &resourceMetrics{
 			histograms:     initHistogramMetrics(p.config),
-			sums:           metrics.NewSumMetrics(p.config.Exemplars.MaxPerDataPoint),
-			events:         metrics.NewSumMetrics(p.config.Exemplars.MaxPerDataPoint),
+			sums:           metrics.NewSumMetrics(p.config.Exemplars.MaxPerDataPoint, p.config.Exemplars.Reservoir),
+			events:         metrics.NewSumMetrics(p.config.Exemplars.MaxPerDataPoint, p.config.Exemplars.Reservoir),
 			attributes:     attr,
 			startTimestamp: startTimestamp,
 		}
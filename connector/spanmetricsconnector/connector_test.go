@@ -1379,7 +1379,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 		{
 			name:   "initialize histogram with no config provided",
 			config: Config{},
-			want:   metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsMs, nil),
+			want:   metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsMs, nil, ""),
 		},
 		{
 			name: "Disable histogram",
@@ -1397,7 +1397,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					Unit: metrics.Milliseconds,
 				},
 			},
-			want: metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsMs, nil),
+			want: metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsMs, nil, ""),
 		},
 		{
 			name: "initialize explicit histogram with default bounds (seconds)",
@@ -1406,7 +1406,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					Unit: metrics.Seconds,
 				},
 			},
-			want: metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsSeconds, nil),
+			want: metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsSeconds, nil, ""),
 		},
 		{
 			name: "initialize explicit histogram with bounds (seconds)",
@@ -1421,7 +1421,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					},
 				},
 			},
-			want: metrics.NewExplicitHistogramMetrics([]float64{0.1, 1}, nil),
+			want: metrics.NewExplicitHistogramMetrics([]float64{0.1, 1}, nil, ""),
 		},
 		{
 			name: "initialize explicit histogram with bounds (ms)",
@@ -1436,7 +1436,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					},
 				},
 			},
-			want: metrics.NewExplicitHistogramMetrics([]float64{100, 1000}, nil),
+			want: metrics.NewExplicitHistogramMetrics([]float64{100, 1000}, nil, ""),
 		},
 		{
 			name: "initialize exponential histogram",
@@ -1448,7 +1448,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					},
 				},
 			},
-			want: metrics.NewExponentialHistogramMetrics(10, nil),
+			want: metrics.NewExponentialHistogramMetrics(10, nil, ""),
 		},
 		{
 			name: "initialize exponential histogram with default max buckets count",
@@ -1458,7 +1458,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					Exponential: &ExponentialHistogramConfig{},
 				},
 			},
-			want: metrics.NewExponentialHistogramMetrics(structure.DefaultMaxSize, nil),
+			want: metrics.NewExponentialHistogramMetrics(structure.DefaultMaxSize, nil, ""),
 		},
 	}
 	for _, tt := range tests {
@@ -1604,6 +1604,49 @@ func TestExemplarsAreDiscardedAfterFlushing(t *testing.T) {
 	}
 }
 
+func TestExemplarsFromSampledSpansOnly(t *testing.T) {
+	sampledOnlyExemplarsConfig := func() ExemplarsConfig {
+		return ExemplarsConfig{Enabled: true, SampledOnly: true}
+	}
+	for _, histogramConfig := range []func() HistogramConfig{explicitHistogramsConfig, exponentialHistogramsConfig} {
+		p, err := newConnectorImp(stringp("defaultNullValue"), histogramConfig, sampledOnlyExemplarsConfig, disabledEventsConfig, cumulative, 0, []string{}, 1000, clock.NewMock(time.Now()))
+		require.NoError(t, err)
+		p.metricsConsumer = &consumertest.MetricsSink{}
+
+		traces := ptrace.NewTraces()
+		sampledTraceID := [16]byte{0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1A, 0x1B, 0x1C, 0x1D, 0x1E, 0x1F, 0x10}
+		notSampledTraceID := [16]byte{0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27, 0x28, 0x29, 0x2A, 0x2B, 0x2C, 0x2D, 0x2E, 0x2F, 0x20}
+		initServiceSpans(
+			serviceSpans{
+				serviceName: "service-b",
+				spans: []span{
+					{
+						name:       "/ping",
+						kind:       ptrace.SpanKindServer,
+						statusCode: ptrace.StatusCodeError,
+						traceID:    notSampledTraceID,
+						spanID:     [8]byte{0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27, 0x28},
+					},
+					{
+						name:       "/ping",
+						kind:       ptrace.SpanKindServer,
+						statusCode: ptrace.StatusCodeError,
+						traceID:    sampledTraceID,
+						spanID:     [8]byte{0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18},
+					},
+				},
+			}, traces.ResourceSpans().AppendEmpty())
+		traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).SetFlags(traceFlagsSampled)
+
+		ctx := metadata.NewIncomingContext(context.Background(), nil)
+		require.NoError(t, p.ConsumeTraces(ctx, traces))
+		p.exportMetrics(ctx)
+
+		m := p.metricsConsumer.(*consumertest.MetricsSink).AllMetrics()[0]
+		assertDataPointsHaveExactlyOneExemplarForTrace(t, m, sampledTraceID)
+	}
+}
+
 func assertDataPointsHaveExactlyOneExemplarForTrace(t *testing.T, metrics pmetric.Metrics, traceID pcommon.TraceID) {
 	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
 		rm := metrics.ResourceMetrics().At(i)
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metrics"
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// ExemplarReservoir is the strategy selecting the exemplars kept for a data point
+// when more spans are seen than `max_per_data_point`.
+type ExemplarReservoir string
+
+const (
+	// ExemplarReservoirFirstSeen keeps the exemplars of the first spans seen.
+	ExemplarReservoirFirstSeen ExemplarReservoir = "first_seen"
+	// ExemplarReservoirPerBucket keeps the exemplar of the last span seen in each bucket of explicit histograms,
+	// and falls back to ExemplarReservoirRandom for the other metrics.
+	ExemplarReservoirPerBucket ExemplarReservoir = "per_bucket"
+	// ExemplarReservoirRandom keeps a uniformly random sample of the spans seen, using reservoir sampling.
+	ExemplarReservoirRandom ExemplarReservoir = "random"
+)
+
+var _ encoding.TextUnmarshaler = (*ExemplarReservoir)(nil)
+
+// UnmarshalText unmarshalls text to an ExemplarReservoir.
+func (r *ExemplarReservoir) UnmarshalText(text []byte) error {
+	if r == nil {
+		return errors.New("cannot unmarshal to a nil *ExemplarReservoir")
+	}
+
+	str := ExemplarReservoir(strings.ToLower(string(text)))
+	switch str {
+	case ExemplarReservoirFirstSeen, ExemplarReservoirPerBucket, ExemplarReservoirRandom:
+		*r = str
+		return nil
+	}
+	return fmt.Errorf("unknown exemplar reservoir %q, allowed reservoirs are %q, %q and %q",
+		str, ExemplarReservoirFirstSeen, ExemplarReservoirPerBucket, ExemplarReservoirRandom)
+}
+
+// offerExemplar adds an exemplar to exemplars according to the reservoir strategy, seen being the number
+// of exemplars offered since exemplars were last cleared, this one included.
+func offerExemplar(
+	exemplars pmetric.ExemplarSlice,
+	reservoir ExemplarReservoir,
+	maxExemplarCount *int,
+	seen uint64,
+	traceID pcommon.TraceID,
+	spanID pcommon.SpanID,
+	value float64,
+) {
+	if maxExemplarCount == nil || exemplars.Len() < *maxExemplarCount {
+		setExemplar(exemplars.AppendEmpty(), traceID, spanID, value)
+		return
+	}
+	if reservoir != ExemplarReservoirRandom && reservoir != ExemplarReservoirPerBucket {
+		return
+	}
+	// Algorithm R: the exemplar replaces a kept one with a probability of maxExemplarCount/seen.
+	if i := rand.Int63n(int64(seen)); i < int64(*maxExemplarCount) {
+		setExemplar(exemplars.At(int(i)), traceID, spanID, value)
+	}
+}
+
+func setExemplar(e pmetric.Exemplar, traceID pcommon.TraceID, spanID pcommon.SpanID, value float64) {
+	e.SetTraceID(traceID)
+	e.SetSpanID(spanID)
+	e.SetDoubleValue(value)
+}
@@ -25,20 +25,26 @@ type Histogram interface {
 }
 
 type explicitHistogramMetrics struct {
-	metrics          map[Key]*explicitHistogram
-	bounds           []float64
-	maxExemplarCount *int
+	metrics           map[Key]*explicitHistogram
+	bounds            []float64
+	maxExemplarCount  *int
+	exemplarReservoir ExemplarReservoir
 }
 
 type exponentialHistogramMetrics struct {
-	metrics          map[Key]*exponentialHistogram
-	maxSize          int32
-	maxExemplarCount *int
+	metrics           map[Key]*exponentialHistogram
+	maxSize           int32
+	maxExemplarCount  *int
+	exemplarReservoir ExemplarReservoir
 }
 
 type explicitHistogram struct {
 	attributes pcommon.Map
 	exemplars  pmetric.ExemplarSlice
+	// bucketExemplars holds the index in exemplars of the exemplar of each bucket, or -1,
+	// when using the per bucket exemplar reservoir.
+	bucketExemplars []int
+	exemplarsSeen   uint64
 
 	bucketCounts []uint64
 	count        uint64
@@ -46,33 +52,38 @@ type explicitHistogram struct {
 
 	bounds []float64
 
-	maxExemplarCount *int
+	maxExemplarCount  *int
+	exemplarReservoir ExemplarReservoir
 }
 
 type exponentialHistogram struct {
-	attributes pcommon.Map
-	exemplars  pmetric.ExemplarSlice
+	attributes    pcommon.Map
+	exemplars     pmetric.ExemplarSlice
+	exemplarsSeen uint64
 
 	histogram *structure.Histogram[float64]
 
-	maxExemplarCount *int
+	maxExemplarCount  *int
+	exemplarReservoir ExemplarReservoir
 }
 
 type generateStartTimestamp = func(Key) pcommon.Timestamp
 
-func NewExponentialHistogramMetrics(maxSize int32, maxExemplarCount *int) HistogramMetrics {
+func NewExponentialHistogramMetrics(maxSize int32, maxExemplarCount *int, exemplarReservoir ExemplarReservoir) HistogramMetrics {
 	return &exponentialHistogramMetrics{
-		metrics:          make(map[Key]*exponentialHistogram),
-		maxSize:          maxSize,
-		maxExemplarCount: maxExemplarCount,
+		metrics:           make(map[Key]*exponentialHistogram),
+		maxSize:           maxSize,
+		maxExemplarCount:  maxExemplarCount,
+		exemplarReservoir: exemplarReservoir,
 	}
 }
 
-func NewExplicitHistogramMetrics(bounds []float64, maxExemplarCount *int) HistogramMetrics {
+func NewExplicitHistogramMetrics(bounds []float64, maxExemplarCount *int, exemplarReservoir ExemplarReservoir) HistogramMetrics {
 	return &explicitHistogramMetrics{
-		metrics:          make(map[Key]*explicitHistogram),
-		bounds:           bounds,
-		maxExemplarCount: maxExemplarCount,
+		metrics:           make(map[Key]*explicitHistogram),
+		bounds:            bounds,
+		maxExemplarCount:  maxExemplarCount,
+		exemplarReservoir: exemplarReservoir,
 	}
 }
 
@@ -80,11 +91,12 @@ func (m *explicitHistogramMetrics) GetOrCreate(key Key, attributes pcommon.Map)
 	h, ok := m.metrics[key]
 	if !ok {
 		h = &explicitHistogram{
-			attributes:       attributes,
-			exemplars:        pmetric.NewExemplarSlice(),
-			bounds:           m.bounds,
-			bucketCounts:     make([]uint64, len(m.bounds)+1),
-			maxExemplarCount: m.maxExemplarCount,
+			attributes:        attributes,
+			exemplars:         pmetric.NewExemplarSlice(),
+			bounds:            m.bounds,
+			bucketCounts:      make([]uint64, len(m.bounds)+1),
+			maxExemplarCount:  m.maxExemplarCount,
+			exemplarReservoir: m.exemplarReservoir,
 		}
 		m.metrics[key] = h
 	}
@@ -120,6 +132,8 @@ func (m *explicitHistogramMetrics) BuildMetrics(
 func (m *explicitHistogramMetrics) ClearExemplars() {
 	for _, h := range m.metrics {
 		h.exemplars = pmetric.NewExemplarSlice()
+		h.bucketExemplars = nil
+		h.exemplarsSeen = 0
 	}
 }
 
@@ -133,10 +147,11 @@ func (m *exponentialHistogramMetrics) GetOrCreate(key Key, attributes pcommon.Ma
 		histogram.Init(cfg)
 
 		h = &exponentialHistogram{
-			histogram:        histogram,
-			attributes:       attributes,
-			exemplars:        pmetric.NewExemplarSlice(),
-			maxExemplarCount: m.maxExemplarCount,
+			histogram:         histogram,
+			attributes:        attributes,
+			exemplars:         pmetric.NewExemplarSlice(),
+			maxExemplarCount:  m.maxExemplarCount,
+			exemplarReservoir: m.exemplarReservoir,
 		}
 		m.metrics[key] = h
 
@@ -201,6 +216,7 @@ func expoHistToExponentialDataPoint(agg *structure.Histogram[float64], dp pmetri
 func (m *exponentialHistogramMetrics) ClearExemplars() {
 	for _, m := range m.metrics {
 		m.exemplars = pmetric.NewExemplarSlice()
+		m.exemplarsSeen = 0
 	}
 }
 
@@ -214,13 +230,29 @@ func (h *explicitHistogram) Observe(value float64) {
 }
 
 func (h *explicitHistogram) AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64) {
-	if h.maxExemplarCount != nil && h.exemplars.Len() >= *h.maxExemplarCount {
+	if h.exemplarReservoir == ExemplarReservoirPerBucket {
+		h.addBucketExemplar(traceID, spanID, value)
+		return
+	}
+	h.exemplarsSeen++
+	offerExemplar(h.exemplars, h.exemplarReservoir, h.maxExemplarCount, h.exemplarsSeen, traceID, spanID, value)
+}
+
+// addBucketExemplar keeps the exemplar of the last span seen in the bucket of the value.
+func (h *explicitHistogram) addBucketExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64) {
+	if h.bucketExemplars == nil {
+		h.bucketExemplars = make([]int, len(h.bounds)+1)
+		for i := range h.bucketExemplars {
+			h.bucketExemplars[i] = -1
+		}
+	}
+	bucket := sort.SearchFloat64s(h.bounds, value)
+	if i := h.bucketExemplars[bucket]; i >= 0 {
+		setExemplar(h.exemplars.At(i), traceID, spanID, value)
 		return
 	}
-	e := h.exemplars.AppendEmpty()
-	e.SetTraceID(traceID)
-	e.SetSpanID(spanID)
-	e.SetDoubleValue(value)
+	h.bucketExemplars[bucket] = h.exemplars.Len()
+	setExemplar(h.exemplars.AppendEmpty(), traceID, spanID, value)
 }
 
 func (h *exponentialHistogram) Observe(value float64) {
@@ -228,45 +260,45 @@ func (h *exponentialHistogram) Observe(value float64) {
 }
 
 func (h *exponentialHistogram) AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64) {
-	if h.maxExemplarCount != nil && h.exemplars.Len() >= *h.maxExemplarCount {
-		return
-	}
-	e := h.exemplars.AppendEmpty()
-	e.SetTraceID(traceID)
-	e.SetSpanID(spanID)
-	e.SetDoubleValue(value)
+	h.exemplarsSeen++
+	offerExemplar(h.exemplars, h.exemplarReservoir, h.maxExemplarCount, h.exemplarsSeen, traceID, spanID, value)
 }
 
 type Sum struct {
-	attributes       pcommon.Map
-	count            uint64
-	exemplars        pmetric.ExemplarSlice
-	maxExemplarCount *int
+	attributes        pcommon.Map
+	count             uint64
+	exemplars         pmetric.ExemplarSlice
+	exemplarsSeen     uint64
+	maxExemplarCount  *int
+	exemplarReservoir ExemplarReservoir
 }
 
 func (s *Sum) Add(value uint64) {
 	s.count += value
 }
 
-func NewSumMetrics(maxExemplarCount *int) SumMetrics {
+func NewSumMetrics(maxExemplarCount *int, exemplarReservoir ExemplarReservoir) SumMetrics {
 	return SumMetrics{
-		metrics:          make(map[Key]*Sum),
-		maxExemplarCount: maxExemplarCount,
+		metrics:           make(map[Key]*Sum),
+		maxExemplarCount:  maxExemplarCount,
+		exemplarReservoir: exemplarReservoir,
 	}
 }
 
 type SumMetrics struct {
-	metrics          map[Key]*Sum
-	maxExemplarCount *int
+	metrics           map[Key]*Sum
+	maxExemplarCount  *int
+	exemplarReservoir ExemplarReservoir
 }
 
 func (m *SumMetrics) GetOrCreate(key Key, attributes pcommon.Map) *Sum {
 	s, ok := m.metrics[key]
 	if !ok {
 		s = &Sum{
-			attributes:       attributes,
-			exemplars:        pmetric.NewExemplarSlice(),
-			maxExemplarCount: m.maxExemplarCount,
+			attributes:        attributes,
+			exemplars:         pmetric.NewExemplarSlice(),
+			maxExemplarCount:  m.maxExemplarCount,
+			exemplarReservoir: m.exemplarReservoir,
 		}
 		m.metrics[key] = s
 	}
@@ -274,13 +306,8 @@ func (m *SumMetrics) GetOrCreate(key Key, attributes pcommon.Map) *Sum {
 }
 
 func (s *Sum) AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64) {
-	if s.maxExemplarCount != nil && s.exemplars.Len() >= *s.maxExemplarCount {
-		return
-	}
-	e := s.exemplars.AppendEmpty()
-	e.SetTraceID(traceID)
-	e.SetSpanID(spanID)
-	e.SetDoubleValue(value)
+	s.exemplarsSeen++
+	offerExemplar(s.exemplars, s.exemplarReservoir, s.maxExemplarCount, s.exemplarsSeen, traceID, spanID, value)
 }
 
 func (m *SumMetrics) BuildMetrics(
@@ -310,5 +337,6 @@ func (m *SumMetrics) BuildMetrics(
 func (m *SumMetrics) ClearExemplars() {
 	for _, sum := range m.metrics {
 		sum.exemplars = pmetric.NewExemplarSlice()
+		sum.exemplarsSeen = 0
 	}
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/lightstep/go-expohisto/structure"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
@@ -287,3 +288,55 @@ func TestExponentialHistogram_AddExemplar(t *testing.T) {
 		})
 	}
 }
+
+func TestExplicitHistogram_PerBucketExemplars(t *testing.T) {
+	m := NewExplicitHistogramMetrics([]float64{10, 100}, nil, ExemplarReservoirPerBucket)
+	h := m.GetOrCreate("key", pcommon.NewMap())
+	for i, value := range []float64{1, 2, 50, 500, 60} {
+		h.AddExemplar(pcommon.TraceID{byte(i)}, pcommon.SpanID{byte(i)}, value)
+	}
+
+	exemplars := h.(*explicitHistogram).exemplars
+	require.Equal(t, 3, exemplars.Len())
+	// the exemplar of each bucket is the last one seen
+	assert.Equal(t, 2.0, exemplars.At(0).DoubleValue())
+	assert.Equal(t, pcommon.TraceID{1}, exemplars.At(0).TraceID())
+	assert.Equal(t, 60.0, exemplars.At(1).DoubleValue())
+	assert.Equal(t, 500.0, exemplars.At(2).DoubleValue())
+
+	m.ClearExemplars()
+	h.AddExemplar(pcommon.TraceID{9}, pcommon.SpanID{9}, 50)
+	require.Equal(t, 1, h.(*explicitHistogram).exemplars.Len())
+	assert.Equal(t, 50.0, h.(*explicitHistogram).exemplars.At(0).DoubleValue())
+}
+
+func TestRandomExemplarReservoir(t *testing.T) {
+	maxCount := 3
+	m := NewSumMetrics(&maxCount, ExemplarReservoirRandom)
+	s := m.GetOrCreate("key", pcommon.NewMap())
+	offered := map[float64]bool{}
+	for i := 0; i < 100; i++ {
+		s.AddExemplar(pcommon.TraceID{byte(i)}, pcommon.SpanID{byte(i)}, float64(i))
+		offered[float64(i)] = true
+	}
+
+	require.Equal(t, maxCount, s.exemplars.Len())
+	kept := map[float64]bool{}
+	for i := 0; i < s.exemplars.Len(); i++ {
+		value := s.exemplars.At(i).DoubleValue()
+		assert.True(t, offered[value])
+		assert.False(t, kept[value], "an exemplar is kept only once")
+		kept[value] = true
+	}
+
+	m.ClearExemplars()
+	assert.Equal(t, 0, s.exemplars.Len())
+	assert.Zero(t, s.exemplarsSeen)
+}
+
+func TestExemplarReservoir_UnmarshalText(t *testing.T) {
+	var r ExemplarReservoir
+	require.NoError(t, r.UnmarshalText([]byte("Per_Bucket")))
+	assert.Equal(t, ExemplarReservoirPerBucket, r)
+	assert.EqualError(t, r.UnmarshalText([]byte("last_seen")), `unknown exemplar reservoir "last_seen", allowed reservoirs are "first_seen", "per_bucket" and "random"`)
+}
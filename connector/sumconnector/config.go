@@ -3,6 +3,16 @@
 
 package sumconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/sumconnector"
 
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
 // Config for the connector
 type Config struct {
 	Spans      map[string]MetricInfo `mapstructure:"spans"`
@@ -24,3 +34,76 @@ type AttributeConfig struct {
 	Key          string `mapstructure:"key"`
 	DefaultValue any    `mapstructure:"default_value"`
 }
+
+func (c *Config) Validate() error {
+	for name, info := range c.Spans {
+		if name == "" {
+			return fmt.Errorf("spans: metric name missing")
+		}
+		if info.SourceAttribute == "" {
+			return fmt.Errorf("spans: metric %q: source_attribute missing", name)
+		}
+		if _, err := filterottl.NewBoolExprForSpan(info.Conditions, filterottl.StandardSpanFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+			return fmt.Errorf("spans condition: metric %q: %w", name, err)
+		}
+		if err := info.validateAttributes(); err != nil {
+			return fmt.Errorf("spans attributes: metric %q: %w", name, err)
+		}
+	}
+	for name, info := range c.SpanEvents {
+		if name == "" {
+			return fmt.Errorf("spanevents: metric name missing")
+		}
+		if info.SourceAttribute == "" {
+			return fmt.Errorf("spanevents: metric %q: source_attribute missing", name)
+		}
+		if _, err := filterottl.NewBoolExprForSpanEvent(info.Conditions, filterottl.StandardSpanEventFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+			return fmt.Errorf("spanevents condition: metric %q: %w", name, err)
+		}
+		if err := info.validateAttributes(); err != nil {
+			return fmt.Errorf("spanevents attributes: metric %q: %w", name, err)
+		}
+	}
+	// Metrics have no attributes holding a value to sum, the values are summed from their data points.
+	if len(c.Metrics) > 0 {
+		return fmt.Errorf("metrics: not supported, sum the attributes of the data points with datapoints instead")
+	}
+	for name, info := range c.DataPoints {
+		if name == "" {
+			return fmt.Errorf("datapoints: metric name missing")
+		}
+		if info.SourceAttribute == "" {
+			return fmt.Errorf("datapoints: metric %q: source_attribute missing", name)
+		}
+		if _, err := filterottl.NewBoolExprForDataPoint(info.Conditions, filterottl.StandardDataPointFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+			return fmt.Errorf("datapoints condition: metric %q: %w", name, err)
+		}
+		if err := info.validateAttributes(); err != nil {
+			return fmt.Errorf("datapoints attributes: metric %q: %w", name, err)
+		}
+	}
+	for name, info := range c.Logs {
+		if name == "" {
+			return fmt.Errorf("logs: metric name missing")
+		}
+		if info.SourceAttribute == "" {
+			return fmt.Errorf("logs: metric %q: source_attribute missing", name)
+		}
+		if _, err := filterottl.NewBoolExprForLog(info.Conditions, filterottl.StandardLogFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+			return fmt.Errorf("logs condition: metric %q: %w", name, err)
+		}
+		if err := info.validateAttributes(); err != nil {
+			return fmt.Errorf("logs attributes: metric %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (i *MetricInfo) validateAttributes() error {
+	for _, attr := range i.Attributes {
+		if attr.Key == "" {
+			return fmt.Errorf("attribute key missing")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sumconnector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  *Config
+		expect string
+	}{
+		{
+			name: "missing_source_attribute",
+			input: &Config{
+				Logs: map[string]MetricInfo{
+					"log.total": {},
+				},
+			},
+			expect: `logs: metric "log.total": source_attribute missing`,
+		},
+		{
+			name: "invalid_condition",
+			input: &Config{
+				Spans: map[string]MetricInfo{
+					"span.total": {
+						SourceAttribute: "value",
+						Conditions:      []string{"invalid condition"},
+					},
+				},
+			},
+			expect: `spans condition: metric "span.total"`,
+		},
+		{
+			name: "missing_attribute_key",
+			input: &Config{
+				DataPoints: map[string]MetricInfo{
+					"datapoint.total": {
+						SourceAttribute: "value",
+						Attributes:      []AttributeConfig{{DefaultValue: "foo"}},
+					},
+				},
+			},
+			expect: `datapoints attributes: metric "datapoint.total": attribute key missing`,
+		},
+		{
+			name: "metrics",
+			input: &Config{
+				Metrics: map[string]MetricInfo{
+					"metric.total": {
+						SourceAttribute: "value",
+					},
+				},
+			},
+			expect: `metrics: not supported, sum the attributes of the data points with datapoints instead`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.input.Validate()
+			assert.ErrorContains(t, err, tc.expect)
+		})
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sumconnector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestLogsToMetrics(t *testing.T) {
+	cfg := &Config{
+		Logs: map[string]MetricInfo{
+			"checkout.total": {
+				Description:     "Total checkout amount",
+				SourceAttribute: "total.payment",
+				Conditions: []string{
+					`attributes["total.payment"] != "NULL"`,
+				},
+				Attributes: []AttributeConfig{
+					{Key: "payment.processor", DefaultValue: "unspecified_processor"},
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+
+	lr := lrs.AppendEmpty()
+	lr.Attributes().PutInt("total.payment", 10)
+	lr.Attributes().PutStr("payment.processor", "visa")
+
+	lr = lrs.AppendEmpty()
+	lr.Attributes().PutDouble("total.payment", 2.5)
+	lr.Attributes().PutStr("payment.processor", "visa")
+
+	lr = lrs.AppendEmpty()
+	lr.Attributes().PutStr("total.payment", "4")
+
+	// Filtered out by the condition
+	lr = lrs.AppendEmpty()
+	lr.Attributes().PutStr("total.payment", "NULL")
+
+	// No source attribute, nothing to sum
+	lr = lrs.AppendEmpty()
+	lr.Attributes().PutStr("payment.processor", "visa")
+
+	sink := &consumertest.MetricsSink{}
+	conn, err := NewFactory().CreateLogsToMetrics(context.Background(), connectortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, conn.Shutdown(context.Background()))
+	}()
+
+	require.NoError(t, conn.ConsumeLogs(context.Background(), ld))
+	require.Len(t, sink.AllMetrics(), 1)
+
+	md := sink.AllMetrics()[0]
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	rm := md.ResourceMetrics().At(0)
+	serviceName, ok := rm.Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "checkout", serviceName.Str())
+
+	sm := rm.ScopeMetrics().At(0)
+	assert.Equal(t, scopeName, sm.Scope().Name())
+	require.Equal(t, 1, sm.Metrics().Len())
+	m := sm.Metrics().At(0)
+	assert.Equal(t, "checkout.total", m.Name())
+	assert.Equal(t, "Total checkout amount", m.Description())
+	require.Equal(t, pmetric.MetricTypeSum, m.Type())
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, m.Sum().AggregationTemporality())
+	assert.False(t, m.Sum().IsMonotonic())
+
+	sums := sumsByAttribute(m.Sum().DataPoints(), "payment.processor")
+	assert.Equal(t, map[string]float64{
+		"visa":                  12.5,
+		"unspecified_processor": 4,
+	}, sums)
+}
+
+func TestTracesToMetrics(t *testing.T) {
+	cfg := &Config{
+		Spans: map[string]MetricInfo{
+			"span.bytes": {
+				SourceAttribute: "bytes",
+			},
+		},
+		SpanEvents: map[string]MetricInfo{
+			"event.retries": {
+				SourceAttribute: "retries",
+				Conditions: []string{
+					`name == "retry"`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for i := 0; i < 3; i++ {
+		span := spans.AppendEmpty()
+		span.Attributes().PutInt("bytes", 100)
+		event := span.Events().AppendEmpty()
+		event.SetName("retry")
+		event.Attributes().PutInt("retries", 2)
+		event = span.Events().AppendEmpty()
+		event.SetName("other")
+		event.Attributes().PutInt("retries", 5)
+	}
+
+	sink := &consumertest.MetricsSink{}
+	conn, err := NewFactory().CreateTracesToMetrics(context.Background(), connectortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, conn.ConsumeTraces(context.Background(), td))
+	require.Len(t, sink.AllMetrics(), 1)
+
+	got := map[string]float64{}
+	metrics := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		dps := metrics.At(i).Sum().DataPoints()
+		require.Equal(t, 1, dps.Len())
+		got[metrics.At(i).Name()] = dps.At(0).DoubleValue()
+	}
+	assert.Equal(t, map[string]float64{
+		"span.bytes":    300,
+		"event.retries": 6,
+	}, got)
+}
+
+func TestMetricsToMetrics(t *testing.T) {
+	cfg := &Config{
+		DataPoints: map[string]MetricInfo{
+			"datapoint.weight": {
+				SourceAttribute: "weight",
+				Attributes: []AttributeConfig{
+					{Key: "host"},
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	gauge := metrics.AppendEmpty().SetEmptyGauge()
+	for _, host := range []string{"a", "a", "b"} {
+		dp := gauge.DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("host", host)
+		dp.Attributes().PutDouble("weight", 1.5)
+	}
+	// Missing the required "host" attribute
+	gauge.DataPoints().AppendEmpty().Attributes().PutDouble("weight", 1.5)
+
+	sink := &consumertest.MetricsSink{}
+	conn, err := NewFactory().CreateMetricsToMetrics(context.Background(), connectortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, conn.ConsumeMetrics(context.Background(), md))
+	require.Len(t, sink.AllMetrics(), 1)
+
+	m := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "datapoint.weight", m.Name())
+	assert.Equal(t, map[string]float64{"a": 3, "b": 1.5}, sumsByAttribute(m.Sum().DataPoints(), "host"))
+}
+
+func TestNoMatchingTelemetry(t *testing.T) {
+	cfg := &Config{
+		Logs: map[string]MetricInfo{
+			"log.size": {SourceAttribute: "size"},
+		},
+	}
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().PutStr("size", "not a number")
+
+	sink := &consumertest.MetricsSink{}
+	conn, err := NewFactory().CreateLogsToMetrics(context.Background(), connectortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, conn.ConsumeLogs(context.Background(), ld))
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, 0, sink.AllMetrics()[0].ResourceMetrics().Len())
+}
+
+func sumsByAttribute(dps pmetric.NumberDataPointSlice, key string) map[string]float64 {
+	sums := make(map[string]float64, dps.Len())
+	for i := 0; i < dps.Len(); i++ {
+		var attr string
+		if v, ok := dps.At(i).Attributes().Get(key); ok {
+			attr = v.AsString()
+		}
+		sums[attr] = dps.At(i).DoubleValue()
+	}
+	return sums
+}
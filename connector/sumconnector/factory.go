@@ -18,7 +18,6 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
 )
@@ -51,8 +50,9 @@ func createTracesToMetrics(
 	spanMetricDefs := make(map[string]metricDef[ottlspan.TransformContext], len(c.Spans))
 	for name, info := range c.Spans {
 		md := metricDef[ottlspan.TransformContext]{
-			desc:  info.Description,
-			attrs: info.Attributes,
+			desc:       info.Description,
+			attrs:      info.Attributes,
+			sourceAttr: info.SourceAttribute,
 		}
 		if len(info.Conditions) > 0 {
 			// Error checked in Config.Validate()
@@ -65,8 +65,9 @@ func createTracesToMetrics(
 	spanEventMetricDefs := make(map[string]metricDef[ottlspanevent.TransformContext], len(c.SpanEvents))
 	for name, info := range c.SpanEvents {
 		md := metricDef[ottlspanevent.TransformContext]{
-			desc:  info.Description,
-			attrs: info.Attributes,
+			desc:       info.Description,
+			attrs:      info.Attributes,
+			sourceAttr: info.SourceAttribute,
 		}
 		if len(info.Conditions) > 0 {
 			// Error checked in Config.Validate()
@@ -92,24 +93,12 @@ func createMetricsToMetrics(
 ) (connector.Metrics, error) {
 	c := cfg.(*Config)
 
-	metricMetricDefs := make(map[string]metricDef[ottlmetric.TransformContext], len(c.Metrics))
-	for name, info := range c.Metrics {
-		md := metricDef[ottlmetric.TransformContext]{
-			desc: info.Description,
-		}
-		if len(info.Conditions) > 0 {
-			// Error checked in Config.Validate()
-			condition, _ := filterottl.NewBoolExprForMetric(info.Conditions, filterottl.StandardMetricFuncs(), ottl.PropagateError, set.TelemetrySettings)
-			md.condition = condition
-		}
-		metricMetricDefs[name] = md
-	}
-
 	dataPointMetricDefs := make(map[string]metricDef[ottldatapoint.TransformContext], len(c.DataPoints))
 	for name, info := range c.DataPoints {
 		md := metricDef[ottldatapoint.TransformContext]{
-			desc:  info.Description,
-			attrs: info.Attributes,
+			desc:       info.Description,
+			attrs:      info.Attributes,
+			sourceAttr: info.SourceAttribute,
 		}
 		if len(info.Conditions) > 0 {
 			// Error checked in Config.Validate()
@@ -121,7 +110,6 @@ func createMetricsToMetrics(
 
 	return &sum{
 		metricsConsumer:      nextConsumer,
-		metricsMetricDefs:    metricMetricDefs,
 		dataPointsMetricDefs: dataPointMetricDefs,
 	}, nil
 }
@@ -138,8 +126,9 @@ func createLogsToMetrics(
 	metricDefs := make(map[string]metricDef[ottllog.TransformContext], len(c.Logs))
 	for name, info := range c.Logs {
 		md := metricDef[ottllog.TransformContext]{
-			desc:  info.Description,
-			attrs: info.Attributes,
+			desc:       info.Description,
+			attrs:      info.Attributes,
+			sourceAttr: info.SourceAttribute,
 		}
 		if len(info.Conditions) > 0 {
 			// Error checked in Config.Validate()
@@ -156,7 +145,8 @@ func createLogsToMetrics(
 }
 
 type metricDef[K any] struct {
-	condition expr.BoolExpr[K]
-	desc      string
-	attrs     []AttributeConfig
+	condition  expr.BoolExpr[K]
+	desc       string
+	attrs      []AttributeConfig
+	sourceAttr string
 }
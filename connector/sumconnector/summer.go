@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sumconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/sumconnector"
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+var noAttributes = [16]byte{}
+
+func newSummer[K any](metricDefs map[string]metricDef[K]) *summer[K] {
+	return &summer[K]{
+		metricDefs: metricDefs,
+		sums:       make(map[string]map[[16]byte]*attrSummer, len(metricDefs)),
+		timestamp:  time.Now(),
+	}
+}
+
+type summer[K any] struct {
+	metricDefs map[string]metricDef[K]
+	sums       map[string]map[[16]byte]*attrSummer
+	timestamp  time.Time
+}
+
+type attrSummer struct {
+	attrs pcommon.Map
+	sum   float64
+}
+
+func (c *summer[K]) update(ctx context.Context, attrs pcommon.Map, tCtx K) error {
+	var multiError error
+	for name, md := range c.metricDefs {
+		// Telemetry without a numerical source attribute has nothing to contribute
+		sumVal, ok := sourceValue(attrs, md.sourceAttr)
+		if !ok {
+			continue
+		}
+
+		sumAttrs := pcommon.NewMap()
+		for _, attr := range md.attrs {
+			if attrVal, ok := attrs.Get(attr.Key); ok {
+				switch typeAttr := attrVal.Type(); typeAttr {
+				case pcommon.ValueTypeInt:
+					sumAttrs.PutInt(attr.Key, attrVal.Int())
+				case pcommon.ValueTypeDouble:
+					sumAttrs.PutDouble(attr.Key, attrVal.Double())
+				default:
+					sumAttrs.PutStr(attr.Key, attrVal.Str())
+				}
+			} else if attr.DefaultValue != nil {
+				switch v := attr.DefaultValue.(type) {
+				case string:
+					if v != "" {
+						sumAttrs.PutStr(attr.Key, v)
+					}
+				case int:
+					if v != 0 {
+						sumAttrs.PutInt(attr.Key, int64(v))
+					}
+				case float64:
+					if v != 0 {
+						sumAttrs.PutDouble(attr.Key, float64(v))
+					}
+				}
+			}
+		}
+
+		// Missing necessary attributes to be summed
+		if sumAttrs.Len() != len(md.attrs) {
+			continue
+		}
+
+		// No conditions, so match all.
+		if md.condition == nil {
+			multiError = errors.Join(multiError, c.increment(name, sumVal, sumAttrs))
+			continue
+		}
+
+		if match, err := md.condition.Eval(ctx, tCtx); err != nil {
+			multiError = errors.Join(multiError, err)
+		} else if match {
+			multiError = errors.Join(multiError, c.increment(name, sumVal, sumAttrs))
+		}
+	}
+	return multiError
+}
+
+// sourceValue returns the numerical value of the source attribute.
+// Strings are accepted as long as they can be parsed as a float.
+func sourceValue(attrs pcommon.Map, key string) (float64, bool) {
+	val, ok := attrs.Get(key)
+	if !ok {
+		return 0, false
+	}
+	switch val.Type() {
+	case pcommon.ValueTypeInt:
+		return float64(val.Int()), true
+	case pcommon.ValueTypeDouble:
+		return val.Double(), true
+	case pcommon.ValueTypeStr:
+		f, err := strconv.ParseFloat(val.Str(), 64)
+		if err != nil {
+			return 0, false
+		}
+		return f, true
+	}
+	return 0, false
+}
+
+func (c *summer[K]) increment(metricName string, sumVal float64, attrs pcommon.Map) error {
+	if _, ok := c.sums[metricName]; !ok {
+		c.sums[metricName] = make(map[[16]byte]*attrSummer)
+	}
+
+	key := noAttributes
+	if attrs.Len() > 0 {
+		key = pdatautil.MapHash(attrs)
+	}
+
+	if _, ok := c.sums[metricName][key]; !ok {
+		c.sums[metricName][key] = &attrSummer{attrs: attrs}
+	}
+
+	c.sums[metricName][key].sum += sumVal
+	return nil
+}
+
+func (c *summer[K]) appendMetricsTo(metricSlice pmetric.MetricSlice) {
+	for name, md := range c.metricDefs {
+		if len(c.sums[name]) == 0 {
+			continue
+		}
+		sumMetric := metricSlice.AppendEmpty()
+		sumMetric.SetName(name)
+		sumMetric.SetDescription(md.desc)
+		metricSum := sumMetric.SetEmptySum()
+		// Source attribute values may be negative, so the sum is not guaranteed to be monotonic
+		metricSum.SetIsMonotonic(false)
+		metricSum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		for _, dpSum := range c.sums[name] {
+			dp := metricSum.DataPoints().AppendEmpty()
+			dpSum.attrs.CopyTo(dp.Attributes())
+			dp.SetDoubleValue(dpSum.sum)
+			// TODO determine appropriate start time
+			dp.SetTimestamp(pcommon.NewTimestampFromTime(c.timestamp))
+		}
+	}
+}
@@ -4,6 +4,7 @@
 package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
 
 import (
+	"errors"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/servicediscovery/types"
@@ -17,6 +18,8 @@ const (
 	svcRouting
 	metricNameRouting
 	resourceRouting
+	streamIDRouting
+	attrRouting
 )
 
 const (
@@ -24,6 +27,8 @@ const (
 	traceIDRoutingStr    = "traceID"
 	metricNameRoutingStr = "metric"
 	resourceRoutingStr   = "resource"
+	streamIDRoutingStr   = "streamID"
+	attrRoutingStr       = "attributes"
 )
 
 // Config defines configuration for the exporter.
@@ -31,6 +36,21 @@ type Config struct {
 	Protocol   Protocol         `mapstructure:"protocol"`
 	Resolver   ResolverSettings `mapstructure:"resolver"`
 	RoutingKey string           `mapstructure:"routing_key"`
+
+	// RoutingAttributes is the list of attributes whose values are used to route log records
+	// when RoutingKey is "attributes". Each attribute is looked up on the log record first,
+	// then on its scope and finally on its resource.
+	RoutingAttributes []string `mapstructure:"routing_attributes"`
+}
+
+var errNoRoutingAttributes = errors.New("routing_attributes must be set when routing_key is \"attributes\"")
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.RoutingKey == attrRoutingStr && len(cfg.RoutingAttributes) == 0 {
+		return errNoRoutingAttributes
+	}
+	return nil
 }
 
 // Protocol holds the individual protocol-specific settings. Only OTLP is supported at the moment.
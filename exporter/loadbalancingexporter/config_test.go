@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
@@ -25,3 +26,33 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, sub.Unmarshal(cfg))
 	require.NotNil(t, cfg)
 }
+
+func TestConfigValidate(t *testing.T) {
+	for _, tt := range []struct {
+		desc   string
+		config *Config
+		err    error
+	}{
+		{
+			desc:   "default",
+			config: createDefaultConfig().(*Config),
+		},
+		{
+			desc:   "attributes",
+			config: &Config{RoutingKey: attrRoutingStr, RoutingAttributes: []string{"tenant"}},
+		},
+		{
+			desc:   "attributes without routing attributes",
+			config: &Config{RoutingKey: attrRoutingStr},
+			err:    errNoRoutingAttributes,
+		},
+		{
+			desc:   "routing attributes are ignored for other routing keys",
+			config: &Config{RoutingKey: svcRoutingStr},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.err, tt.config.Validate())
+		})
+	}
+}
@@ -6,6 +6,7 @@ package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry
 import (
 	"context"
 	"math/rand"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/multierr"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
@@ -25,7 +27,9 @@ import (
 var _ exporter.Logs = (*logExporterImp)(nil)
 
 type logExporterImp struct {
-	loadBalancer *loadBalancer
+	loadBalancer      *loadBalancer
+	routingKey        routingKey
+	routingAttributes []string
 
 	started    bool
 	shutdownWg sync.WaitGroup
@@ -49,10 +53,24 @@ func newLogsExporter(params exporter.Settings, cfg component.Config) (*logExport
 		return nil, err
 	}
 
-	return &logExporterImp{
+	logExporter := logExporterImp{
 		loadBalancer: lb,
+		routingKey:   traceIDRouting,
 		telemetry:    telemetry,
-	}, nil
+	}
+
+	switch cfg.(*Config).RoutingKey {
+	case traceIDRoutingStr, "":
+	case attrRoutingStr:
+		logExporter.routingKey = attrRouting
+		logExporter.routingAttributes = cfg.(*Config).RoutingAttributes
+	default:
+		// the configuration may be shared with trace or metric pipelines, whose routing keys
+		// are not supported for logs
+		params.Logger.Warn("routing_key is not supported for logs, routing by traceID instead",
+			zap.String("routing_key", cfg.(*Config).RoutingKey))
+	}
+	return &logExporter, nil
 }
 
 func (e *logExporterImp) Capabilities() consumer.Capabilities {
@@ -75,6 +93,10 @@ func (e *logExporterImp) Shutdown(ctx context.Context) error {
 }
 
 func (e *logExporterImp) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if e.routingKey == attrRouting {
+		return e.consumeLogsByAttributes(ctx, ld)
+	}
+
 	var errs error
 	batches := batchpersignal.SplitLogs(ld)
 	for _, batch := range batches {
@@ -84,6 +106,44 @@ func (e *logExporterImp) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 	return errs
 }
 
+// consumeLogsByAttributes routes every log record based on the values of the routing attributes,
+// sending a single merged batch to each of the selected backends.
+func (e *logExporterImp) consumeLogsByAttributes(ctx context.Context, ld plog.Logs) error {
+	logsByExporter := map[*wrappedExporter]plog.Logs{}
+	for routingID, batch := range splitLogsByAttributes(ld, e.routingAttributes) {
+		exp, _, err := e.loadBalancer.exporterAndEndpoint([]byte(routingID))
+		if err != nil {
+			return err
+		}
+
+		expLogs, ok := logsByExporter[exp]
+		if !ok {
+			exp.consumeWG.Add(1)
+			logsByExporter[exp] = batch
+			continue
+		}
+		batch.ResourceLogs().MoveAndAppendTo(expLogs.ResourceLogs())
+	}
+
+	var errs error
+	for exp, logs := range logsByExporter {
+		start := time.Now()
+		err := exp.ConsumeLogs(ctx, logs)
+		duration := time.Since(start)
+
+		exp.consumeWG.Done()
+		errs = multierr.Append(errs, err)
+		e.telemetry.LoadbalancerBackendLatency.Record(ctx, duration.Milliseconds(), metric.WithAttributeSet(exp.endpointAttr))
+		if err == nil {
+			e.telemetry.LoadbalancerBackendOutcome.Add(ctx, 1, metric.WithAttributeSet(exp.successAttr))
+		} else {
+			e.telemetry.LoadbalancerBackendOutcome.Add(ctx, 1, metric.WithAttributeSet(exp.failureAttr))
+		}
+	}
+
+	return errs
+}
+
 func (e *logExporterImp) consumeLog(ctx context.Context, ld plog.Logs) error {
 	traceID := traceIDFromLogs(ld)
 	balancingKey := traceID
@@ -134,6 +194,64 @@ func traceIDFromLogs(ld plog.Logs) pcommon.TraceID {
 	return logs.At(0).TraceID()
 }
 
+// splitLogsByAttributes groups the log records by the values of the given attributes.
+// Each attribute is looked up on the log record, then on its scope and finally on its resource.
+// Records missing all the attributes share the same routing key.
+func splitLogsByAttributes(ld plog.Logs, attrs []string) map[string]plog.Logs {
+	results := map[string]plog.Logs{}
+
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			// scope logs of the batches built from this scope, by routing key
+			scopes := map[string]plog.ScopeLogs{}
+
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				lr := sl.LogRecords().At(k)
+				key := routingKeyFromAttributes(attrs, lr.Attributes(), sl.Scope().Attributes(), rl.Resource().Attributes())
+
+				slClone, ok := scopes[key]
+				if !ok {
+					batch, ok := results[key]
+					if !ok {
+						batch = plog.NewLogs()
+						results[key] = batch
+					}
+					rlClone := batch.ResourceLogs().AppendEmpty()
+					rl.Resource().CopyTo(rlClone.Resource())
+					rlClone.SetSchemaUrl(rl.SchemaUrl())
+
+					slClone = rlClone.ScopeLogs().AppendEmpty()
+					sl.Scope().CopyTo(slClone.Scope())
+					slClone.SetSchemaUrl(sl.SchemaUrl())
+					scopes[key] = slClone
+				}
+				lr.CopyTo(slClone.LogRecords().AppendEmpty())
+			}
+		}
+	}
+
+	return results
+}
+
+func routingKeyFromAttributes(attrs []string, maps ...pcommon.Map) string {
+	var sb strings.Builder
+	for _, attr := range attrs {
+		for _, m := range maps {
+			if v, ok := m.Get(attr); ok {
+				sb.WriteString(attr)
+				sb.WriteByte('=')
+				sb.WriteString(v.AsString())
+				break
+			}
+		}
+		sb.WriteByte(';')
+	}
+	return sb.String()
+}
+
 func random() pcommon.TraceID {
 	v1 := uint8(rand.Intn(256))
 	v2 := uint8(rand.Intn(256))
@@ -24,6 +24,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestNewLogsExporter(t *testing.T) {
@@ -42,6 +43,15 @@ func TestNewLogsExporter(t *testing.T) {
 			&Config{},
 			errNoResolver,
 		},
+		{
+			"attributes",
+			&Config{
+				Resolver:          simpleConfig().Resolver,
+				RoutingKey:        attrRoutingStr,
+				RoutingAttributes: []string{"tenant"},
+			},
+			nil,
+		},
 	} {
 		t.Run(tt.desc, func(t *testing.T) {
 			// test
@@ -53,6 +63,98 @@ func TestNewLogsExporter(t *testing.T) {
 	}
 }
 
+func TestNewLogsExporterUnsupportedRoutingKey(t *testing.T) {
+	for _, key := range []string{svcRoutingStr, resourceRoutingStr, metricNameRoutingStr, streamIDRoutingStr} {
+		t.Run(key, func(t *testing.T) {
+			core, logs := observer.New(zap.WarnLevel)
+			settings := exportertest.NewNopSettings()
+			settings.Logger = zap.New(core)
+			cfg := simpleConfig()
+			cfg.RoutingKey = key
+
+			p, err := newLogsExporter(settings, cfg)
+			require.NoError(t, err)
+			assert.Equal(t, traceIDRouting, p.routingKey)
+			require.Equal(t, 1, logs.FilterMessage("routing_key is not supported for logs, routing by traceID instead").Len())
+		})
+	}
+}
+
+func TestSplitLogsByAttributes(t *testing.T) {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("tenant", "acme")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("scope")
+
+	// the log record attribute takes precedence over the resource attribute
+	sl.LogRecords().AppendEmpty().Attributes().PutStr("tenant", "other")
+	sl.LogRecords().AppendEmpty().Body().SetStr("first acme log")
+	sl.LogRecords().AppendEmpty().Body().SetStr("second acme log")
+
+	batches := splitLogsByAttributes(ld, []string{"tenant"})
+	require.Len(t, batches, 2)
+
+	acme := batches[routingKeyFromAttributes([]string{"tenant"}, rl.Resource().Attributes())]
+	require.Equal(t, 2, acme.LogRecordCount())
+	require.Equal(t, 1, acme.ResourceLogs().Len())
+	assert.Equal(t, "scope", acme.ResourceLogs().At(0).ScopeLogs().At(0).Scope().Name())
+	tenant, ok := acme.ResourceLogs().At(0).Resource().Attributes().Get("tenant")
+	require.True(t, ok)
+	assert.Equal(t, "acme", tenant.Str())
+
+	other := batches[routingKeyFromAttributes([]string{"tenant"}, sl.LogRecords().At(0).Attributes())]
+	require.Equal(t, 1, other.LogRecordCount())
+}
+
+func TestConsumeLogsByAttributes(t *testing.T) {
+	ts, tb := getTelemetryAssets(t)
+	componentFactory := func(_ context.Context, _ string) (component.Component, error) {
+		return newNopMockLogsExporter(), nil
+	}
+	cfg := &Config{
+		Resolver:          serviceBasedRoutingConfig().Resolver,
+		RoutingKey:        attrRoutingStr,
+		RoutingAttributes: []string{"tenant"},
+	}
+
+	lb, err := newLoadBalancer(ts.Logger, cfg, componentFactory, tb)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newLogsExporter(ts, cfg)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// pre-load the exporters here, so that we don't use the actual OTLP exporter
+	lb.addMissingExporters(context.Background(), []string{"endpoint-1", "endpoint-2"})
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(_ context.Context) ([]string, error) {
+			return []string{"endpoint-1", "endpoint-2"}, nil
+		},
+	}
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, tenant := range []string{"a", "b", "c", "d"} {
+		lrs.AppendEmpty().Attributes().PutStr("tenant", tenant)
+	}
+
+	// test
+	res := p.ConsumeLogs(context.Background(), ld)
+
+	// verify
+	assert.Nil(t, res)
+}
+
 func TestLogExporterStart(t *testing.T) {
 	ts, tb := getTelemetryAssets(t)
 	for _, tt := range []struct {
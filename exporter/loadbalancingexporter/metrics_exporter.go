@@ -65,6 +65,8 @@ func newMetricsExporter(params exporter.Settings, cfg component.Config) (*metric
 		metricExporter.routingKey = resourceRouting
 	case metricNameRoutingStr:
 		metricExporter.routingKey = metricNameRouting
+	case streamIDRoutingStr:
+		metricExporter.routingKey = streamIDRouting
 	default:
 		return nil, fmt.Errorf("unsupported routing_key: %q", cfg.(*Config).RoutingKey)
 	}
@@ -101,6 +103,8 @@ func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pmetric.Metri
 		batches = splitMetricsByResourceID(md)
 	case metricNameRouting:
 		batches = splitMetricsByMetricName(md)
+	case streamIDRouting:
+		batches = splitMetricsByStreamID(md)
 	}
 
 	// Now assign each batch to an exporter, and merge as we go
@@ -221,6 +225,83 @@ func splitMetricsByMetricName(md pmetric.Metrics) map[string]pmetric.Metrics {
 	return results
 }
 
+// splitMetricsByStreamID splits the metrics so that all data points of a stream, identified by their
+// resource, scope, metric and data point attributes, end up in the same batch.
+func splitMetricsByStreamID(md pmetric.Metrics) map[string]pmetric.Metrics {
+	results := map[string]pmetric.Metrics{}
+
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		resourceID := identity.OfResource(rm.Resource())
+
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			scopeID := identity.OfScope(resourceID, sm.Scope())
+
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				m := sm.Metrics().At(k)
+				metricID := identity.OfMetric(scopeID, m)
+
+				//exhaustive:enforce
+				switch m.Type() {
+				case pmetric.MetricTypeGauge:
+					dps := m.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						newMD, mClone := cloneMetricWithoutType(rm, sm, m)
+						dps.At(l).CopyTo(mClone.SetEmptyGauge().DataPoints().AppendEmpty())
+						mergeByKey(results, identity.OfStream(metricID, dps.At(l)).String(), newMD)
+					}
+				case pmetric.MetricTypeSum:
+					dps := m.Sum().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						newMD, mClone := cloneMetricWithoutType(rm, sm, m)
+						sum := mClone.SetEmptySum()
+						sum.SetIsMonotonic(m.Sum().IsMonotonic())
+						sum.SetAggregationTemporality(m.Sum().AggregationTemporality())
+						dps.At(l).CopyTo(sum.DataPoints().AppendEmpty())
+						mergeByKey(results, identity.OfStream(metricID, dps.At(l)).String(), newMD)
+					}
+				case pmetric.MetricTypeHistogram:
+					dps := m.Histogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						newMD, mClone := cloneMetricWithoutType(rm, sm, m)
+						histogram := mClone.SetEmptyHistogram()
+						histogram.SetAggregationTemporality(m.Histogram().AggregationTemporality())
+						dps.At(l).CopyTo(histogram.DataPoints().AppendEmpty())
+						mergeByKey(results, identity.OfStream(metricID, dps.At(l)).String(), newMD)
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					dps := m.ExponentialHistogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						newMD, mClone := cloneMetricWithoutType(rm, sm, m)
+						expHistogram := mClone.SetEmptyExponentialHistogram()
+						expHistogram.SetAggregationTemporality(m.ExponentialHistogram().AggregationTemporality())
+						dps.At(l).CopyTo(expHistogram.DataPoints().AppendEmpty())
+						mergeByKey(results, identity.OfStream(metricID, dps.At(l)).String(), newMD)
+					}
+				case pmetric.MetricTypeSummary:
+					dps := m.Summary().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						newMD, mClone := cloneMetricWithoutType(rm, sm, m)
+						dps.At(l).CopyTo(mClone.SetEmptySummary().DataPoints().AppendEmpty())
+						mergeByKey(results, identity.OfStream(metricID, dps.At(l)).String(), newMD)
+					}
+				}
+			}
+		}
+	}
+
+	return results
+}
+
+func mergeByKey(results map[string]pmetric.Metrics, key string, md pmetric.Metrics) {
+	if existing, ok := results[key]; ok {
+		metrics.Merge(existing, md)
+	} else {
+		results[key] = md
+	}
+}
+
 func cloneMetricWithoutType(rm pmetric.ResourceMetrics, sm pmetric.ScopeMetrics, m pmetric.Metric) (md pmetric.Metrics, mClone pmetric.Metric) {
 	md = pmetric.NewMetrics()
 
@@ -85,6 +85,11 @@ func TestNewMetricsExporter(t *testing.T) {
 			resourceBasedRoutingConfig(),
 			nil,
 		},
+		{
+			"streamID",
+			streamIDBasedRoutingConfig(),
+			nil,
+		},
 		{
 			"traceID",
 			&Config{
@@ -303,6 +308,67 @@ func TestSplitMetrics(t *testing.T) {
 	}
 }
 
+func TestSplitMetricsByStreamID(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr(conventions.AttributeServiceName, serviceName1)
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope")
+
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("requests")
+	sum.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	sum.Sum().SetIsMonotonic(true)
+	for _, route := range []string{"/a", "/b", "/a"} {
+		dp := sum.Sum().DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("route", route)
+		dp.SetIntValue(1)
+	}
+
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("temperature")
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(21.5)
+
+	histogram := sm.Metrics().AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	histogram.Histogram().DataPoints().AppendEmpty().SetCount(3)
+
+	batches := splitMetricsByStreamID(md)
+	// one batch per stream: two routes, the gauge and the histogram
+	require.Len(t, batches, 4)
+
+	var dataPoints int
+	for _, batch := range batches {
+		require.Equal(t, 1, batch.ResourceMetrics().Len())
+		batchRM := batch.ResourceMetrics().At(0)
+		svc, ok := batchRM.Resource().Attributes().Get(conventions.AttributeServiceName)
+		require.True(t, ok)
+		assert.Equal(t, serviceName1, svc.Str())
+		require.Equal(t, 1, batchRM.ScopeMetrics().Len())
+		assert.Equal(t, "scope", batchRM.ScopeMetrics().At(0).Scope().Name())
+
+		batchMetrics := batchRM.ScopeMetrics().At(0).Metrics()
+		require.Equal(t, 1, batchMetrics.Len())
+		m := batchMetrics.At(0)
+		switch m.Name() {
+		case "requests":
+			assert.True(t, m.Sum().IsMonotonic())
+			assert.Equal(t, pmetric.AggregationTemporalityDelta, m.Sum().AggregationTemporality())
+			route, _ := m.Sum().DataPoints().At(0).Attributes().Get("route")
+			if route.Str() == "/a" {
+				assert.Equal(t, 2, m.Sum().DataPoints().Len())
+			} else {
+				assert.Equal(t, 1, m.Sum().DataPoints().Len())
+			}
+		case "latency":
+			assert.Equal(t, pmetric.AggregationTemporalityCumulative, m.Histogram().AggregationTemporality())
+		}
+		dataPoints += batch.DataPointCount()
+	}
+	assert.Equal(t, md.DataPointCount(), dataPoints)
+}
+
 func TestConsumeMetrics_SingleEndpoint(t *testing.T) {
 	ts, tb := getTelemetryAssets(t)
 	t.Parallel()
@@ -968,6 +1034,15 @@ func resourceBasedRoutingConfig() *Config {
 	}
 }
 
+func streamIDBasedRoutingConfig() *Config {
+	return &Config{
+		Resolver: ResolverSettings{
+			Static: &StaticResolver{Hostnames: []string{"endpoint-1", "endpoint-2"}},
+		},
+		RoutingKey: streamIDRoutingStr,
+	}
+}
+
 func metricNameBasedRoutingConfig() *Config {
 	return &Config{
 		Resolver: ResolverSettings{
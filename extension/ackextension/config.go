@@ -8,7 +8,8 @@ import (
 
 // Config defines configuration for ack extension
 type Config struct {
-	// StorageID defines the storage type of the extension. In-memory type is set by default (if not provided). Future consideration is disk type.
+	// StorageID defines the storage extension used to persist acks. In-memory type is set by default (if not provided).
+	// When set, partitions and their pending acks are written to a client of that storage extension and recovered on restart.
 	StorageID *component.ID `mapstructure:"storage"`
 	// MaxNumPartition Specifies the maximum number of partitions that clients can acquire for this extension instance.
 	// Implementation defines how limit exceeding should be handled.
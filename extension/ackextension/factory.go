@@ -37,10 +37,10 @@ func createDefaultConfig() component.Config {
 	}
 }
 
-func createExtension(_ context.Context, _ extension.Settings, cfg component.Config) (extension.Extension, error) {
+func createExtension(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
 	if cfg.(*Config).StorageID == nil {
 		return newInMemoryAckExtension(cfg.(*Config)), nil
 	}
 
-	return nil, nil
+	return newStorageAckExtension(cfg.(*Config), set)
 }
@@ -0,0 +1,507 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ackextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+const (
+	partitionsKey           = "partitions"
+	partitionKeyPrefix      = "partition_"
+	partitionDeltaKeyPrefix = "partition-delta_"
+
+	// flushInterval is how often the changes made since the last write are persisted.
+	flushInterval = time.Second
+	// minCompactionOps is the number of changes a partition accumulates in deltas before it is
+	// rewritten as a single snapshot, unless it holds more pending acks than that.
+	minCompactionOps = 1000
+)
+
+// storageAckExtension is the storage-backed implementation of the AckExtension.
+// Partitions and their pending acks are kept in memory. The changes are written to a storage client
+// every flushInterval and at Shutdown, so they can be recovered when the collector restarts.
+// Each partition is stored as a snapshot followed by the deltas written since, which are
+// compacted into a new snapshot once they outgrow it.
+// Eviction follows the same rules as inMemoryAckExtension.
+type storageAckExtension struct {
+	id                            component.ID
+	storageID                     component.ID
+	logger                        *zap.Logger
+	maxNumPartition               uint64
+	maxNumPendingAcksPerPartition uint64
+
+	// cancel stops the periodic flush, which closes doneCh once it returned.
+	cancel context.CancelFunc
+	doneCh chan struct{}
+
+	// mu guards every field below. It is not held while writing to storage.
+	mu           sync.Mutex
+	client       storage.Client
+	partitionMap *lru.Cache[string, *storedPartition]
+	// dirty holds the IDs of the partitions with pending changes.
+	dirty map[string]struct{}
+	// evicted holds the partitions to delete from storage.
+	evicted      []evictedPartition
+	indexChanged bool
+}
+
+// storedPartition is an ackPartition along with what is needed to persist its changes.
+type storedPartition struct {
+	*ackPartition
+	// generation identifies the snapshot in storage and the deltas written after it, zero if there is none.
+	generation uint64
+	// deltas is the number of deltas written after the snapshot.
+	deltas int
+	// deltaOps is the number of changes held by these deltas.
+	deltaOps int
+	// pending holds the changes not written to storage yet.
+	pending []ackOp
+}
+
+type evictedPartition struct {
+	id     string
+	deltas int
+}
+
+// partitionState is the persisted snapshot of an ackPartition.
+type partitionState struct {
+	Generation uint64     `json:"generation"`
+	LastAckID  uint64     `json:"last_ack_id"`
+	Acks       []ackState `json:"acks"`
+}
+
+type ackState struct {
+	ID    uint64 `json:"id"`
+	Acked bool   `json:"acked"`
+}
+
+// partitionDelta holds changes made to a partition after its snapshot of the same generation was written.
+type partitionDelta struct {
+	Generation uint64  `json:"generation"`
+	Ops        []ackOp `json:"ops"`
+}
+
+type ackOpType string
+
+const (
+	ackOpProcess ackOpType = "process"
+	ackOpAck     ackOpType = "ack"
+	ackOpQuery   ackOpType = "query"
+)
+
+// ackOp is a call made to a partition. Replaying the calls in order restores the ack IDs,
+// the acks and the order in which they are evicted.
+type ackOp struct {
+	Type   ackOpType `json:"type"`
+	AckIDs []uint64  `json:"ack_ids,omitempty"`
+}
+
+func newStorageAckExtension(conf *Config, set extension.Settings) (*storageAckExtension, error) {
+	s := &storageAckExtension{
+		id:                            set.ID,
+		storageID:                     *conf.StorageID,
+		logger:                        set.Logger,
+		maxNumPartition:               conf.MaxNumPartition,
+		maxNumPendingAcksPerPartition: conf.MaxNumPendingAcksPerPartition,
+		dirty:                         map[string]struct{}{},
+	}
+	cache, err := lru.NewWithEvict[string, *storedPartition](int(conf.MaxNumPartition), func(partitionID string, partition *storedPartition) {
+		s.evicted = append(s.evicted, evictedPartition{id: partitionID, deltas: partition.deltas})
+		s.indexChanged = true
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.partitionMap = cache
+	return s, nil
+}
+
+// Start acquires a client from the configured storage extension, recovers the persisted partitions
+// and starts writing the changes to storage periodically.
+func (s *storageAckExtension) Start(ctx context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[s.storageID]
+	if !ok {
+		return fmt.Errorf("storage extension '%s' not found", s.storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return fmt.Errorf("non-storage extension '%s' found", s.storageID)
+	}
+
+	client, err := storageExt.GetClient(ctx, component.KindExtension, s.id, "")
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.client = client
+	err = s.load(ctx)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	// The configured limits may have been lowered since the state was persisted.
+	// Keep storage consistent with what was actually loaded.
+	if err = s.flush(ctx); err != nil {
+		return err
+	}
+
+	flushCtx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.doneCh = make(chan struct{})
+	go s.flushPeriodically(flushCtx)
+	return nil
+}
+
+// Shutdown writes the remaining changes to storage and closes the storage client.
+func (s *storageAckExtension) Shutdown(ctx context.Context) error {
+	var err error
+	if s.cancel != nil {
+		s.cancel()
+		<-s.doneCh
+		s.cancel = nil
+		err = s.flush(ctx)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.client == nil {
+		return err
+	}
+	err = errors.Join(err, s.client.Close(ctx))
+	s.client = nil
+	return err
+}
+
+// ProcessEvent marks the beginning of processing an event. It generates an ack ID for the associated partition ID.
+func (s *storageAckExtension) ProcessEvent(partitionID string) (ackID uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	partition, ok := s.partitionMap.Get(partitionID)
+	if !ok {
+		partition = &storedPartition{ackPartition: newAckPartition(s.maxNumPendingAcksPerPartition)}
+		s.partitionMap.Add(partitionID, partition)
+		s.indexChanged = true
+	}
+
+	ackID = partition.nextAck()
+	s.record(partitionID, partition, ackOp{Type: ackOpProcess})
+	return ackID
+}
+
+// Ack acknowledges an event has been processed.
+func (s *storageAckExtension) Ack(partitionID string, ackID uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if partition, ok := s.partitionMap.Get(partitionID); ok {
+		partition.ack(ackID)
+		s.record(partitionID, partition, ackOp{Type: ackOpAck, AckIDs: []uint64{ackID}})
+	}
+}
+
+// QueryAcks checks the statuses of given ackIDs for a partition.
+// ackIDs that are not generated from ProcessEvent or have been removed as a result of previous calls to QueryAcks will return false.
+func (s *storageAckExtension) QueryAcks(partitionID string, ackIDs []uint64) map[uint64]bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if partition, ok := s.partitionMap.Get(partitionID); ok {
+		result := partition.computeAcks(ackIDs)
+		s.record(partitionID, partition, ackOp{Type: ackOpQuery, AckIDs: append([]uint64(nil), ackIDs...)})
+		return result
+	}
+
+	result := make(map[uint64]bool, len(ackIDs))
+	for _, ackID := range ackIDs {
+		result[ackID] = false
+	}
+
+	return result
+}
+
+// record queues a change of a partition until the next flush. Nothing is recorded once the
+// extension is shut down, as it would never be written.
+func (s *storageAckExtension) record(partitionID string, partition *storedPartition, op ackOp) {
+	if s.client == nil {
+		return
+	}
+	partition.pending = append(partition.pending, op)
+	s.dirty[partitionID] = struct{}{}
+}
+
+func (s *storageAckExtension) flushPeriodically(ctx context.Context) {
+	defer close(s.doneCh)
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// The AckExtension interface has no way to report errors, so failures are logged.
+			// The changes are kept and written with the next flush, or at Shutdown if it interrupted this one.
+			if err := s.flush(ctx); err != nil && ctx.Err() == nil {
+				s.logger.Warn("Failed to persist acks", zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// flush writes the changes made since the last flush in a single batch.
+// Only one flush runs at a time: Start and Shutdown flush while the periodic flush is not running.
+func (s *storageAckExtension) flush(ctx context.Context) error {
+	s.mu.Lock()
+	client := s.client
+	ops, flushed, evicted, err := s.collectChanges()
+	s.mu.Unlock()
+	if err != nil || len(ops) == 0 {
+		return err
+	}
+
+	if err = client.Batch(ctx, ops...); err != nil {
+		s.mu.Lock()
+		s.restoreChanges(flushed, evicted)
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// collectChanges returns the storage operations writing the pending changes, along with the
+// partitions they write and delete, and resets the pending changes.
+func (s *storageAckExtension) collectChanges() (ops []storage.Operation, flushed []string, evicted []evictedPartition, err error) {
+	evicted = s.evicted
+	s.evicted = nil
+	for _, partition := range evicted {
+		ops = append(ops, deleteDeltaOps(partition.id, partition.deltas)...)
+		ops = append(ops, storage.DeleteOperation(partitionKey(partition.id)))
+	}
+
+	if s.indexChanged {
+		s.indexChanged = false
+		index, marshalErr := json.Marshal(s.partitionMap.Keys())
+		if marshalErr != nil {
+			s.restoreChanges(nil, evicted)
+			return nil, nil, nil, marshalErr
+		}
+		ops = append(ops, storage.SetOperation(partitionsKey, index))
+	}
+
+	for partitionID := range s.dirty {
+		delete(s.dirty, partitionID)
+		// Partitions evicted since their changes were recorded are deleted instead.
+		partition, ok := s.partitionMap.Peek(partitionID)
+		if !ok {
+			continue
+		}
+		partitionOps, marshalErr := partition.changes(partitionID)
+		if marshalErr != nil {
+			s.logger.Warn("Failed to marshal acks", zap.String("partition", partitionID), zap.Error(marshalErr))
+			continue
+		}
+		ops = append(ops, partitionOps...)
+		flushed = append(flushed, partitionID)
+	}
+	return ops, flushed, evicted, nil
+}
+
+// restoreChanges queues again the changes of a failed flush. The partitions that were written
+// get a new snapshot, as the deltas they were given have been lost.
+func (s *storageAckExtension) restoreChanges(flushed []string, evicted []evictedPartition) {
+	s.evicted = append(evicted, s.evicted...)
+	s.indexChanged = true
+	for _, partitionID := range flushed {
+		if partition, ok := s.partitionMap.Peek(partitionID); ok {
+			partition.generation = 0
+			s.dirty[partitionID] = struct{}{}
+		}
+	}
+}
+
+// changes returns the storage operations writing the pending changes of the partition.
+// They are appended as a delta unless the partition has no snapshot yet or its deltas
+// outgrew it, in which case a new snapshot replaces them.
+func (sp *storedPartition) changes(partitionID string) ([]storage.Operation, error) {
+	deltaOps := sp.deltaOps + len(sp.pending)
+	if sp.generation != 0 && deltaOps < max(sp.ackMap.Len(), minCompactionOps) {
+		value, err := json.Marshal(partitionDelta{Generation: sp.generation, Ops: sp.pending})
+		if err != nil {
+			return nil, err
+		}
+		sp.deltas++
+		sp.deltaOps = deltaOps
+		sp.pending = nil
+		return []storage.Operation{storage.SetOperation(partitionDeltaKey(partitionID, sp.deltas), value)}, nil
+	}
+
+	generation := newGeneration()
+	value, err := json.Marshal(sp.state(generation))
+	if err != nil {
+		return nil, err
+	}
+	ops := append(deleteDeltaOps(partitionID, sp.deltas), storage.SetOperation(partitionKey(partitionID), value))
+	sp.generation = generation
+	sp.deltas = 0
+	sp.deltaOps = 0
+	sp.pending = nil
+	return ops, nil
+}
+
+// load restores the partitions found in storage, from the least to the most recently used.
+func (s *storageAckExtension) load(ctx context.Context) error {
+	index, err := s.client.Get(ctx, partitionsKey)
+	if err != nil {
+		return fmt.Errorf("failed to read partitions from storage: %w", err)
+	}
+	if index == nil {
+		return nil
+	}
+
+	var partitionIDs []string
+	if err = json.Unmarshal(index, &partitionIDs); err != nil {
+		return fmt.Errorf("failed to unmarshal partitions: %w", err)
+	}
+
+	ops := make([]storage.Operation, len(partitionIDs))
+	for i, partitionID := range partitionIDs {
+		ops[i] = storage.GetOperation(partitionKey(partitionID))
+	}
+	if err = s.client.Batch(ctx, ops...); err != nil {
+		return fmt.Errorf("failed to read partitions from storage: %w", err)
+	}
+
+	for i, partitionID := range partitionIDs {
+		if ops[i].Value == nil {
+			continue
+		}
+		var state partitionState
+		if err = json.Unmarshal(ops[i].Value, &state); err != nil {
+			s.logger.Warn("Dropping unreadable partition", zap.String("partition", partitionID), zap.Error(err))
+			continue
+		}
+		partition := &storedPartition{
+			ackPartition: newAckPartitionFromState(s.maxNumPendingAcksPerPartition, state),
+			generation:   state.Generation,
+		}
+		if err = s.loadDeltas(ctx, partitionID, partition); err != nil {
+			return err
+		}
+		s.partitionMap.Add(partitionID, partition)
+	}
+
+	if len(partitionIDs) != s.partitionMap.Len() {
+		s.indexChanged = true
+	}
+	return nil
+}
+
+// loadDeltas replays the deltas written after the snapshot of the partition. Deltas left over
+// from a previous snapshot have another generation and end the replay.
+func (s *storageAckExtension) loadDeltas(ctx context.Context, partitionID string, partition *storedPartition) error {
+	for seq := 1; ; seq++ {
+		value, err := s.client.Get(ctx, partitionDeltaKey(partitionID, seq))
+		if err != nil {
+			return fmt.Errorf("failed to read partition %q from storage: %w", partitionID, err)
+		}
+		if value == nil {
+			return nil
+		}
+		var delta partitionDelta
+		if err = json.Unmarshal(value, &delta); err != nil {
+			s.logger.Warn("Dropping unreadable partition changes", zap.String("partition", partitionID), zap.Error(err))
+			return nil
+		}
+		if delta.Generation != partition.generation {
+			return nil
+		}
+		for _, op := range delta.Ops {
+			partition.replay(op)
+		}
+		partition.deltas = seq
+		partition.deltaOps += len(delta.Ops)
+	}
+}
+
+func partitionKey(partitionID string) string {
+	return partitionKeyPrefix + partitionID
+}
+
+func partitionDeltaKey(partitionID string, seq int) string {
+	return partitionDeltaKeyPrefix + strconv.Itoa(seq) + "_" + partitionID
+}
+
+func deleteDeltaOps(partitionID string, deltas int) []storage.Operation {
+	ops := make([]storage.Operation, 0, deltas+1)
+	for seq := 1; seq <= deltas; seq++ {
+		ops = append(ops, storage.DeleteOperation(partitionDeltaKey(partitionID, seq)))
+	}
+	return ops
+}
+
+// newGeneration returns a random non-zero generation, so deltas left over from a snapshot
+// written before a restart are never mistaken for the current ones.
+func newGeneration() uint64 {
+	for {
+		if generation := rand.Uint64(); generation != 0 {
+			return generation
+		}
+	}
+}
+
+// state returns the persisted snapshot of the partition, from the oldest to the newest ack.
+func (as *ackPartition) state(generation uint64) partitionState {
+	keys := as.ackMap.Keys()
+	state := partitionState{
+		Generation: generation,
+		LastAckID:  as.id.Load(),
+		Acks:       make([]ackState, 0, len(keys)),
+	}
+	for _, key := range keys {
+		if acked, ok := as.ackMap.Peek(key); ok {
+			state.Acks = append(state.Acks, ackState{ID: key, Acked: acked})
+		}
+	}
+	return state
+}
+
+// replay applies a change recorded by the storageAckExtension.
+func (as *ackPartition) replay(op ackOp) {
+	switch op.Type {
+	case ackOpProcess:
+		as.nextAck()
+	case ackOpAck:
+		for _, ackID := range op.AckIDs {
+			as.ack(ackID)
+		}
+	case ackOpQuery:
+		as.computeAcks(op.AckIDs)
+	}
+}
+
+func newAckPartitionFromState(maxPendingAcks uint64, state partitionState) *ackPartition {
+	partition := newAckPartition(maxPendingAcks)
+	partition.id.Store(state.LastAckID)
+	for _, ack := range state.Acks {
+		partition.ackMap.Add(ack.ID, ack.Acked)
+	}
+	return partition
+}
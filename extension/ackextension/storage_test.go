@@ -0,0 +1,343 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ackextension
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+var testStorageID = component.MustNewID("test_storage")
+
+func TestStorageExtensionAck_RecoversAfterRestart(t *testing.T) {
+	host := newTestStorageHost()
+
+	ext := newTestStorageAckExtension(t, 10, 10)
+	require.NoError(t, ext.Start(context.Background(), host))
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			ext.ProcessEvent(fmt.Sprintf("part-%d", i))
+		}
+	}
+	ext.Ack("part-0", 1)
+	ext.Ack("part-1", 3)
+	require.NoError(t, ext.Shutdown(context.Background()))
+
+	restarted := newTestStorageAckExtension(t, 10, 10)
+	require.NoError(t, restarted.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, restarted.Shutdown(context.Background()))
+	}()
+
+	require.Equal(t, map[uint64]bool{1: true, 2: false, 3: false}, restarted.QueryAcks("part-0", []uint64{1, 2, 3}))
+	require.Equal(t, map[uint64]bool{1: false, 2: false, 3: true}, restarted.QueryAcks("part-1", []uint64{1, 2, 3}))
+	require.Equal(t, map[uint64]bool{1: false, 2: false, 3: false}, restarted.QueryAcks("part-2", []uint64{1, 2, 3}))
+
+	// ack IDs keep increasing from where the previous instance stopped
+	require.Equal(t, uint64(4), restarted.ProcessEvent("part-0"))
+	require.Equal(t, uint64(1), restarted.ProcessEvent("part-3"))
+}
+
+func TestStorageExtensionAck_QueriedAcksAreRemoved(t *testing.T) {
+	host := newTestStorageHost()
+
+	ext := newTestStorageAckExtension(t, 10, 10)
+	require.NoError(t, ext.Start(context.Background(), host))
+	ackID := ext.ProcessEvent("part")
+	ext.Ack("part", ackID)
+	require.Equal(t, map[uint64]bool{ackID: true}, ext.QueryAcks("part", []uint64{ackID}))
+	require.NoError(t, ext.Shutdown(context.Background()))
+
+	restarted := newTestStorageAckExtension(t, 10, 10)
+	require.NoError(t, restarted.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, restarted.Shutdown(context.Background()))
+	}()
+	require.Equal(t, map[uint64]bool{ackID: false}, restarted.QueryAcks("part", []uint64{ackID}))
+}
+
+func TestStorageExtensionAck_EvictedPartitionsAreDeleted(t *testing.T) {
+	host := newTestStorageHost()
+
+	ext := newTestStorageAckExtension(t, 2, 2)
+	require.NoError(t, ext.Start(context.Background(), host))
+	for i := 0; i < 3; i++ {
+		ext.ProcessEvent(fmt.Sprintf("part-%d", i))
+	}
+	// the oldest ack of a partition is evicted when the limit is reached
+	for i := 0; i < 3; i++ {
+		ext.Ack("part-2", ext.ProcessEvent("part-2"))
+	}
+	require.NoError(t, ext.Shutdown(context.Background()))
+
+	require.NotContains(t, host.storage.data, partitionKey("part-0"))
+	require.Contains(t, host.storage.data, partitionKey("part-1"))
+	require.Contains(t, host.storage.data, partitionKey("part-2"))
+
+	restarted := newTestStorageAckExtension(t, 2, 2)
+	require.NoError(t, restarted.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, restarted.Shutdown(context.Background()))
+	}()
+	require.Equal(t, map[uint64]bool{1: false, 2: false, 3: true, 4: true}, restarted.QueryAcks("part-2", []uint64{1, 2, 3, 4}))
+}
+
+func TestStorageExtensionAck_LoweredPartitionLimit(t *testing.T) {
+	host := newTestStorageHost()
+
+	ext := newTestStorageAckExtension(t, 3, 10)
+	require.NoError(t, ext.Start(context.Background(), host))
+	for i := 0; i < 3; i++ {
+		ext.Ack(fmt.Sprintf("part-%d", i), ext.ProcessEvent(fmt.Sprintf("part-%d", i)))
+	}
+	require.NoError(t, ext.Shutdown(context.Background()))
+
+	restarted := newTestStorageAckExtension(t, 1, 10)
+	require.NoError(t, restarted.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, restarted.Shutdown(context.Background()))
+	}()
+	require.NotContains(t, host.storage.data, partitionKey("part-0"))
+	require.NotContains(t, host.storage.data, partitionKey("part-1"))
+	require.Equal(t, map[uint64]bool{1: true}, restarted.QueryAcks("part-2", []uint64{1}))
+}
+
+func TestStorageExtensionAck_WritesDeltas(t *testing.T) {
+	host := newTestStorageHost()
+
+	ext := newTestStorageAckExtension(t, 10, 10)
+	require.NoError(t, ext.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, ext.Shutdown(context.Background()))
+	}()
+	ext.ProcessEvent("part")
+	require.NoError(t, ext.flush(context.Background()))
+	snapshot, ok := host.storage.get(partitionKey("part"))
+	require.True(t, ok)
+	index, ok := host.storage.get(partitionsKey)
+	require.True(t, ok)
+
+	// later changes are appended without rewriting the snapshot or the index
+	ext.Ack("part", 1)
+	require.NoError(t, ext.flush(context.Background()))
+	ext.ProcessEvent("part")
+	require.NoError(t, ext.flush(context.Background()))
+	require.NoError(t, ext.flush(context.Background()))
+
+	value, _ := host.storage.get(partitionKey("part"))
+	require.Equal(t, snapshot, value)
+	value, _ = host.storage.get(partitionsKey)
+	require.Equal(t, index, value)
+	for seq := 1; seq <= 2; seq++ {
+		_, ok = host.storage.get(partitionDeltaKey("part", seq))
+		require.True(t, ok)
+	}
+	_, ok = host.storage.get(partitionDeltaKey("part", 3))
+	require.False(t, ok)
+
+	// the deltas are replayed by an instance started without a clean shutdown of the previous one
+	restarted := newTestStorageAckExtension(t, 10, 10)
+	require.NoError(t, restarted.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, restarted.Shutdown(context.Background()))
+	}()
+	require.Equal(t, map[uint64]bool{1: true, 2: false}, restarted.QueryAcks("part", []uint64{1, 2}))
+	require.Equal(t, uint64(3), restarted.ProcessEvent("part"))
+}
+
+func TestStorageExtensionAck_FlushesPeriodically(t *testing.T) {
+	host := newTestStorageHost()
+
+	ext := newTestStorageAckExtension(t, 10, 10)
+	require.NoError(t, ext.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, ext.Shutdown(context.Background()))
+	}()
+	ext.ProcessEvent("part")
+
+	require.Eventually(t, func() bool {
+		_, ok := host.storage.get(partitionKey("part"))
+		return ok
+	}, 5*flushInterval, flushInterval/10)
+}
+
+func TestStorageExtensionAck_CompactsDeltas(t *testing.T) {
+	host := newTestStorageHost()
+
+	ext := newTestStorageAckExtension(t, 10, 10)
+	require.NoError(t, ext.Start(context.Background(), host))
+	ackID := ext.ProcessEvent("part")
+	require.NoError(t, ext.flush(context.Background()))
+	for i := 0; i < minCompactionOps; i++ {
+		ext.Ack("part", ackID)
+		require.NoError(t, ext.flush(context.Background()))
+	}
+
+	for seq := 1; seq < minCompactionOps; seq++ {
+		_, ok := host.storage.get(partitionDeltaKey("part", seq))
+		require.False(t, ok)
+	}
+	partition, ok := ext.partitionMap.Peek("part")
+	require.True(t, ok)
+	require.Zero(t, partition.deltas)
+	require.NoError(t, ext.Shutdown(context.Background()))
+
+	restarted := newTestStorageAckExtension(t, 10, 10)
+	require.NoError(t, restarted.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, restarted.Shutdown(context.Background()))
+	}()
+	require.Equal(t, map[uint64]bool{ackID: true}, restarted.QueryAcks("part", []uint64{ackID}))
+}
+
+func TestStorageExtensionAck_FailedFlushIsRetried(t *testing.T) {
+	host := newTestStorageHost()
+
+	ext := newTestStorageAckExtension(t, 10, 10)
+	require.NoError(t, ext.Start(context.Background(), host))
+	ext.ProcessEvent("part-0")
+	require.NoError(t, ext.flush(context.Background()))
+
+	host.storage.setErr(errors.New("unavailable"))
+	ext.Ack("part-0", 1)
+	ext.ProcessEvent("part-1")
+	require.ErrorContains(t, ext.flush(context.Background()), "unavailable")
+
+	host.storage.setErr(nil)
+	require.NoError(t, ext.Shutdown(context.Background()))
+
+	restarted := newTestStorageAckExtension(t, 10, 10)
+	require.NoError(t, restarted.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, restarted.Shutdown(context.Background()))
+	}()
+	require.Equal(t, map[uint64]bool{1: true}, restarted.QueryAcks("part-0", []uint64{1}))
+	require.Equal(t, uint64(2), restarted.ProcessEvent("part-1"))
+}
+
+func TestStorageExtensionAck_MissingStorage(t *testing.T) {
+	ext := newTestStorageAckExtension(t, 10, 10)
+	require.ErrorContains(t, ext.Start(context.Background(), componenttest.NewNopHost()), "storage extension 'test_storage' not found")
+}
+
+func TestCreateExtensionWithStorage(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &testStorageID
+	ext, err := createExtension(context.Background(), extension.Settings{ID: component.MustNewID("ack"), TelemetrySettings: componenttest.NewNopTelemetrySettings()}, cfg)
+	require.NoError(t, err)
+	require.IsType(t, &storageAckExtension{}, ext)
+}
+
+func newTestStorageAckExtension(t *testing.T, maxNumPartition, maxNumPendingAcksPerPartition uint64) *storageAckExtension {
+	ext, err := newStorageAckExtension(&Config{
+		StorageID:                     &testStorageID,
+		MaxNumPartition:               maxNumPartition,
+		MaxNumPendingAcksPerPartition: maxNumPendingAcksPerPartition,
+	}, extension.Settings{
+		ID:                component.MustNewID("ack"),
+		TelemetrySettings: componenttest.NewNopTelemetrySettings(),
+	})
+	require.NoError(t, err)
+	return ext
+}
+
+type testStorageHost struct {
+	component.Host
+	storage *testStorage
+}
+
+func newTestStorageHost() *testStorageHost {
+	return &testStorageHost{
+		Host:    componenttest.NewNopHost(),
+		storage: &testStorage{data: map[string][]byte{}},
+	}
+}
+
+func (h *testStorageHost) GetExtensions() map[component.ID]component.Component {
+	return map[component.ID]component.Component{testStorageID: h.storage}
+}
+
+// testStorage hands out clients that share the same data, so that it outlives the ack extension.
+type testStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	mu   sync.Mutex
+	data map[string][]byte
+	// err fails every batch that writes to storage when set.
+	err error
+}
+
+func (s *testStorage) get(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.data[key]
+	return value, ok
+}
+
+func (s *testStorage) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+var _ storage.Extension = (*testStorage)(nil)
+
+func (s *testStorage) GetClient(context.Context, component.Kind, component.ID, string) (storage.Client, error) {
+	return &testStorageClient{storage: s}, nil
+}
+
+type testStorageClient struct {
+	storage *testStorage
+}
+
+func (c *testStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
+	op := storage.GetOperation(key)
+	err := c.Batch(ctx, op)
+	return op.Value, err
+}
+
+func (c *testStorageClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.Batch(ctx, storage.SetOperation(key, value))
+}
+
+func (c *testStorageClient) Delete(ctx context.Context, key string) error {
+	return c.Batch(ctx, storage.DeleteOperation(key))
+}
+
+func (c *testStorageClient) Batch(_ context.Context, ops ...storage.Operation) error {
+	c.storage.mu.Lock()
+	defer c.storage.mu.Unlock()
+	if c.storage.err != nil {
+		for _, op := range ops {
+			if op.Type != storage.Get {
+				return c.storage.err
+			}
+		}
+	}
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = c.storage.data[op.Key]
+		case storage.Set:
+			c.storage.data[op.Key] = op.Value
+		case storage.Delete:
+			delete(c.storage.data, op.Key)
+		}
+	}
+	return nil
+}
+
+func (c *testStorageClient) Close(context.Context) error {
+	return nil
+}
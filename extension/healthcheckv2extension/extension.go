@@ -5,6 +5,7 @@ package healthcheckv2extension // import "github.com/open-telemetry/opentelemetr
 
 import (
 	"context"
+	"sync"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
@@ -14,9 +15,15 @@ import (
 type healthCheckExtension struct {
 	config    Config
 	telemetry component.TelemetrySettings
+
+	mu               sync.Mutex
+	statuses         map[*component.InstanceID]*component.StatusEvent
+	subscribers      map[uint64]func(*component.InstanceID, *component.StatusEvent)
+	nextSubscriberID uint64
 }
 
 var _ component.Component = (*healthCheckExtension)(nil)
+var _ extension.StatusWatcher = (*healthCheckExtension)(nil)
 
 func newExtension(
 	_ context.Context,
@@ -24,8 +31,10 @@ func newExtension(
 	set extension.Settings,
 ) *healthCheckExtension {
 	return &healthCheckExtension{
-		config:    config,
-		telemetry: set.TelemetrySettings,
+		config:      config,
+		telemetry:   set.TelemetrySettings,
+		statuses:    make(map[*component.InstanceID]*component.StatusEvent),
+		subscribers: make(map[uint64]func(*component.InstanceID, *component.StatusEvent)),
 	}
 }
 
@@ -40,3 +49,39 @@ func (hc *healthCheckExtension) Start(context.Context, component.Host) error {
 func (hc *healthCheckExtension) Shutdown(context.Context) error {
 	return nil
 }
+
+// ComponentStatusChanged implements the extension.StatusWatcher interface.
+func (hc *healthCheckExtension) ComponentStatusChanged(
+	source *component.InstanceID,
+	event *component.StatusEvent,
+) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	hc.statuses[source] = event
+	for _, fn := range hc.subscribers {
+		fn(source, event)
+	}
+}
+
+// SubscribeComponentStatus lets other components, like the failover connector, follow the component
+// status of the collector. fn is called with the latest status of every component reported so far, then
+// with every status change, until the returned function is called. fn must not block.
+func (hc *healthCheckExtension) SubscribeComponentStatus(
+	fn func(*component.InstanceID, *component.StatusEvent),
+) (unsubscribe func()) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	for source, event := range hc.statuses {
+		fn(source, event)
+	}
+
+	id := hc.nextSubscriberID
+	hc.nextSubscriberID++
+	hc.subscribers[id] = fn
+
+	return func() {
+		hc.mu.Lock()
+		defer hc.mu.Unlock()
+		delete(hc.subscribers, id)
+	}
+}
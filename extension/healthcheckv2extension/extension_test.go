@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheckv2extension
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+func TestSubscribeComponentStatus(t *testing.T) {
+	hc := newExtension(context.Background(), *createDefaultConfig().(*Config), extensiontest.NewNopSettings())
+
+	exporter := &component.InstanceID{ID: component.MustNewID("otlp"), Kind: component.KindExporter}
+	receiver := &component.InstanceID{ID: component.MustNewID("otlp"), Kind: component.KindReceiver}
+	hc.ComponentStatusChanged(exporter, component.NewStatusEvent(component.StatusStarting))
+	hc.ComponentStatusChanged(exporter, component.NewStatusEvent(component.StatusOK))
+
+	statuses := map[*component.InstanceID][]component.Status{}
+	unsubscribe := hc.SubscribeComponentStatus(func(source *component.InstanceID, event *component.StatusEvent) {
+		statuses[source] = append(statuses[source], event.Status())
+	})
+
+	// the latest status of the components is replayed on subscription
+	assert.Equal(t, map[*component.InstanceID][]component.Status{
+		exporter: {component.StatusOK},
+	}, statuses)
+
+	hc.ComponentStatusChanged(receiver, component.NewStatusEvent(component.StatusOK))
+	hc.ComponentStatusChanged(exporter, component.NewRecoverableErrorEvent(errors.New("unavailable")))
+	assert.Equal(t, map[*component.InstanceID][]component.Status{
+		exporter: {component.StatusOK, component.StatusRecoverableError},
+		receiver: {component.StatusOK},
+	}, statuses)
+
+	unsubscribe()
+	hc.ComponentStatusChanged(exporter, component.NewStatusEvent(component.StatusOK))
+	assert.Equal(t, []component.Status{component.StatusOK, component.StatusRecoverableError}, statuses[exporter])
+}
@@ -8,72 +8,81 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	// Postgres driver
 	_ "github.com/jackc/pgx/v5/stdlib"
 	// SQLite driver
 	_ "github.com/mattn/go-sqlite3"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
 )
 
 const (
-	createTable     = "create table if not exists %s (key text primary key, value blob)"
-	getQueryText    = "select value from %s where key=?"
-	setQueryText    = "insert into %s(key, value) values(?,?) on conflict(key) do update set value=?"
-	deleteQueryText = "delete from %s where key=?"
+	getQueryText           = "select value from %s where key=?"
+	setQueryText           = "insert into %s(key, value, updated_at) values(?,?,?) on conflict(key) do update set value=excluded.value, updated_at=excluded.updated_at"
+	deleteQueryText        = "delete from %s where key=?"
+	deleteExpiredQueryText = "delete from %s where updated_at<?"
 )
 
 type dbStorageClient struct {
 	db          *sql.DB
+	logger      *zap.Logger
+	tableName   string
 	getQuery    *sql.Stmt
 	setQuery    *sql.Stmt
 	deleteQuery *sql.Stmt
+
+	ttl                 time.Duration
+	deleteExpiredQuery  *sql.Stmt
+	stopCompaction      context.CancelFunc
+	compactionWaitGroup sync.WaitGroup
 }
 
-func newClient(ctx context.Context, db *sql.DB, tableName string) (*dbStorageClient, error) {
-	var err error
-	_, err = db.ExecContext(ctx, fmt.Sprintf(createTable, tableName))
-	if err != nil {
+func newClient(ctx context.Context, logger *zap.Logger, db *sql.DB, d dialect, tableName string, cfg *Config) (*dbStorageClient, error) {
+	if err := migrate(ctx, db, d, tableName); err != nil {
 		return nil, err
 	}
 
-	selectQuery, err := db.PrepareContext(ctx, fmt.Sprintf(getQueryText, tableName))
-	if err != nil {
-		return nil, err
+	client := &dbStorageClient{
+		db:        db,
+		logger:    logger.With(zap.String("table", tableName)),
+		tableName: tableName,
+		ttl:       cfg.TTL,
 	}
-	setQuery, err := db.PrepareContext(ctx, fmt.Sprintf(setQueryText, tableName))
-	if err != nil {
+	var err error
+	if client.getQuery, err = prepare(ctx, db, d, getQueryText, tableName); err != nil {
 		return nil, err
 	}
-	deleteQuery, err := db.PrepareContext(ctx, fmt.Sprintf(deleteQueryText, tableName))
-	if err != nil {
-		return nil, err
+	if client.setQuery, err = prepare(ctx, db, d, setQueryText, tableName); err != nil {
+		return nil, errors.Join(err, client.closeStatements())
+	}
+	if client.deleteQuery, err = prepare(ctx, db, d, deleteQueryText, tableName); err != nil {
+		return nil, errors.Join(err, client.closeStatements())
+	}
+	if client.deleteExpiredQuery, err = prepare(ctx, db, d, deleteExpiredQueryText, tableName); err != nil {
+		return nil, errors.Join(err, client.closeStatements())
+	}
+
+	if client.ttl > 0 {
+		client.startCompaction(cfg.CompactionInterval)
 	}
-	return &dbStorageClient{db, selectQuery, setQuery, deleteQuery}, nil
+	return client, nil
+}
+
+func prepare(ctx context.Context, db *sql.DB, d dialect, queryText string, tableName string) (*sql.Stmt, error) {
+	return db.PrepareContext(ctx, d.rebind(fmt.Sprintf(queryText, tableName)))
 }
 
 // Get will retrieve data from storage that corresponds to the specified key
 func (c *dbStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
-	rows, err := c.getQuery.QueryContext(ctx, key)
-	if err != nil {
-		return nil, err
-	}
-	if !rows.Next() {
-		return nil, nil
-	}
-	var result []byte
-	err = rows.Scan(&result)
-	if err != nil {
-		return result, err
-	}
-	err = rows.Close()
-	return result, err
+	return c.get(ctx, c.getQuery, key)
 }
 
 // Set will store data. The data can be retrieved using the same key
 func (c *dbStorageClient) Set(ctx context.Context, key string, value []byte) error {
-	_, err := c.setQuery.ExecContext(ctx, key, value, value)
-	return err
+	return c.set(ctx, c.setQuery, key, value)
 }
 
 // Delete will delete data associated with the specified key
@@ -82,17 +91,38 @@ func (c *dbStorageClient) Delete(ctx context.Context, key string) error {
 	return err
 }
 
-// Batch executes the specified operations in order. Get operation results are updated in place
+// Batch executes the specified operations in order, in a single transaction. Get operation results are updated in place
 func (c *dbStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err = c.batch(ctx, tx, ops); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	return tx.Commit()
+}
+
+func (c *dbStorageClient) batch(ctx context.Context, tx *sql.Tx, ops []storage.Operation) error {
+	var getQuery, setQuery, deleteQuery *sql.Stmt
 	var err error
 	for _, op := range ops {
 		switch op.Type {
 		case storage.Get:
-			op.Value, err = c.Get(ctx, op.Key)
+			if getQuery == nil {
+				getQuery = tx.StmtContext(ctx, c.getQuery)
+			}
+			op.Value, err = c.get(ctx, getQuery, op.Key)
 		case storage.Set:
-			err = c.Set(ctx, op.Key, op.Value)
+			if setQuery == nil {
+				setQuery = tx.StmtContext(ctx, c.setQuery)
+			}
+			err = c.set(ctx, setQuery, op.Key, op.Value)
 		case storage.Delete:
-			err = c.Delete(ctx, op.Key)
+			if deleteQuery == nil {
+				deleteQuery = tx.StmtContext(ctx, c.deleteQuery)
+			}
+			_, err = deleteQuery.ExecContext(ctx, op.Key)
 		default:
 			return errors.New("wrong operation type")
 		}
@@ -101,16 +131,76 @@ func (c *dbStorageClient) Batch(ctx context.Context, ops ...storage.Operation) e
 			return err
 		}
 	}
+	return nil
+}
+
+func (c *dbStorageClient) get(ctx context.Context, getQuery *sql.Stmt, key string) ([]byte, error) {
+	var result []byte
+	err := getQuery.QueryRowContext(ctx, key).Scan(&result)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return result, err
+}
+
+func (c *dbStorageClient) set(ctx context.Context, setQuery *sql.Stmt, key string, value []byte) error {
+	_, err := setQuery.ExecContext(ctx, key, value, time.Now().UnixNano())
 	return err
 }
 
+// startCompaction periodically purges the keys that were not written for longer than the TTL.
+func (c *dbStorageClient) startCompaction(interval time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.stopCompaction = cancel
+	c.compactionWaitGroup.Add(1)
+	go func() {
+		defer c.compactionWaitGroup.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				deleted, err := c.deleteExpired(ctx, time.Now().Add(-c.ttl))
+				if err != nil {
+					if ctx.Err() == nil {
+						c.logger.Warn("Failed to purge expired keys", zap.Error(err))
+					}
+					continue
+				}
+				if deleted > 0 {
+					c.logger.Debug("Purged expired keys", zap.Int64("count", deleted))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// deleteExpired deletes the keys last written before the cutoff, and returns how many were deleted.
+func (c *dbStorageClient) deleteExpired(ctx context.Context, cutoff time.Time) (int64, error) {
+	res, err := c.deleteExpiredQuery.ExecContext(ctx, cutoff.UnixNano())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
 // Close will close the database
 func (c *dbStorageClient) Close(_ context.Context) error {
-	if err := c.setQuery.Close(); err != nil {
-		return err
+	if c.stopCompaction != nil {
+		c.stopCompaction()
+		c.compactionWaitGroup.Wait()
 	}
-	if err := c.deleteQuery.Close(); err != nil {
-		return err
+	return c.closeStatements()
+}
+
+func (c *dbStorageClient) closeStatements() error {
+	var errs error
+	for _, stmt := range []*sql.Stmt{c.getQuery, c.setQuery, c.deleteQuery, c.deleteExpiredQuery} {
+		if stmt != nil {
+			errs = errors.Join(errs, stmt.Close())
+		}
 	}
-	return c.getQuery.Close()
+	return errs
 }
@@ -5,12 +5,21 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 
 import (
 	"errors"
+	"fmt"
+	"time"
 )
 
 // Config defines configuration for dbstorage extension.
 type Config struct {
 	DriverName string `mapstructure:"driver,omitempty"`
 	DataSource string `mapstructure:"datasource,omitempty"`
+	// Dialect is the SQL dialect of the database, "sqlite" or "postgres".
+	// When empty, it is inferred from the driver name.
+	Dialect string `mapstructure:"dialect,omitempty"`
+	// TTL is how long a key is kept after it was last written. Keys are kept forever when zero.
+	TTL time.Duration `mapstructure:"ttl,omitempty"`
+	// CompactionInterval is how often keys older than the TTL are purged.
+	CompactionInterval time.Duration `mapstructure:"compaction_interval,omitempty"`
 }
 
 func (cfg *Config) Validate() error {
@@ -20,6 +29,15 @@ func (cfg *Config) Validate() error {
 	if cfg.DriverName == "" {
 		return errors.New("missing driver name")
 	}
+	if _, ok := dialects[cfg.Dialect]; cfg.Dialect != "" && !ok {
+		return fmt.Errorf("unsupported dialect: %s", cfg.Dialect)
+	}
+	if cfg.TTL < 0 {
+		return errors.New("ttl must not be negative")
+	}
+	if cfg.TTL > 0 && cfg.CompactionInterval <= 0 {
+		return errors.New("compaction interval must be positive when a ttl is set")
+	}
 
 	return nil
 }
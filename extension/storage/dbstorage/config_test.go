@@ -6,6 +6,7 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -31,6 +32,26 @@ func TestConfigValidate(t *testing.T) {
 			Config{DriverName: "foo", DataSource: "bar"},
 			nil,
 		},
+		{
+			"Unsupported dialect",
+			Config{DriverName: "foo", DataSource: "bar", Dialect: "oracle"},
+			errors.New("unsupported dialect: oracle"),
+		},
+		{
+			"Negative ttl",
+			Config{DriverName: "foo", DataSource: "bar", TTL: -time.Second},
+			errors.New("ttl must not be negative"),
+		},
+		{
+			"Missing compaction interval",
+			Config{DriverName: "foo", DataSource: "bar", TTL: time.Hour},
+			errors.New("compaction interval must be positive when a ttl is set"),
+		},
+		{
+			"valid with ttl",
+			Config{DriverName: "pgx", DataSource: "bar", Dialect: "postgres", TTL: time.Hour, CompactionInterval: time.Minute},
+			nil,
+		},
 	}
 
 	for _, test := range tests {
@@ -42,3 +63,16 @@ func TestConfigValidate(t *testing.T) {
 		}
 	}
 }
+
+func TestDialectFor(t *testing.T) {
+	assert.Equal(t, dialectSQLite, dialectFor(&Config{DriverName: "sqlite3"}).name)
+	assert.Equal(t, dialectPostgres, dialectFor(&Config{DriverName: "pgx"}).name)
+	assert.Equal(t, dialectSQLite, dialectFor(&Config{DriverName: "custom"}).name)
+	assert.Equal(t, dialectPostgres, dialectFor(&Config{DriverName: "custom", Dialect: "postgres"}).name)
+}
+
+func TestDialectRebind(t *testing.T) {
+	query := "insert into t(key, value) values(?,?)"
+	assert.Equal(t, query, dialects[dialectSQLite].rebind(query))
+	assert.Equal(t, "insert into t(key, value) values($1,$2)", dialects[dialectPostgres].rebind(query))
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	dialectSQLite   = "sqlite"
+	dialectPostgres = "postgres"
+)
+
+// dialect holds what differs between the SQL engines supported by the extension.
+// Queries are written with '?' placeholders, and rebound to the placeholders of the engine.
+type dialect struct {
+	name string
+	// valueType is the column type used to store values.
+	valueType string
+	// numberedPlaceholders is true when the engine uses $1, $2, ... placeholders.
+	numberedPlaceholders bool
+}
+
+var dialects = map[string]dialect{
+	dialectSQLite: {
+		name:      dialectSQLite,
+		valueType: "blob",
+	},
+	dialectPostgres: {
+		name:                 dialectPostgres,
+		valueType:            "bytea",
+		numberedPlaceholders: true,
+	},
+}
+
+// driverDialects maps the well known driver names to their dialect.
+var driverDialects = map[string]string{
+	"sqlite3":  dialectSQLite,
+	"sqlite":   dialectSQLite,
+	"pgx":      dialectPostgres,
+	"pgx/v5":   dialectPostgres,
+	"postgres": dialectPostgres,
+}
+
+// dialectFor returns the dialect to use for the configuration. When no dialect is configured,
+// it is inferred from the driver name, falling back to SQLite.
+func dialectFor(cfg *Config) dialect {
+	if cfg.Dialect != "" {
+		return dialects[cfg.Dialect]
+	}
+	if name, ok := driverDialects[cfg.DriverName]; ok {
+		return dialects[name]
+	}
+	return dialects[dialectSQLite]
+}
+
+// rebind replaces the '?' placeholders of the query with the ones of the dialect.
+func (d dialect) rebind(query string) string {
+	if !d.numberedPlaceholders {
+		return query
+	}
+	var sb strings.Builder
+	n := 0
+	for _, r := range query {
+		if r != '?' {
+			sb.WriteRune(r)
+			continue
+		}
+		n++
+		sb.WriteByte('$')
+		sb.WriteString(strconv.Itoa(n))
+	}
+	return sb.String()
+}
@@ -6,6 +6,7 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -18,6 +19,8 @@ import (
 type databaseStorage struct {
 	driverName     string
 	datasourceName string
+	dialect        dialect
+	config         *Config
 	logger         *zap.Logger
 	db             *sql.DB
 }
@@ -29,19 +32,24 @@ func newDBStorage(logger *zap.Logger, config *Config) (extension.Extension, erro
 	return &databaseStorage{
 		driverName:     config.DriverName,
 		datasourceName: config.DataSource,
+		dialect:        dialectFor(config),
+		config:         config,
 		logger:         logger,
 	}, nil
 }
 
-// Start opens a connection to the database
-func (ds *databaseStorage) Start(context.Context, component.Host) error {
+// Start opens a connection to the database, and creates the table tracking the schema of the client tables
+func (ds *databaseStorage) Start(ctx context.Context, _ component.Host) error {
 	db, err := sql.Open(ds.driverName, ds.datasourceName)
 	if err != nil {
 		return err
 	}
 
-	if err := db.Ping(); err != nil {
-		return err
+	if err := db.PingContext(ctx); err != nil {
+		return errors.Join(err, db.Close())
+	}
+	if _, err := db.ExecContext(ctx, createMigrationsTable); err != nil {
+		return errors.Join(err, db.Close())
 	}
 	ds.db = db
 	return nil
@@ -64,7 +72,7 @@ func (ds *databaseStorage) GetClient(ctx context.Context, kind component.Kind, e
 		fullName = fmt.Sprintf("%s_%s_%s_%s", kindString(kind), ent.Type(), ent.Name(), name)
 	}
 	fullName = strings.ReplaceAll(fullName, " ", "")
-	return newClient(ctx, ds.db, fullName)
+	return newClient(ctx, ds.logger, ds.db, ds.dialect, fullName, ds.config)
 }
 
 func kindString(k component.Kind) string {
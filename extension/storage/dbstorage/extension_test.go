@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -97,11 +98,108 @@ func TestExtensionIntegrity(t *testing.T) {
 	wg.Wait()
 }
 
-func newTestExtension(t *testing.T) storage.Extension {
+func TestBatchIsTransactional(t *testing.T) {
+	ctx := context.Background()
+	se := newTestExtension(t)
+	require.NoError(t, se.Start(ctx, componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, se.Shutdown(ctx))
+	}()
+
+	client, err := se.GetClient(ctx, component.KindExporter, newTestEntity("exporter"), "queue")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, client.Close(ctx))
+	}()
+
+	require.NoError(t, client.Batch(ctx,
+		storage.SetOperation("a", []byte("1")),
+		storage.SetOperation("b", []byte("2")),
+		storage.DeleteOperation("a"),
+	))
+	getA, getB := storage.GetOperation("a"), storage.GetOperation("b")
+	require.NoError(t, client.Batch(ctx, getA, getB))
+	assert.Nil(t, getA.Value)
+	assert.Equal(t, []byte("2"), getB.Value)
+
+	// a failing operation rolls back the whole batch
+	invalid := storage.SetOperation("c", nil)
+	invalid.Type = storage.OpType(42)
+	require.Error(t, client.Batch(ctx, storage.SetOperation("b", []byte("3")), invalid))
+	v, err := client.Get(ctx, "b")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("2"), v)
+}
+
+func TestExpiredKeysArePurged(t *testing.T) {
+	ctx := context.Background()
+	se := newTestExtension(t, func(cfg *Config) {
+		cfg.TTL = time.Hour
+	})
+	require.NoError(t, se.Start(ctx, componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, se.Shutdown(ctx))
+	}()
+
+	client, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("receiver"), "")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, client.Close(ctx))
+	}()
+
+	require.NoError(t, client.Set(ctx, "a", []byte("1")))
+
+	dbClient := client.(*dbStorageClient)
+	deleted, err := dbClient.deleteExpired(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	deleted, err = dbClient.deleteExpired(ctx, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	v, err := client.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Nil(t, v)
+}
+
+func TestMigrations(t *testing.T) {
+	ctx := context.Background()
+	se := newTestExtension(t)
+	require.NoError(t, se.Start(ctx, componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, se.Shutdown(ctx))
+	}()
+	db := se.(*databaseStorage).db
+
+	// a table created by a previous version of the extension, without any schema version
+	_, err := db.ExecContext(ctx, "create table if not exists receiver_nop_legacy (key text primary key, value blob)")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "insert into receiver_nop_legacy(key, value) values('a', 'old')")
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		client, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("legacy"), "")
+		require.NoError(t, err)
+
+		v, err := client.Get(ctx, "a")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("old"), v)
+		require.NoError(t, client.Close(ctx))
+	}
+
+	var version int
+	require.NoError(t, db.QueryRowContext(ctx, getVersionQueryText, "receiver_nop_legacy").Scan(&version))
+	assert.Equal(t, len(migrations), version)
+}
+
+func newTestExtension(t *testing.T, opts ...func(*Config)) storage.Extension {
 	f := NewFactory()
 	cfg := f.CreateDefaultConfig().(*Config)
 	cfg.DriverName = "sqlite3"
 	cfg.DataSource = fmt.Sprintf("file:%s/foo.db?_busy_timeout=10000&_journal=WAL&_sync=NORMAL", t.TempDir())
+	for _, opt := range opts {
+		opt(cfg)
+	}
 
 	extension, err := f.CreateExtension(context.Background(), extensiontest.NewNopSettings(), cfg)
 	require.NoError(t, err)
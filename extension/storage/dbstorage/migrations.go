@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// migrationsTable records the schema version of every client table.
+const (
+	migrationsTable            = "dbstorage_schema_migrations"
+	createMigrationsTable      = "create table if not exists " + migrationsTable + " (table_name text primary key, version integer not null)"
+	getVersionQueryText        = "select version from " + migrationsTable + " where table_name=?"
+	setVersionQueryText        = "insert into " + migrationsTable + "(table_name, version) values(?,?) on conflict(table_name) do update set version=excluded.version"
+	migrationCreateTable       = "create table if not exists %s (key text primary key, value %s)"
+	migrationAddUpdatedAt      = "alter table %s add column updated_at bigint"
+	migrationSetUpdatedAtQuery = "update %s set updated_at=? where updated_at is null"
+)
+
+// migration upgrades a client table to the next schema version.
+type migration func(ctx context.Context, tx *sql.Tx, d dialect, tableName string) error
+
+// migrations are applied in order, the schema version of a table being the number of migrations applied to it.
+// Existing migrations must never be modified, changes to the schema are made by appending new ones.
+var migrations = []migration{
+	// 1: the original key/value table
+	func(ctx context.Context, tx *sql.Tx, d dialect, tableName string) error {
+		_, err := tx.ExecContext(ctx, fmt.Sprintf(migrationCreateTable, tableName, d.valueType))
+		return err
+	},
+	// 2: track when keys were last written, so stale keys can be purged
+	func(ctx context.Context, tx *sql.Tx, d dialect, tableName string) error {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(migrationAddUpdatedAt, tableName)); err != nil {
+			return err
+		}
+		// existing keys are considered written now, rather than being purged right away
+		_, err := tx.ExecContext(ctx, d.rebind(fmt.Sprintf(migrationSetUpdatedAtQuery, tableName)), time.Now().UnixNano())
+		return err
+	},
+}
+
+// migrate brings the schema of the table up to date, in a single transaction.
+func migrate(ctx context.Context, db *sql.DB, d dialect, tableName string) (err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		}
+	}()
+
+	var version int
+	err = tx.QueryRowContext(ctx, d.rebind(getVersionQueryText), tableName).Scan(&version)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("table %s has schema version %d, newer than the supported version %d", tableName, version, len(migrations))
+	}
+	if version == len(migrations) {
+		return tx.Commit()
+	}
+
+	for i := version; i < len(migrations); i++ {
+		if err = migrations[i](ctx, tx, d, tableName); err != nil {
+			return fmt.Errorf("failed to migrate table %s to schema version %d: %w", tableName, i+1, err)
+		}
+	}
+	if _, err = tx.ExecContext(ctx, d.rebind(setVersionQueryText), tableName, len(migrations)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
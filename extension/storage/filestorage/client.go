@@ -8,17 +8,25 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sync"
 	"syscall"
 	"time"
 
 	"go.etcd.io/bbolt"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage/internal/metadata"
 )
 
 var defaultBucket = []byte(`default`)
 
+// ErrStorageFull is returned when writing would make the data stored by a client exceed the configured max size.
+var ErrStorageFull = errors.New("storage is full")
+
 const (
 	TempDbPrefix = "tempdb"
 
@@ -37,6 +45,22 @@ type fileStorageClient struct {
 	openTimeout     time.Duration
 	cancel          context.CancelFunc
 	closed          bool
+
+	// writeMutex serializes the write transactions of Batch, bbolt updating the database
+	// statistics only after releasing its own lock
+	writeMutex sync.Mutex
+	// maxSizeBytes is the maximum size of the stored data, zero meaning no limit
+	maxSizeBytes int64
+	// telemetry is nil when no internal metrics are reported
+	telemetry         *metadata.TelemetryBuilder
+	telemetryAttrs    metric.MeasurementOption
+	compactionJournal map[string]journalEntry
+}
+
+// journalEntry is a write made while the database is being compacted, to be replayed on the compacted database.
+type journalEntry struct {
+	value   []byte
+	deleted bool
 }
 
 func bboltOptions(timeout time.Duration, noSync bool) *bbolt.Options {
@@ -48,7 +72,7 @@ func bboltOptions(timeout time.Duration, noSync bool) *bbolt.Options {
 	}
 }
 
-func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, noSync bool) (*fileStorageClient, error) {
+func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, noSync bool, maxSizeMiB int64, telemetry *metadata.TelemetryBuilder) (*fileStorageClient, error) {
 	options := bboltOptions(timeout, noSync)
 	db, err := bbolt.Open(filePath, 0600, options)
 	if err != nil {
@@ -64,7 +88,16 @@ func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compa
 		return nil, err
 	}
 
-	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout}
+	client := &fileStorageClient{
+		logger:         logger,
+		db:             db,
+		compactionCfg:  compactionCfg,
+		openTimeout:    timeout,
+		maxSizeBytes:   maxSizeMiB * oneMiB,
+		telemetry:      telemetry,
+		telemetryAttrs: metric.WithAttributes(attribute.String("file", filepath.Base(filePath))),
+	}
+	client.recordDbSize()
 	if compactionCfg.OnRebound {
 		client.startCompactionLoop(context.Background())
 	}
@@ -94,13 +127,28 @@ func (c *fileStorageClient) Delete(ctx context.Context, key string) error {
 }
 
 // Batch executes the specified operations in order. Get operation results are updated in place
-func (c *fileStorageClient) Batch(_ context.Context, ops ...storage.Operation) error {
+func (c *fileStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	c.compactionMutex.RLock()
+	defer c.compactionMutex.RUnlock()
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+
+	var size int64
+	// writes to journal once the transaction is committed, if the database is being compacted
+	var writes map[string]journalEntry
+	if c.compactionJournal != nil {
+		writes = make(map[string]journalEntry)
+	}
 	batch := func(tx *bbolt.Tx) error {
 		bucket := tx.Bucket(defaultBucket)
 		if bucket == nil {
 			return errors.New("storage not initialized")
 		}
 
+		if err := c.checkSize(tx, ops); err != nil {
+			return err
+		}
+
 		var err error
 		for _, op := range ops {
 			switch op.Type {
@@ -116,8 +164,15 @@ func (c *fileStorageClient) Batch(_ context.Context, ops ...storage.Operation) e
 				}
 			case storage.Set:
 				err = bucket.Put([]byte(op.Key), op.Value)
+				if writes != nil {
+					// the value is owned by the caller, and may be modified once the batch is done
+					writes[op.Key] = journalEntry{value: append([]byte{}, op.Value...)}
+				}
 			case storage.Delete:
 				err = bucket.Delete([]byte(op.Key))
+				if writes != nil {
+					writes[op.Key] = journalEntry{deleted: true}
+				}
 			default:
 				return errors.New("wrong operation type")
 			}
@@ -127,12 +182,48 @@ func (c *fileStorageClient) Batch(_ context.Context, ops ...storage.Operation) e
 			}
 		}
 
+		size = tx.Size()
 		return nil
 	}
 
-	c.compactionMutex.RLock()
-	defer c.compactionMutex.RUnlock()
-	return c.db.Update(batch)
+	if err := c.db.Update(batch); err != nil {
+		return err
+	}
+	// only the writes of committed transactions are replayed on the compacted database
+	for key, entry := range writes {
+		c.compactionJournal[key] = entry
+	}
+	if c.telemetry != nil {
+		c.telemetry.FilestorageDbSize.Record(ctx, size, c.telemetryAttrs)
+	}
+	return nil
+}
+
+// checkSize returns ErrStorageFull if the operations would make the stored data exceed the max size.
+// Operations that do not write any data, like deletions, are always allowed so space can be freed.
+// It is called within the write transaction, with the write mutex held so that the database
+// statistics include the previous writes.
+func (c *fileStorageClient) checkSize(tx *bbolt.Tx, ops []storage.Operation) error {
+	if c.maxSizeBytes <= 0 {
+		return nil
+	}
+
+	var written int64
+	for _, op := range ops {
+		if op.Type == storage.Set {
+			written += int64(len(op.Key) + len(op.Value))
+		}
+	}
+	if written == 0 {
+		return nil
+	}
+
+	dataSize := tx.Size() - int64(c.db.Stats().FreeAlloc)
+	if dataSize+written > c.maxSizeBytes {
+		return fmt.Errorf("%w: writing %d bytes would exceed the max size of %d bytes, %d bytes are already used",
+			ErrStorageFull, written, c.maxSizeBytes, dataSize)
+	}
+	return nil
 }
 
 // Close will close the database
@@ -147,7 +238,10 @@ func (c *fileStorageClient) Close(_ context.Context) error {
 	return c.db.Close()
 }
 
-// Compact database. Use temporary file as helper as we cannot replace database in-place
+// Compact database. Use temporary file as helper as we cannot replace database in-place.
+// The data is copied to the temporary file while the database remains usable, the writes made meanwhile are
+// recorded and replayed on the compacted database, which then replaces the current one.
+// Operations are only blocked during that last step.
 func (c *fileStorageClient) Compact(compactionDirectory string, timeout time.Duration, maxTransactionSize int64) error {
 	var err error
 	var file *os.File
@@ -176,31 +270,44 @@ func (c *fileStorageClient) Compact(compactionDirectory string, timeout time.Dur
 	// use temporary file as compaction target
 	options := bboltOptions(timeout, c.db.NoSync)
 
+	// start recording the writes made while compacting
 	c.compactionMutex.Lock()
-	defer c.compactionMutex.Unlock()
 	if c.closed {
+		c.compactionMutex.Unlock()
 		c.logger.Debug("skipping compaction since database is already closed")
 		return nil
 	}
+	c.compactionJournal = make(map[string]journalEntry)
+	c.compactionMutex.Unlock()
 
 	c.logger.Debug("starting compaction",
 		zap.String(directoryKey, c.db.Path()),
 		zap.String(tempDirectoryKey, file.Name()))
 
+	compactionStart := time.Now()
+
 	// cannot reuse newClient as db shouldn't contain any bucket
-	compactedDb, err = bbolt.Open(file.Name(), 0600, options)
+	compactedDb, err = c.copyToCompactedDb(file.Name(), options, maxTransactionSize)
+
+	c.compactionMutex.Lock()
+	defer c.compactionMutex.Unlock()
+	journal := c.compactionJournal
+	c.compactionJournal = nil
 	if err != nil {
 		return err
 	}
+	if c.closed {
+		c.logger.Debug("skipping compaction since database is already closed")
+		return compactedDb.Close()
+	}
 
-	compactionStart := time.Now()
-
-	if err = bbolt.Compact(compactedDb, c.db, maxTransactionSize); err != nil {
-		return err
+	if err = replayJournal(compactedDb, journal); err != nil {
+		return errors.Join(err, compactedDb.Close())
 	}
 
 	dbPath := c.db.Path()
 	compactedDbPath := compactedDb.Path()
+	sizeBefore, _, _ := c.getDbSize()
 
 	c.db.Close()
 	compactedDb.Close()
@@ -232,13 +339,65 @@ func (c *fileStorageClient) Compact(compactionDirectory string, timeout time.Dur
 		return fmt.Errorf("failed to move compacted database, compaction aborted: %w", moveErr)
 	}
 
+	elapsed := time.Since(compactionStart)
+	sizeAfter, _, _ := c.getDbSize()
+	if c.telemetry != nil {
+		ctx := context.Background()
+		c.telemetry.FilestorageCompactionDuration.Record(ctx, elapsed.Seconds(), c.telemetryAttrs)
+		if sizeBefore > sizeAfter {
+			c.telemetry.FilestorageCompactionReclaimedSize.Add(ctx, sizeBefore-sizeAfter, c.telemetryAttrs)
+		}
+		c.telemetry.FilestorageDbSize.Record(ctx, sizeAfter, c.telemetryAttrs)
+	}
+
 	c.logger.Info("finished compaction",
 		zap.String(directoryKey, dbPath),
-		zap.Duration(elapsedKey, time.Since(compactionStart)))
+		zap.Duration(elapsedKey, elapsed),
+		zap.Int64("reclaimedBytes", sizeBefore-sizeAfter))
 
 	return nil
 }
 
+// copyToCompactedDb copies the data of the database to a new database at path.
+// The compaction mutex is only held as a reader, so operations can still be performed on the database.
+func (c *fileStorageClient) copyToCompactedDb(path string, options *bbolt.Options, maxTransactionSize int64) (*bbolt.DB, error) {
+	c.compactionMutex.RLock()
+	defer c.compactionMutex.RUnlock()
+
+	compactedDb, err := bbolt.Open(path, 0600, options)
+	if err != nil {
+		return nil, err
+	}
+	if err = bbolt.Compact(compactedDb, c.db, maxTransactionSize); err != nil {
+		return nil, errors.Join(err, compactedDb.Close())
+	}
+	return compactedDb, nil
+}
+
+// replayJournal applies the writes made during the compaction to the compacted database.
+func replayJournal(db *bbolt.DB, journal map[string]journalEntry) error {
+	if len(journal) == 0 {
+		return nil
+	}
+	return db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(defaultBucket)
+		if err != nil {
+			return err
+		}
+		for key, entry := range journal {
+			if entry.deleted {
+				err = bucket.Delete([]byte(key))
+			} else {
+				err = bucket.Put([]byte(key), entry.value)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // startCompactionLoop provides asynchronous compaction function
 func (c *fileStorageClient) startCompactionLoop(ctx context.Context) {
 	ctx, c.cancel = context.WithCancel(ctx)
@@ -297,6 +456,19 @@ func (c *fileStorageClient) shouldCompact() bool {
 	return true
 }
 
+// recordDbSize reports the size of the database, if internal metrics are reported.
+func (c *fileStorageClient) recordDbSize() {
+	if c.telemetry == nil {
+		return
+	}
+	totalSize, _, err := c.getDbSize()
+	if err != nil {
+		c.logger.Debug("failed to get db size", zap.Error(err))
+		return
+	}
+	c.telemetry.FilestorageDbSize.Record(context.Background(), totalSize, c.telemetryAttrs)
+}
+
 func (c *fileStorageClient) getDbSize() (totalSizeResult int64, dataSizeResult int64, errResult error) {
 	var totalSize int64
 
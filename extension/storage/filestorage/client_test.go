@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.etcd.io/bbolt"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
@@ -21,7 +22,7 @@ import (
 func TestClientOperations(t *testing.T) {
 	dbFile := filepath.Join(t.TempDir(), "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false)
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, 0, nil)
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		require.NoError(t, client.Close(context.TODO()))
@@ -59,7 +60,7 @@ func TestClientBatchOperations(t *testing.T) {
 	tempDir := t.TempDir()
 	dbFile := filepath.Join(tempDir, "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false)
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, 0, nil)
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		require.NoError(t, client.Close(context.TODO()))
@@ -180,7 +181,7 @@ func TestNewClientTransactionErrors(t *testing.T) {
 			tempDir := t.TempDir()
 			dbFile := filepath.Join(tempDir, "my_db")
 
-			client, err := newClient(zap.NewNop(), dbFile, timeout, &CompactionConfig{}, false)
+			client, err := newClient(zap.NewNop(), dbFile, timeout, &CompactionConfig{}, false, 0, nil)
 			require.NoError(t, err)
 			t.Cleanup(func() {
 				require.NoError(t, client.Close(context.TODO()))
@@ -204,13 +205,128 @@ func TestNewClientErrorsOnInvalidBucket(t *testing.T) {
 	tempDir := t.TempDir()
 	dbFile := filepath.Join(tempDir, "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false)
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, 0, nil)
 	require.Error(t, err)
 	require.Nil(t, client)
 
 	defaultBucket = temp
 }
 
+func TestClientMaxSize(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, 1, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(context.TODO()))
+	})
+
+	ctx := context.Background()
+	require.NoError(t, client.Set(ctx, "small", make([]byte, 1000)))
+
+	err = client.Set(ctx, "large", make([]byte, oneMiB))
+	require.ErrorIs(t, err, ErrStorageFull)
+	value, err := client.Get(ctx, "large")
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	// deleting is always allowed, so space can be freed
+	require.NoError(t, client.Batch(ctx, storage.GetOperation("small"), storage.DeleteOperation("small")))
+	require.NoError(t, client.Set(ctx, "medium", make([]byte, oneMiB/2)))
+}
+
+func TestClientWritesDuringCompactionArePreserved(t *testing.T) {
+	tempDir := t.TempDir()
+	dbFile := filepath.Join(tempDir, "my_db")
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, 0, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(context.TODO()))
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, client.Set(ctx, fmt.Sprintf("initial-%d", i), make([]byte, 1000)))
+	}
+
+	done := make(chan struct{})
+	written := 0
+	go func() {
+		defer close(done)
+		for ; written < 500; written++ {
+			assert.NoError(t, client.Batch(ctx,
+				storage.SetOperation(fmt.Sprintf("written-%d", written), []byte("value")),
+				storage.DeleteOperation(fmt.Sprintf("initial-%d", written)),
+			))
+		}
+	}()
+	for i := 0; i < 5; i++ {
+		require.NoError(t, client.Compact(tempDir, time.Second, 100))
+	}
+	<-done
+
+	for i := 0; i < written; i++ {
+		value, err := client.Get(ctx, fmt.Sprintf("written-%d", i))
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), value)
+
+		value, err = client.Get(ctx, fmt.Sprintf("initial-%d", i))
+		require.NoError(t, err)
+		require.Nil(t, value)
+	}
+}
+
+func TestClientFailedBatchDuringCompactionIsNotReplayed(t *testing.T) {
+	tempDir := t.TempDir()
+	dbFile := filepath.Join(tempDir, "my_db")
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, 0, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(context.TODO()))
+	})
+
+	ctx := context.Background()
+	require.NoError(t, client.Set(ctx, "kept", []byte("value")))
+
+	// record the writes as Compact does while copying the database
+	client.compactionMutex.Lock()
+	client.compactionJournal = make(map[string]journalEntry)
+	client.compactionMutex.Unlock()
+
+	// the empty key makes the transaction fail after the first operations were applied
+	err = client.Batch(ctx,
+		storage.SetOperation("rolled-back", []byte("value")),
+		storage.DeleteOperation("kept"),
+		storage.SetOperation("", []byte("value")),
+	)
+	require.ErrorIs(t, err, bbolt.ErrKeyRequired)
+	require.NoError(t, client.Set(ctx, "committed", []byte("value")))
+
+	client.compactionMutex.Lock()
+	journal := client.compactionJournal
+	client.compactionJournal = nil
+	client.compactionMutex.Unlock()
+	assert.Equal(t, map[string]journalEntry{"committed": {value: []byte("value")}}, journal)
+
+	compactedDb, err := bbolt.Open(filepath.Join(tempDir, "compacted"), 0600, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, compactedDb.Close())
+	})
+	require.NoError(t, bbolt.Compact(compactedDb, client.db, 0))
+	require.NoError(t, replayJournal(compactedDb, journal))
+
+	require.NoError(t, compactedDb.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(defaultBucket)
+		assert.Equal(t, []byte("value"), bucket.Get([]byte("kept")))
+		assert.Equal(t, []byte("value"), bucket.Get([]byte("committed")))
+		assert.Nil(t, bucket.Get([]byte("rolled-back")))
+		return nil
+	}))
+}
+
 func TestClientReboundCompaction(t *testing.T) {
 	testCases := []struct {
 		testName                   string
@@ -259,7 +375,7 @@ func TestClientReboundCompaction(t *testing.T) {
 				CheckInterval:              checkInterval,
 				ReboundNeededThresholdMiB:  testCase.reboundNeededThresholdMiB,
 				ReboundTriggerThresholdMiB: testCase.reboundTriggerThresholdMiB,
-			}, false)
+			}, false, 0, nil)
 			require.NoError(t, err)
 			t.Cleanup(func() {
 				require.NoError(t, client.Close(context.TODO()))
@@ -348,7 +464,7 @@ func TestClientConcurrentCompaction(t *testing.T) {
 		CheckInterval:              stepInterval * 2,
 		ReboundNeededThresholdMiB:  1,
 		ReboundTriggerThresholdMiB: 5,
-	}, false)
+	}, false, 0, nil)
 	require.NoError(t, err)
 
 	t.Cleanup(func() {
@@ -408,7 +524,7 @@ func BenchmarkClientGet(b *testing.B) {
 	tempDir := b.TempDir()
 	dbFile := filepath.Join(tempDir, "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false)
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, 0, nil)
 	require.NoError(b, err)
 	b.Cleanup(func() {
 		require.NoError(b, client.Close(context.TODO()))
@@ -428,7 +544,7 @@ func BenchmarkClientGet100(b *testing.B) {
 	tempDir := b.TempDir()
 	dbFile := filepath.Join(tempDir, "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false)
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, 0, nil)
 	require.NoError(b, err)
 	b.Cleanup(func() {
 		require.NoError(b, client.Close(context.TODO()))
@@ -451,7 +567,7 @@ func BenchmarkClientSet(b *testing.B) {
 	tempDir := b.TempDir()
 	dbFile := filepath.Join(tempDir, "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false)
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, 0, nil)
 	require.NoError(b, err)
 	b.Cleanup(func() {
 		require.NoError(b, client.Close(context.TODO()))
@@ -471,7 +587,7 @@ func BenchmarkClientSet100(b *testing.B) {
 	tempDir := b.TempDir()
 	dbFile := filepath.Join(tempDir, "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false)
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, 0, nil)
 	require.NoError(b, err)
 	b.Cleanup(func() {
 		require.NoError(b, client.Close(context.TODO()))
@@ -493,7 +609,7 @@ func BenchmarkClientDelete(b *testing.B) {
 	tempDir := b.TempDir()
 	dbFile := filepath.Join(tempDir, "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false)
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, 0, nil)
 	require.NoError(b, err)
 	b.Cleanup(func() {
 		require.NoError(b, client.Close(context.TODO()))
@@ -519,7 +635,7 @@ func BenchmarkClientSetLargeDB(b *testing.B) {
 	tempDir := b.TempDir()
 	dbFile := filepath.Join(tempDir, "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false)
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, 0, nil)
 	require.NoError(b, err)
 	b.Cleanup(func() {
 		require.NoError(b, client.Close(context.TODO()))
@@ -556,7 +672,7 @@ func BenchmarkClientInitLargeDB(b *testing.B) {
 	tempDir := b.TempDir()
 	dbFile := filepath.Join(tempDir, "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false)
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, 0, nil)
 	require.NoError(b, err)
 	b.Cleanup(func() {
 		require.NoError(b, client.Close(context.TODO()))
@@ -575,7 +691,7 @@ func BenchmarkClientInitLargeDB(b *testing.B) {
 	var tempClient *fileStorageClient
 	b.ResetTimer()
 	for n := 0; n < b.N; n++ {
-		tempClient, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false)
+		tempClient, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, 0, nil)
 		require.NoError(b, err)
 		b.StopTimer()
 		err = tempClient.Close(ctx)
@@ -593,7 +709,7 @@ func BenchmarkClientCompactLargeDBFile(b *testing.B) {
 	tempDir := b.TempDir()
 	dbFile := filepath.Join(tempDir, "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false)
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, 0, nil)
 	require.NoError(b, err)
 	b.Cleanup(func() {
 		require.NoError(b, client.Close(context.TODO()))
@@ -620,7 +736,7 @@ func BenchmarkClientCompactLargeDBFile(b *testing.B) {
 		testDbFile := filepath.Join(tempDir, fmt.Sprintf("my_db%d", n))
 		err = os.Link(dbFile, testDbFile)
 		require.NoError(b, err)
-		client, err = newClient(zap.NewNop(), testDbFile, time.Second, &CompactionConfig{}, false)
+		client, err = newClient(zap.NewNop(), testDbFile, time.Second, &CompactionConfig{}, false, 0, nil)
 		require.NoError(b, err)
 		b.StartTimer()
 		require.NoError(b, client.Compact(tempDir, time.Second, 65536))
@@ -637,7 +753,7 @@ func BenchmarkClientCompactDb(b *testing.B) {
 	tempDir := b.TempDir()
 	dbFile := filepath.Join(tempDir, "my_db")
 
-	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false)
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, 0, nil)
 	require.NoError(b, err)
 	b.Cleanup(func() {
 		require.NoError(b, client.Close(context.TODO()))
@@ -664,7 +780,7 @@ func BenchmarkClientCompactDb(b *testing.B) {
 		testDbFile := filepath.Join(tempDir, fmt.Sprintf("my_db%d", n))
 		err = os.Link(dbFile, testDbFile)
 		require.NoError(b, err)
-		client, err = newClient(zap.NewNop(), testDbFile, time.Second, &CompactionConfig{}, false)
+		client, err = newClient(zap.NewNop(), testDbFile, time.Second, &CompactionConfig{}, false, 0, nil)
 		require.NoError(b, err)
 		b.StartTimer()
 		require.NoError(b, client.Compact(tempDir, time.Second, 65536))
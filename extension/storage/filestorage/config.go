@@ -20,6 +20,10 @@ type Config struct {
 
 	// FSync specifies that fsync should be called after each database write
 	FSync bool `mapstructure:"fsync,omitempty"`
+
+	// MaxSizeMiB is the maximum size of the data stored by each client. Once reached, writes fail with
+	// ErrStorageFull until data is deleted. Zero means no limit.
+	MaxSizeMiB int64 `mapstructure:"max_size_mib,omitempty"`
 }
 
 // CompactionConfig defines configuration for optional file storage compaction.
@@ -79,6 +83,10 @@ func (cfg *Config) Validate() error {
 		return errors.New("max transaction size for compaction cannot be less than 0")
 	}
 
+	if cfg.MaxSizeMiB < 0 {
+		return errors.New("max size cannot be less than 0")
+	}
+
 	if cfg.Compaction.OnRebound && cfg.Compaction.CheckInterval <= 0 {
 		return errors.New("compaction check interval must be positive when rebound compaction is set")
 	}
@@ -15,20 +15,28 @@ import (
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage/internal/metadata"
 )
 
 type localFileStorage struct {
-	cfg    *Config
-	logger *zap.Logger
+	cfg       *Config
+	logger    *zap.Logger
+	telemetry *metadata.TelemetryBuilder
 }
 
 // Ensure this storage extension implements the appropriate interface
 var _ storage.Extension = (*localFileStorage)(nil)
 
-func newLocalFileStorage(logger *zap.Logger, config *Config) (extension.Extension, error) {
+func newLocalFileStorage(set component.TelemetrySettings, config *Config) (extension.Extension, error) {
+	telemetry, err := metadata.NewTelemetryBuilder(set)
+	if err != nil {
+		return nil, err
+	}
 	return &localFileStorage{
-		cfg:    config,
-		logger: logger,
+		cfg:       config,
+		logger:    set.Logger,
+		telemetry: telemetry,
 	}, nil
 }
 
@@ -58,7 +66,7 @@ func (lfs *localFileStorage) GetClient(_ context.Context, kind component.Kind, e
 
 	rawName = sanitize(rawName)
 	absoluteName := filepath.Join(lfs.cfg.Directory, rawName)
-	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, !lfs.cfg.FSync)
+	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, !lfs.cfg.FSync, lfs.cfg.MaxSizeMiB, lfs.telemetry)
 
 	if err != nil {
 		return nil, err
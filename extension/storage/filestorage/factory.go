@@ -57,5 +57,5 @@ func createExtension(
 	params extension.Settings,
 	cfg component.Config,
 ) (extension.Extension, error) {
-	return newLocalFileStorage(params.Logger, cfg.(*Config))
+	return newLocalFileStorage(params.TelemetrySettings, cfg.(*Config))
 }
@@ -3,9 +3,14 @@
 package metadata
 
 import (
-	"go.opentelemetry.io/collector/component"
+	"errors"
+
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
 )
 
 func Meter(settings component.TelemetrySettings) metric.Meter {
@@ -15,3 +20,57 @@ func Meter(settings component.TelemetrySettings) metric.Meter {
 func Tracer(settings component.TelemetrySettings) trace.Tracer {
 	return settings.TracerProvider.Tracer("otelcol/filestorage")
 }
+
+// TelemetryBuilder provides an interface for components to report telemetry
+// as defined in metadata and user config.
+type TelemetryBuilder struct {
+	meter                              metric.Meter
+	FilestorageCompactionDuration      metric.Float64Histogram
+	FilestorageCompactionReclaimedSize metric.Int64Counter
+	FilestorageDbSize                  metric.Int64Gauge
+	level                              configtelemetry.Level
+}
+
+// telemetryBuilderOption applies changes to default builder.
+type telemetryBuilderOption func(*TelemetryBuilder)
+
+// WithLevel sets the current telemetry level for the component.
+func WithLevel(lvl configtelemetry.Level) telemetryBuilderOption {
+	return func(builder *TelemetryBuilder) {
+		builder.level = lvl
+	}
+}
+
+// NewTelemetryBuilder provides a struct with methods to update all internal telemetry
+// for a component
+func NewTelemetryBuilder(settings component.TelemetrySettings, options ...telemetryBuilderOption) (*TelemetryBuilder, error) {
+	builder := TelemetryBuilder{level: configtelemetry.LevelBasic}
+	for _, op := range options {
+		op(&builder)
+	}
+	var err, errs error
+	if builder.level >= configtelemetry.LevelBasic {
+		builder.meter = Meter(settings)
+	} else {
+		builder.meter = noop.Meter{}
+	}
+	builder.FilestorageCompactionDuration, err = builder.meter.Float64Histogram(
+		"filestorage_compaction_duration",
+		metric.WithDescription("Duration of the database compactions"),
+		metric.WithUnit("s"),
+	)
+	errs = errors.Join(errs, err)
+	builder.FilestorageCompactionReclaimedSize, err = builder.meter.Int64Counter(
+		"filestorage_compaction_reclaimed_size",
+		metric.WithDescription("Disk space reclaimed by the database compactions"),
+		metric.WithUnit("By"),
+	)
+	errs = errors.Join(errs, err)
+	builder.FilestorageDbSize, err = builder.meter.Int64Gauge(
+		"filestorage_db_size",
+		metric.WithDescription("Size of the database file, including the space claimed but no longer used"),
+		metric.WithUnit("By"),
+	)
+	errs = errors.Join(errs, err)
+	return &builder, errs
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlscope"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanlink"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
 )
 
@@ -28,6 +29,10 @@ func StandardSpanEventFuncs() map[string]ottl.Factory[ottlspanevent.TransformCon
 	return ottlfuncs.StandardConverters[ottlspanevent.TransformContext]()
 }
 
+func StandardSpanLinkFuncs() map[string]ottl.Factory[ottlspanlink.TransformContext] {
+	return ottlfuncs.StandardConverters[ottlspanlink.TransformContext]()
+}
+
 func StandardMetricFuncs() map[string]ottl.Factory[ottlmetric.TransformContext] {
 	m := ottlfuncs.StandardConverters[ottlmetric.TransformContext]()
 	hasAttributeOnDatapointFactory := newHasAttributeOnDatapointFactory()
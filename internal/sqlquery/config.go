@@ -6,6 +6,7 @@ package sqlquery // import "github.com/open-telemetry/opentelemetry-collector-co
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
@@ -44,6 +45,35 @@ type Query struct {
 	Logs               []LogsCfg   `mapstructure:"logs"`
 	TrackingColumn     string      `mapstructure:"tracking_column"`
 	TrackingStartValue string      `mapstructure:"tracking_start_value"`
+	// TrackingColumns is used instead of TrackingColumn to track a composite key, e.g. a timestamp and an ID.
+	// The tracked values are passed to the query as parameters, in the same order.
+	TrackingColumns     []string `mapstructure:"tracking_columns"`
+	TrackingStartValues []string `mapstructure:"tracking_start_values"`
+	// TrackingRetention is how long the tracking values kept in the storage remain valid. Older values
+	// are discarded on start, and the start values are used instead. Zero means they never expire.
+	TrackingRetention time.Duration `mapstructure:"tracking_retention"`
+	// CollectionInterval overrides the collection interval of the receiver for this query.
+	CollectionInterval time.Duration `mapstructure:"collection_interval"`
+}
+
+// TrackingColumnNames returns the columns tracked by the query, whether configured with
+// tracking_column or tracking_columns.
+func (q Query) TrackingColumnNames() []string {
+	if q.TrackingColumn != "" {
+		return []string{q.TrackingColumn}
+	}
+	return q.TrackingColumns
+}
+
+// TrackingStartValueList returns the start values of the tracked columns, in the same order as TrackingColumnNames.
+func (q Query) TrackingStartValueList() []string {
+	if q.TrackingColumn != "" {
+		return []string{q.TrackingStartValue}
+	}
+	if len(q.TrackingStartValues) == 0 {
+		return make([]string, len(q.TrackingColumns))
+	}
+	return q.TrackingStartValues
 }
 
 func (q Query) Validate() error {
@@ -54,6 +84,21 @@ func (q Query) Validate() error {
 	if len(q.Logs) == 0 && len(q.Metrics) == 0 {
 		errs = append(errs, errors.New("at least one of 'query.logs' and 'query.metrics' must not be empty"))
 	}
+	if q.TrackingColumn != "" && len(q.TrackingColumns) > 0 {
+		errs = append(errs, errors.New("'tracking_column' and 'tracking_columns' cannot be both set"))
+	}
+	if q.TrackingStartValue != "" && q.TrackingColumn == "" {
+		errs = append(errs, errors.New("'tracking_start_value' requires 'tracking_column' to be set"))
+	}
+	if len(q.TrackingStartValues) > 0 && len(q.TrackingStartValues) != len(q.TrackingColumns) {
+		errs = append(errs, fmt.Errorf("'tracking_start_values' must have one value per tracking column, got %d values for %d columns", len(q.TrackingStartValues), len(q.TrackingColumns)))
+	}
+	if q.TrackingRetention < 0 {
+		errs = append(errs, errors.New("'tracking_retention' cannot be negative"))
+	}
+	if q.CollectionInterval < 0 {
+		errs = append(errs, errors.New("'collection_interval' cannot be negative"))
+	}
 	for _, logs := range q.Logs {
 		if err := logs.Validate(); err != nil {
 			errs = append(errs, err)
@@ -80,6 +125,8 @@ func (config LogsCfg) Validate() error {
 }
 
 type MetricCfg struct {
+	// MetricName and Description may hold `{{column}}` placeholders, replaced with the values of
+	// the columns of each row, so that a single query can produce differently named metrics.
 	MetricName       string            `mapstructure:"metric_name"`
 	ValueColumn      string            `mapstructure:"value_column"`
 	AttributeColumns []string          `mapstructure:"attribute_columns"`
@@ -8,6 +8,7 @@ import (
 	"errors"
 
 	// register Db drivers
+	_ "github.com/ClickHouse/clickhouse-go/v2"
 	_ "github.com/SAP/go-hdb/driver"
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/lib/pq"
@@ -16,6 +17,7 @@ import (
 	_ "github.com/sijms/go-ora/v2"
 	_ "github.com/snowflakedb/gosnowflake"
 	"go.uber.org/zap"
+	_ "modernc.org/sqlite"
 )
 
 type StringMap map[string]string
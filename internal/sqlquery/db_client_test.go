@@ -5,6 +5,7 @@ package sqlquery // import "github.com/open-telemetry/opentelemetry-collector-co
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"testing"
@@ -32,6 +33,24 @@ func TestDBSQLClient_SingleRow(t *testing.T) {
 	}, rows[0])
 }
 
+func TestDBSQLClient_SQLite(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec("create table movie (genre text, title text)")
+	require.NoError(t, err)
+	_, err = db.Exec("insert into movie values ('sf', 'Alien'), ('sf', 'Dune'), ('drama', 'Amour')")
+	require.NoError(t, err)
+
+	cl := NewDbClient(DbWrapper{db}, "select genre, count(*) as count from movie where title > ? group by genre order by genre", zap.NewNop(), TelemetryConfig{})
+	rows, err := cl.QueryRows(context.Background(), "A")
+	require.NoError(t, err)
+	assert.Equal(t, []StringMap{
+		{"genre": "drama", "count": "1"},
+		{"genre": "sf", "count": "2"},
+	}, rows)
+}
+
 func TestDBSQLClient_MultiRow(t *testing.T) {
 	cl := DbSQLClient{
 		Db: fakeDB{rowVals: [][]any{
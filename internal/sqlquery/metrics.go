@@ -5,6 +5,7 @@ package sqlquery // import "github.com/open-telemetry/opentelemetry-collector-co
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -12,9 +13,37 @@ import (
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
 
+// columnPlaceholder matches the `{{column}}` placeholders of the metric names and descriptions.
+var columnPlaceholder = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+
+// expandColumns replaces the `{{column}}` placeholders of the template with the values of the columns of the row.
+func expandColumns(template string, row StringMap) (string, error) {
+	var err error
+	out := columnPlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		column := columnPlaceholder.FindStringSubmatch(placeholder)[1]
+		val, found := row[column]
+		if !found && err == nil {
+			err = fmt.Errorf("column '%s' not found in result set", column)
+		}
+		return val
+	})
+	return out, err
+}
+
 func rowToMetric(row StringMap, cfg MetricCfg, dest pmetric.Metric, startTime pcommon.Timestamp, ts pcommon.Timestamp, scrapeCfg scraperhelper.ControllerConfig) error {
-	dest.SetName(cfg.MetricName)
-	dest.SetDescription(cfg.Description)
+	name, err := expandColumns(cfg.MetricName, row)
+	if err != nil {
+		return fmt.Errorf("rowToMetric: metric_name: %w", err)
+	}
+	if name == "" {
+		return fmt.Errorf("rowToMetric: metric_name '%s' expanded to an empty name", cfg.MetricName)
+	}
+	description, err := expandColumns(cfg.Description, row)
+	if err != nil {
+		return fmt.Errorf("rowToMetric: description: %w", err)
+	}
+	dest.SetName(name)
+	dest.SetDescription(description)
 	dest.SetUnit(cfg.Unit)
 	dataPointSlice := setMetricFields(cfg, dest)
 	dataPoint := dataPointSlice.AppendEmpty()
@@ -45,7 +74,7 @@ func rowToMetric(row StringMap, cfg MetricCfg, dest pmetric.Metric, startTime pc
 	if !found {
 		return fmt.Errorf("rowToMetric: value_column '%s' not found in result set", cfg.ValueColumn)
 	}
-	err := setDataPointValue(cfg, value, dataPoint)
+	err = setDataPointValue(cfg, value, dataPoint)
 	if err != nil {
 		return fmt.Errorf("rowToMetric: %w", err)
 	}
@@ -17,3 +17,27 @@ func TestSetDataPointValue(t *testing.T) {
 	}, "", pmetric.NewNumberDataPoint())
 	assert.EqualError(t, err, `setDataPointValue: col "some-col": error converting to integer: strconv.Atoi: parsing "": invalid syntax`)
 }
+
+func TestExpandColumns(t *testing.T) {
+	row := StringMap{"name": "connections", "db": "orders"}
+	for _, tt := range []struct {
+		template string
+		expected string
+		err      string
+	}{
+		{template: "static.name", expected: "static.name"},
+		{template: "db.{{name}}", expected: "db.connections"},
+		{template: "{{ db }}.{{ name }}", expected: "orders.connections"},
+		{template: "{{missing}}", err: "column 'missing' not found in result set"},
+	} {
+		t.Run(tt.template, func(t *testing.T) {
+			out, err := expandColumns(tt.template, row)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, out)
+		})
+	}
+}
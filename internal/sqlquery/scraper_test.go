@@ -331,6 +331,57 @@ func TestScraper_DescriptionAndUnit(t *testing.T) {
 	assert.Equal(t, "my description", z.Description())
 }
 
+func TestScraper_TemplatedNameAndDescription(t *testing.T) {
+	client := &FakeDBClient{
+		StringMaps: [][]StringMap{
+			{
+				{"name": "connections", "value": "12", "db": "orders"},
+				{"name": "locks", "value": "3", "db": "orders"},
+			},
+		},
+	}
+	scrpr := Scraper{
+		Client: client,
+		Query: Query{
+			Metrics: []MetricCfg{{
+				MetricName:       "db.{{ name }}",
+				ValueColumn:      "value",
+				Description:      "{{name}} of the {{db}} database",
+				AttributeColumns: []string{"db"},
+			}},
+		},
+	}
+	metrics, err := scrpr.Scrape(context.Background())
+	require.NoError(t, err)
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, ms.Len())
+	assert.Equal(t, "db.connections", ms.At(0).Name())
+	assert.Equal(t, "connections of the orders database", ms.At(0).Description())
+	assert.EqualValues(t, 12, ms.At(0).Gauge().DataPoints().At(0).IntValue())
+	assert.Equal(t, "db.locks", ms.At(1).Name())
+	assert.Equal(t, "locks of the orders database", ms.At(1).Description())
+	assert.EqualValues(t, 3, ms.At(1).Gauge().DataPoints().At(0).IntValue())
+}
+
+func TestScraper_TemplatedName_ErrorOnColumnNotFound(t *testing.T) {
+	client := &FakeDBClient{
+		StringMaps: [][]StringMap{
+			{{"value": "12"}},
+		},
+	}
+	scrpr := Scraper{
+		Client: client,
+		Query: Query{
+			Metrics: []MetricCfg{{
+				MetricName:  "db.{{name}}",
+				ValueColumn: "value",
+			}},
+		},
+	}
+	_, err := scrpr.Scrape(context.Background())
+	assert.ErrorContains(t, err, "rowToMetric: metric_name: column 'name' not found in result set")
+}
+
 func TestScraper_FakeDB_Warnings(t *testing.T) {
 	db := fakeDB{rowVals: [][]any{{42, nil}}}
 	logger := zap.NewNop()
@@ -0,0 +1,343 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlspanlink
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+var (
+	traceID  = [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	traceID2 = [16]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+	spanID   = [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+	spanID2  = [8]byte{8, 7, 6, 5, 4, 3, 2, 1}
+)
+
+func Test_newPathGetSetter(t *testing.T) {
+	refSpanLink, refSpan, refIS, refResource := createTelemetry()
+
+	newAttrs := pcommon.NewMap()
+	newAttrs.PutStr("hello", "world")
+
+	newCache := pcommon.NewMap()
+	newCache.PutStr("temp", "value")
+
+	tests := []struct {
+		name     string
+		path     ottl.Path[TransformContext]
+		orig     any
+		newVal   any
+		modified func(spanLink ptrace.SpanLink, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource, cache pcommon.Map)
+	}{
+		{
+			name: "cache",
+			path: &internal.TestPath[TransformContext]{
+				N: "cache",
+			},
+			orig:   pcommon.NewMap(),
+			newVal: newCache,
+			modified: func(_ ptrace.SpanLink, _ ptrace.Span, _ pcommon.InstrumentationScope, _ pcommon.Resource, cache pcommon.Map) {
+				newCache.CopyTo(cache)
+			},
+		},
+		{
+			name: "cache access",
+			path: &internal.TestPath[TransformContext]{
+				N: "cache",
+				KeySlice: []ottl.Key[TransformContext]{
+					&internal.TestKey[TransformContext]{
+						S: ottltest.Strp("temp"),
+					},
+				},
+			},
+			orig:   nil,
+			newVal: "new value",
+			modified: func(_ ptrace.SpanLink, _ ptrace.Span, _ pcommon.InstrumentationScope, _ pcommon.Resource, cache pcommon.Map) {
+				cache.PutStr("temp", "new value")
+			},
+		},
+		{
+			name: "trace_id",
+			path: &internal.TestPath[TransformContext]{
+				N: "trace_id",
+			},
+			orig:   pcommon.TraceID(traceID),
+			newVal: pcommon.TraceID(traceID2),
+			modified: func(spanLink ptrace.SpanLink, _ ptrace.Span, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				spanLink.SetTraceID(traceID2)
+			},
+		},
+		{
+			name: "trace_id string",
+			path: &internal.TestPath[TransformContext]{
+				N: "trace_id",
+				NextPath: &internal.TestPath[TransformContext]{
+					N: "string",
+				},
+			},
+			orig:   "0102030405060708090a0b0c0d0e0f10",
+			newVal: "100f0e0d0c0b0a090807060504030201",
+			modified: func(spanLink ptrace.SpanLink, _ ptrace.Span, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				spanLink.SetTraceID(traceID2)
+			},
+		},
+		{
+			name: "span_id",
+			path: &internal.TestPath[TransformContext]{
+				N: "span_id",
+			},
+			orig:   pcommon.SpanID(spanID),
+			newVal: pcommon.SpanID(spanID2),
+			modified: func(spanLink ptrace.SpanLink, _ ptrace.Span, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				spanLink.SetSpanID(spanID2)
+			},
+		},
+		{
+			name: "span_id string",
+			path: &internal.TestPath[TransformContext]{
+				N: "span_id",
+				NextPath: &internal.TestPath[TransformContext]{
+					N: "string",
+				},
+			},
+			orig:   "0102030405060708",
+			newVal: "0807060504030201",
+			modified: func(spanLink ptrace.SpanLink, _ ptrace.Span, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				spanLink.SetSpanID(spanID2)
+			},
+		},
+		{
+			name: "trace_state",
+			path: &internal.TestPath[TransformContext]{
+				N: "trace_state",
+			},
+			orig:   "key1=val1,key2=val2",
+			newVal: "key=newVal",
+			modified: func(spanLink ptrace.SpanLink, _ ptrace.Span, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				spanLink.TraceState().FromRaw("key=newVal")
+			},
+		},
+		{
+			name: "trace_state key",
+			path: &internal.TestPath[TransformContext]{
+				N: "trace_state",
+				KeySlice: []ottl.Key[TransformContext]{
+					&internal.TestKey[TransformContext]{
+						S: ottltest.Strp("key1"),
+					},
+				},
+			},
+			orig:   "val1",
+			newVal: "newVal",
+			modified: func(spanLink ptrace.SpanLink, _ ptrace.Span, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				spanLink.TraceState().FromRaw("key1=newVal,key2=val2")
+			},
+		},
+		{
+			name: "attributes",
+			path: &internal.TestPath[TransformContext]{
+				N: "attributes",
+			},
+			orig:   refSpanLink.Attributes(),
+			newVal: newAttrs,
+			modified: func(spanLink ptrace.SpanLink, _ ptrace.Span, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				newAttrs.CopyTo(spanLink.Attributes())
+			},
+		},
+		{
+			name: "attributes string",
+			path: &internal.TestPath[TransformContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[TransformContext]{
+					&internal.TestKey[TransformContext]{
+						S: ottltest.Strp("str"),
+					},
+				},
+			},
+			orig:   "val",
+			newVal: "newVal",
+			modified: func(spanLink ptrace.SpanLink, _ ptrace.Span, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				spanLink.Attributes().PutStr("str", "newVal")
+			},
+		},
+		{
+			name: "attributes int",
+			path: &internal.TestPath[TransformContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[TransformContext]{
+					&internal.TestKey[TransformContext]{
+						S: ottltest.Strp("int"),
+					},
+				},
+			},
+			orig:   int64(10),
+			newVal: int64(20),
+			modified: func(spanLink ptrace.SpanLink, _ ptrace.Span, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				spanLink.Attributes().PutInt("int", 20)
+			},
+		},
+		{
+			name: "dropped_attributes_count",
+			path: &internal.TestPath[TransformContext]{
+				N: "dropped_attributes_count",
+			},
+			orig:   int64(10),
+			newVal: int64(20),
+			modified: func(spanLink ptrace.SpanLink, _ ptrace.Span, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				spanLink.SetDroppedAttributesCount(20)
+			},
+		},
+		{
+			name: "instrumentation_scope",
+			path: &internal.TestPath[TransformContext]{
+				N: "instrumentation_scope",
+			},
+			orig:   refIS,
+			newVal: pcommon.NewInstrumentationScope(),
+			modified: func(_ ptrace.SpanLink, _ ptrace.Span, il pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				pcommon.NewInstrumentationScope().CopyTo(il)
+			},
+		},
+		{
+			name: "resource",
+			path: &internal.TestPath[TransformContext]{
+				N: "resource",
+			},
+			orig:   refResource,
+			newVal: pcommon.NewResource(),
+			modified: func(_ ptrace.SpanLink, _ ptrace.Span, _ pcommon.InstrumentationScope, resource pcommon.Resource, _ pcommon.Map) {
+				pcommon.NewResource().CopyTo(resource)
+			},
+		},
+		{
+			name: "span",
+			path: &internal.TestPath[TransformContext]{
+				N: "span",
+			},
+			orig:   refSpan,
+			newVal: ptrace.NewSpan(),
+			modified: func(_ ptrace.SpanLink, span ptrace.Span, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				ptrace.NewSpan().CopyTo(span)
+			},
+		},
+		{
+			name: "span name",
+			path: &internal.TestPath[TransformContext]{
+				N: "span",
+				NextPath: &internal.TestPath[TransformContext]{
+					N: "name",
+				},
+			},
+			orig:   "test",
+			newVal: "new name",
+			modified: func(_ ptrace.SpanLink, span ptrace.Span, _ pcommon.InstrumentationScope, _ pcommon.Resource, _ pcommon.Map) {
+				span.SetName("new name")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pep := pathExpressionParser{}
+			accessor, err := pep.parsePath(tt.path)
+			assert.NoError(t, err)
+
+			spanLink, span, il, resource := createTelemetry()
+
+			tCtx := NewTransformContext(spanLink, span, il, resource, ptrace.NewScopeSpans(), ptrace.NewResourceSpans())
+
+			got, err := accessor.Get(context.Background(), tCtx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.orig, got)
+
+			err = accessor.Set(context.Background(), tCtx, tt.newVal)
+			assert.NoError(t, err)
+
+			exSpanLink, exSpan, exIl, exRes := createTelemetry()
+			exCache := pcommon.NewMap()
+			tt.modified(exSpanLink, exSpan, exIl, exRes, exCache)
+
+			assert.Equal(t, exSpanLink, spanLink)
+			assert.Equal(t, exSpan, span)
+			assert.Equal(t, exIl, il)
+			assert.Equal(t, exRes, resource)
+			assert.Equal(t, exCache, tCtx.getCache())
+		})
+	}
+}
+
+func Test_newPathGetSetter_InvalidPath(t *testing.T) {
+	pep := pathExpressionParser{}
+	_, err := pep.parsePath(&internal.TestPath[TransformContext]{
+		N: "name",
+	})
+	assert.Error(t, err)
+
+	_, err = pep.parsePath(&internal.TestPath[TransformContext]{
+		N: "trace_id",
+		NextPath: &internal.TestPath[TransformContext]{
+			N: "bytes",
+		},
+	})
+	assert.Error(t, err)
+}
+
+func Test_NewParser(t *testing.T) {
+	parser, err := NewParser(ottl.CreateFactoryMap[TransformContext](), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	condition, err := parser.ParseCondition(`span_id.string == "0102030405060708" and attributes["str"] == "val"`)
+	require.NoError(t, err)
+
+	spanLink, span, il, resource := createTelemetry()
+	match, err := condition.Eval(context.Background(), NewTransformContext(spanLink, span, il, resource, ptrace.NewScopeSpans(), ptrace.NewResourceSpans()))
+	require.NoError(t, err)
+	assert.True(t, match)
+}
+
+func createTelemetry() (ptrace.SpanLink, ptrace.Span, pcommon.InstrumentationScope, pcommon.Resource) {
+	spanLink := ptrace.NewSpanLink()
+
+	spanLink.SetTraceID(traceID)
+	spanLink.SetSpanID(spanID)
+	spanLink.TraceState().FromRaw("key1=val1,key2=val2")
+	spanLink.SetDroppedAttributesCount(10)
+
+	spanLink.Attributes().PutStr("str", "val")
+	spanLink.Attributes().PutBool("bool", true)
+	spanLink.Attributes().PutInt("int", 10)
+	spanLink.Attributes().PutDouble("double", 1.2)
+
+	span := ptrace.NewSpan()
+	span.SetName("test")
+
+	il := pcommon.NewInstrumentationScope()
+	il.SetName("library")
+	il.SetVersion("version")
+
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("host.name", "localhost")
+
+	return spanLink, span, il, resource
+}
+
+func Test_ParseEnum(t *testing.T) {
+	actual, err := parseEnum((*ottl.EnumSymbol)(ottltest.Strp("SPAN_KIND_SERVER")))
+	assert.NoError(t, err)
+	assert.Equal(t, ottl.Enum(ptrace.SpanKindServer), *actual)
+
+	actual, err = parseEnum((*ottl.EnumSymbol)(ottltest.Strp("not an enum")))
+	assert.Error(t, err)
+	assert.Nil(t, actual)
+}
@@ -852,6 +852,9 @@ func TestLoadingConfigOTTL(t *testing.T) {
 					SpanEventConditions: []string{
 						`attributes["test"] == "pass"`,
 					},
+					SpanLinkConditions: []string{
+						`attributes["test"] == "pass"`,
+					},
 				},
 				Metrics: MetricFilters{
 					MetricConditions: []string{
@@ -898,6 +901,9 @@ func TestLoadingConfigOTTL(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_syntax_spanevent"),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_syntax_spanlink"),
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_syntax_metric"),
 		},
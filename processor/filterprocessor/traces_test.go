@@ -239,6 +239,23 @@ func TestFilterTraceProcessorWithOTTL(t *testing.T) {
 			},
 			errorMode: ottl.IgnoreError,
 		},
+		{
+			name: "drop span links",
+			conditions: TraceFilters{
+				SpanLinkConditions: []string{
+					`dropped_attributes_count == 4`,
+				},
+			},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Links().RemoveIf(func(_ ptrace.SpanLink) bool {
+					return true
+				})
+				td.ResourceSpans().At(0).ScopeSpans().At(1).Spans().At(1).Links().RemoveIf(func(_ ptrace.SpanLink) bool {
+					return true
+				})
+			},
+			errorMode: ottl.IgnoreError,
+		},
 		{
 			name: "multiple conditions",
 			conditions: TraceFilters{
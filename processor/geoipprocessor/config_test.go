@@ -7,6 +7,7 @@ import (
 	"errors"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -16,6 +17,8 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/cidrprovider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/csvprovider"
 	maxmind "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
 )
 
@@ -40,6 +43,22 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "csv"),
+			expected: &Config{
+				Providers: map[string]provider.Config{
+					"csv": &csvprovider.Config{DatabasePath: "/tmp/dbip-city-lite.csv", Format: csvprovider.FormatDBIP, ReloadInterval: time.Minute},
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "cidr"),
+			expected: &Config{
+				Providers: map[string]provider.Config{
+					"cidr": &cidrprovider.Config{DatabasePath: "/tmp/networks.csv"},
+				},
+			},
+		},
 		{
 			id:                    component.NewIDWithName(metadata.Type, "invalid_providers_config"),
 			unmarshalErrorMessage: "unexpected sub-config value kind for key:providers value:this should be a map kind:string",
@@ -16,6 +16,8 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/cidrprovider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/csvprovider"
 	maxmind "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
 )
 
@@ -30,7 +32,9 @@ var (
 
 // providerFactories is a map that stores GeoIPProviderFactory instances, keyed by the provider type.
 var providerFactories = map[string]provider.GeoIPProviderFactory{
-	maxmind.TypeStr: &maxmind.Factory{},
+	maxmind.TypeStr:      &maxmind.Factory{},
+	csvprovider.TypeStr:  &csvprovider.Factory{},
+	cidrprovider.TypeStr: &cidrprovider.Factory{},
 }
 
 // NewFactory creates a new processor factory with default configuration,
@@ -66,11 +70,13 @@ func createGeoIPProviders(
 	for key, cfg := range config.Providers {
 		factory := factories[key]
 		if factory == nil {
+			closeGeoIPProviders(ctx, providers)
 			return nil, fmt.Errorf("geoIP provider factory not found for key: %q", key)
 		}
 
 		provider, err := factory.CreateGeoIPProvider(ctx, set, cfg)
 		if err != nil {
+			closeGeoIPProviders(ctx, providers)
 			return nil, fmt.Errorf("failed to create provider for key %q: %w", key, err)
 		}
 
@@ -87,7 +93,8 @@ func createMetricsProcessor(ctx context.Context, set processor.Settings, cfg com
 	if err != nil {
 		return nil, err
 	}
-	return processorhelper.NewMetricsProcessor(ctx, set, cfg, nextConsumer, newGeoIPProcessor(defaultResourceAttributes, providers).processMetrics, processorhelper.WithCapabilities(processorCapabilities))
+	geoProcessor := newGeoIPProcessor(defaultResourceAttributes, providers)
+	return processorhelper.NewMetricsProcessor(ctx, set, cfg, nextConsumer, geoProcessor.processMetrics, processorhelper.WithCapabilities(processorCapabilities), processorhelper.WithShutdown(geoProcessor.shutdown))
 }
 
 func createTracesProcessor(ctx context.Context, set processor.Settings, cfg component.Config, nextConsumer consumer.Traces) (processor.Traces, error) {
@@ -96,7 +103,8 @@ func createTracesProcessor(ctx context.Context, set processor.Settings, cfg comp
 	if err != nil {
 		return nil, err
 	}
-	return processorhelper.NewTracesProcessor(ctx, set, cfg, nextConsumer, newGeoIPProcessor(defaultResourceAttributes, providers).processTraces, processorhelper.WithCapabilities(processorCapabilities))
+	geoProcessor := newGeoIPProcessor(defaultResourceAttributes, providers)
+	return processorhelper.NewTracesProcessor(ctx, set, cfg, nextConsumer, geoProcessor.processTraces, processorhelper.WithCapabilities(processorCapabilities), processorhelper.WithShutdown(geoProcessor.shutdown))
 }
 
 func createLogsProcessor(ctx context.Context, set processor.Settings, cfg component.Config, nextConsumer consumer.Logs) (processor.Logs, error) {
@@ -105,5 +113,6 @@ func createLogsProcessor(ctx context.Context, set processor.Settings, cfg compon
 	if err != nil {
 		return nil, err
 	}
-	return processorhelper.NewLogsProcessor(ctx, set, cfg, nextConsumer, newGeoIPProcessor(defaultResourceAttributes, providers).processLogs, processorhelper.WithCapabilities(processorCapabilities))
+	geoProcessor := newGeoIPProcessor(defaultResourceAttributes, providers)
+	return processorhelper.NewLogsProcessor(ctx, set, cfg, nextConsumer, geoProcessor.processLogs, processorhelper.WithCapabilities(processorCapabilities), processorhelper.WithShutdown(geoProcessor.shutdown))
 }
@@ -37,6 +37,22 @@ func newGeoIPProcessor(resourceAttributes []attribute.Key, providers []provider.
 	}
 }
 
+// shutdown closes all the providers.
+func (g *geoIPProcessor) shutdown(ctx context.Context) error {
+	var errs error
+	for _, provider := range g.providers {
+		errs = errors.Join(errs, provider.Close(ctx))
+	}
+	return errs
+}
+
+// closeGeoIPProviders closes providers that will not be used, e.g. because the creation of another provider failed.
+func closeGeoIPProviders(ctx context.Context, providers []provider.GeoIPProvider) {
+	for _, provider := range providers {
+		_ = provider.Close(ctx)
+	}
+}
+
 // parseIP parses a string to a net.IP type and returns an error if the IP is invalid or unspecified.
 func parseIP(strIP string) (net.IP, error) {
 	ip := net.ParseIP(strIP)
@@ -15,6 +15,7 @@ require (
 	go.opentelemetry.io/collector/processor v0.104.1-0.20240709093154-e7ce1d50fb5e
 	go.opentelemetry.io/otel v1.28.0
 	go.uber.org/goleak v1.3.0
+	go.uber.org/zap v1.27.0
 )
 
 require go.opentelemetry.io/collector/pdata/pprofile v0.104.1-0.20240709093154-e7ce1d50fb5e // indirect
@@ -98,7 +99,6 @@ require (
 	go.opentelemetry.io/otel/trace v1.28.0 // indirect
 	go.opentelemetry.io/proto/otlp v1.3.1 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
-	go.uber.org/zap v1.27.0 // indirect
 	go4.org/netipx v0.0.0-20230824141953-6213f710f925 // indirect
 	golang.org/x/exp v0.0.0-20240506185415-9bf2ced13842 // indirect
 	golang.org/x/net v0.26.0 // indirect
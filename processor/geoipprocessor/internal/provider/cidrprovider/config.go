@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cidrprovider // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/cidrprovider"
+
+import (
+	"errors"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+)
+
+// Config defines configuration for the CIDR table provider.
+type Config struct {
+	// DatabasePath is the local CSV file mapping networks to their geographical metadata.
+	// Its header line names the columns: "network" for the CIDR, and attribute keys for the other columns.
+	DatabasePath string `mapstructure:"database_path"`
+
+	// ReloadInterval is how often the file is checked for changes. A changed file is loaded
+	// without restarting the collector. Zero disables reloading.
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+}
+
+var _ provider.Config = (*Config)(nil)
+
+// Validate implements provider.Config.
+func (c *Config) Validate() error {
+	if c.DatabasePath == "" {
+		return errors.New("a local CIDR table path must be provided")
+	}
+	if c.ReloadInterval < 0 {
+		return errors.New("reload_interval must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cidrprovider // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/cidrprovider"
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/filedb"
+)
+
+// columnNetwork is the header of the column holding the networks in CIDR notation.
+const columnNetwork = "network"
+
+var (
+	errNilIP           = errors.New("IP passed to Location cannot be nil")
+	errNoMetadataFound = errors.New("no geo IP metadata found")
+)
+
+// table indexes networks by prefix length, so that the most specific network containing an IP is found
+// with one lookup per prefix length. IPv4 networks are mapped to IPv6.
+type table struct {
+	// prefixLengths are sorted from the most to the least specific.
+	prefixLengths []int
+	networks      map[int]map[[net.IPv6len]byte]attribute.Set
+}
+
+type cidrProvider struct {
+	databasePath string
+	watcher      *filedb.Watcher
+
+	// mu guards table, which is replaced when the file changes.
+	mu    sync.RWMutex
+	table table
+}
+
+var _ provider.GeoIPProvider = (*cidrProvider)(nil)
+
+func newCIDRProvider(cfg *Config, logger *zap.Logger) (*cidrProvider, error) {
+	p := &cidrProvider{databasePath: cfg.DatabasePath}
+	if err := p.load(); err != nil {
+		return nil, err
+	}
+
+	if cfg.ReloadInterval > 0 {
+		watcher, err := filedb.NewWatcher(cfg.DatabasePath, cfg.ReloadInterval, logger, p.load)
+		if err != nil {
+			return nil, err
+		}
+		p.watcher = watcher
+		p.watcher.Start()
+	}
+	return p, nil
+}
+
+// Location implements provider.GeoIPProvider for CIDR tables. The metadata of the most specific network containing the IP is returned.
+func (p *cidrProvider) Location(_ context.Context, ipAddress net.IP) (attribute.Set, error) {
+	ip := ipAddress.To16()
+	if ip == nil {
+		return attribute.Set{}, errNilIP
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, prefixLength := range p.table.prefixLengths {
+		if attrs, ok := p.table.networks[prefixLength][networkKey(ip, prefixLength)]; ok {
+			return attrs, nil
+		}
+	}
+	return attribute.Set{}, errNoMetadataFound
+}
+
+// Close stops watching the CIDR table file.
+func (p *cidrProvider) Close(context.Context) error {
+	if p.watcher != nil {
+		p.watcher.Stop()
+	}
+	return nil
+}
+
+func (p *cidrProvider) load() error {
+	t, err := loadTable(p.databasePath)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.table = t
+	return nil
+}
+
+func loadTable(path string) (table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return table{}, fmt.Errorf("could not open CIDR table: %w", err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	header, err := reader.Read()
+	if err != nil {
+		return table{}, fmt.Errorf("could not read CIDR table header: %w", err)
+	}
+	networkIdx := -1
+	attrKeys := make([]string, len(header))
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		switch {
+		case column == columnNetwork:
+			networkIdx = i
+		case filedb.IsSupportedAttribute(column):
+			attrKeys[i] = column
+		default:
+			return table{}, fmt.Errorf("unsupported CIDR table column: %q", column)
+		}
+	}
+	if networkIdx < 0 {
+		return table{}, fmt.Errorf("CIDR table header must include a %q column", columnNetwork)
+	}
+
+	t := table{networks: map[int]map[[net.IPv6len]byte]attribute.Set{}}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return table{}, fmt.Errorf("could not read CIDR table: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		_, network, err := net.ParseCIDR(strings.TrimSpace(record[networkIdx]))
+		if err != nil {
+			return table{}, fmt.Errorf("line %d: %w", line, err)
+		}
+		prefixLength, bits := network.Mask.Size()
+		if bits == 8*net.IPv4len {
+			prefixLength += 8 * (net.IPv6len - net.IPv4len)
+		}
+
+		attrs, err := filedb.Attributes(attrKeys, record)
+		if err != nil {
+			return table{}, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		networks, ok := t.networks[prefixLength]
+		if !ok {
+			networks = map[[net.IPv6len]byte]attribute.Set{}
+			t.networks[prefixLength] = networks
+			t.prefixLengths = append(t.prefixLengths, prefixLength)
+		}
+		networks[networkKey(network.IP.To16(), prefixLength)] = attribute.NewSet(attrs...)
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(t.prefixLengths)))
+	return t, nil
+}
+
+// networkKey returns the first address of the network of the given prefix length containing ip, a 16 bytes address.
+func networkKey(ip net.IP, prefixLength int) [net.IPv6len]byte {
+	var key [net.IPv6len]byte
+	copy(key[:], ip.Mask(net.CIDRMask(prefixLength, 8*net.IPv6len)))
+	return key
+}
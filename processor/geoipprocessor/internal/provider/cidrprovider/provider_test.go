@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cidrprovider
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+)
+
+func TestProviderLocation(t *testing.T) {
+	provider, err := newCIDRProvider(&Config{DatabasePath: "testdata/networks.csv"}, zap.NewNop())
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, provider.Close(context.Background()))
+	}()
+
+	tests := []struct {
+		name               string
+		sourceIP           net.IP
+		expectedAttributes attribute.Set
+		expectedErrMsg     string
+	}{
+		{
+			name:           "nil IP address",
+			expectedErrMsg: "IP passed to Location cannot be nil",
+		},
+		{
+			name:           "IP outside of any network",
+			sourceIP:       net.IPv4(192, 168, 0, 1),
+			expectedErrMsg: "no geo IP metadata found",
+		},
+		{
+			name:     "least specific network",
+			sourceIP: net.IPv4(10, 2, 0, 1),
+			expectedAttributes: attribute.NewSet(
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "FR"),
+			),
+		},
+		{
+			name:     "more specific network",
+			sourceIP: net.IPv4(10, 1, 3, 1),
+			expectedAttributes: attribute.NewSet(
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "FR"),
+				attribute.String(conventions.AttributeGeoRegionName, "Ile-de-France"),
+				attribute.String(conventions.AttributeGeoCityName, "Paris"),
+			),
+		},
+		{
+			name:     "most specific network",
+			sourceIP: net.IPv4(10, 1, 2, 200),
+			expectedAttributes: attribute.NewSet(
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "FR"),
+				attribute.String(conventions.AttributeGeoRegionName, "Ile-de-France"),
+				attribute.String(conventions.AttributeGeoCityName, "Versailles"),
+			),
+		},
+		{
+			name:     "IPv6 network",
+			sourceIP: net.ParseIP("fd12:3456::1"),
+			expectedAttributes: attribute.NewSet(
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "DE"),
+				attribute.String(conventions.AttributeGeoRegionName, "Berlin"),
+				attribute.String(conventions.AttributeGeoCityName, "Berlin"),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualAttributes, err := provider.Location(context.Background(), tt.sourceIP)
+			if tt.expectedErrMsg != "" {
+				assert.EqualError(t, err, tt.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, tt.expectedAttributes.Equals(&actualAttributes), "unexpected attributes: %v", actualAttributes.ToSlice())
+		})
+	}
+}
+
+func TestInvalidTable(t *testing.T) {
+	tests := []struct {
+		name           string
+		content        string
+		expectedErrMsg string
+	}{
+		{
+			name:           "missing network column",
+			content:        "geo.city_name\nParis\n",
+			expectedErrMsg: `CIDR table header must include a "network" column`,
+		},
+		{
+			name:           "unsupported column",
+			content:        "network,asn\n10.0.0.0/8,1234\n",
+			expectedErrMsg: `unsupported CIDR table column: "asn"`,
+		},
+		{
+			name:           "invalid network",
+			content:        "network,geo.city_name\n10.0.0.0/8,Paris\n10.0.0.0,Versailles\n",
+			expectedErrMsg: "line 3: invalid CIDR address: 10.0.0.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "networks.csv")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+			_, err := newCIDRProvider(&Config{DatabasePath: path}, zap.NewNop())
+			assert.EqualError(t, err, tt.expectedErrMsg)
+		})
+	}
+
+	_, err := newCIDRProvider(&Config{DatabasePath: "no valid path"}, zap.NewNop())
+	assert.ErrorContains(t, err, "could not open CIDR table")
+}
+
+func TestProviderReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "networks.csv")
+	require.NoError(t, os.WriteFile(path, []byte("network,geo.city_name\n10.0.0.0/8,Paris\n"), 0o600))
+
+	provider, err := newCIDRProvider(&Config{DatabasePath: path, ReloadInterval: 10 * time.Millisecond}, zap.NewNop())
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, provider.Close(context.Background()))
+	}()
+
+	require.NoError(t, os.WriteFile(path, []byte("network,geo.city_name\n10.0.0.0/8,Versailles\n"), 0o600))
+	assert.Eventually(t, func() bool {
+		attrs, err := provider.Location(context.Background(), net.IPv4(10, 0, 0, 1))
+		if err != nil {
+			return false
+		}
+		city, _ := attrs.Value(conventions.AttributeGeoCityName)
+		return city.AsString() == "Versailles"
+	}, 5*time.Second, 10*time.Millisecond)
+}
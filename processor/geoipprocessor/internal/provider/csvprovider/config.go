@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package csvprovider // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/csvprovider"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/filedb"
+)
+
+const (
+	// FormatDBIP is the layout of the DB-IP "IP to City Lite" CSV database.
+	FormatDBIP = "dbip"
+	// FormatIP2Location is the layout of the IP2Location DB11 CSV database (and its LITE edition).
+	FormatIP2Location = "ip2location"
+
+	// ColumnIPStart is the column holding the first address of a range.
+	ColumnIPStart = "ip_start"
+	// ColumnIPEnd is the column holding the last address of a range.
+	ColumnIPEnd = "ip_end"
+)
+
+// formatColumns holds the columns of the supported database formats. Empty column names are ignored.
+var formatColumns = map[string][]string{
+	FormatDBIP: {
+		ColumnIPStart,
+		ColumnIPEnd,
+		conventions.AttributeGeoContinentCode,
+		conventions.AttributeGeoCountryIsoCode,
+		conventions.AttributeGeoRegionName,
+		conventions.AttributeGeoCityName,
+		conventions.AttributeGeoLocationLat,
+		conventions.AttributeGeoLocationLon,
+	},
+	FormatIP2Location: {
+		ColumnIPStart,
+		ColumnIPEnd,
+		conventions.AttributeGeoCountryIsoCode,
+		conventions.AttributeGeoCountryName,
+		conventions.AttributeGeoRegionName,
+		conventions.AttributeGeoCityName,
+		conventions.AttributeGeoLocationLat,
+		conventions.AttributeGeoLocationLon,
+		conventions.AttributeGeoPostalCode,
+		conventions.AttributeGeoTimezone,
+	},
+}
+
+// Config defines configuration for the CSV provider.
+type Config struct {
+	// DatabasePath is the local CSV file listing IP ranges and their geographical metadata.
+	DatabasePath string `mapstructure:"database_path"`
+
+	// Format is the layout of the database, either "dbip" or "ip2location". It is ignored when Columns is set.
+	Format string `mapstructure:"format"`
+
+	// Columns describes a custom layout: the name of each column of the database, in order.
+	// It must contain "ip_start" and "ip_end", the other columns are attribute keys or empty to be ignored.
+	Columns []string `mapstructure:"columns"`
+
+	// ReloadInterval is how often the database file is checked for changes. A changed file is loaded
+	// without restarting the collector. Zero disables reloading.
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+}
+
+var _ provider.Config = (*Config)(nil)
+
+// Validate implements provider.Config.
+func (c *Config) Validate() error {
+	if c.DatabasePath == "" {
+		return errors.New("a local geoIP database path must be provided")
+	}
+	if c.ReloadInterval < 0 {
+		return errors.New("reload_interval must not be negative")
+	}
+	if len(c.Columns) == 0 {
+		if _, ok := formatColumns[c.Format]; !ok {
+			return fmt.Errorf("unsupported database format %q, columns must be provided", c.Format)
+		}
+		return nil
+	}
+
+	var hasStart, hasEnd bool
+	for _, column := range c.Columns {
+		switch {
+		case column == ColumnIPStart:
+			hasStart = true
+		case column == ColumnIPEnd:
+			hasEnd = true
+		case column == "", filedb.IsSupportedAttribute(column):
+		default:
+			return fmt.Errorf("unsupported column: %q", column)
+		}
+	}
+	if !hasStart || !hasEnd {
+		return fmt.Errorf("columns must include %q and %q", ColumnIPStart, ColumnIPEnd)
+	}
+	return nil
+}
+
+func (c *Config) columns() []string {
+	if len(c.Columns) > 0 {
+		return c.Columns
+	}
+	return formatColumns[c.Format]
+}
@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package csvprovider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := &Factory{}
+	cfg := factory.CreateDefaultConfig()
+	assert.Equal(t, &Config{Format: FormatDBIP}, cfg)
+}
+
+func TestCreateProvider(t *testing.T) {
+	factory := &Factory{}
+	cfg := &Config{
+		DatabasePath: "testdata/dbip-city-lite.csv",
+		Format:       FormatDBIP,
+	}
+	provider, err := factory.CreateGeoIPProvider(context.Background(), processortest.NewNopSettings(), cfg)
+	assert.NoError(t, err)
+	assert.NoError(t, provider.Close(context.Background()))
+}
@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package csvprovider // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/csvprovider"
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/filedb"
+)
+
+var (
+	errNilIP           = errors.New("IP passed to Location cannot be nil")
+	errNoMetadataFound = errors.New("no geo IP metadata found")
+)
+
+// ipRange is a range of addresses sharing the same geographical metadata. Addresses are 16 bytes long.
+type ipRange struct {
+	start net.IP
+	end   net.IP
+	attrs attribute.Set
+}
+
+type csvProvider struct {
+	databasePath string
+	columns      []string
+	watcher      *filedb.Watcher
+
+	// mu guards ranges, which are replaced when the database file changes.
+	mu     sync.RWMutex
+	ranges []ipRange
+}
+
+var _ provider.GeoIPProvider = (*csvProvider)(nil)
+
+func newCSVProvider(cfg *Config, logger *zap.Logger) (*csvProvider, error) {
+	p := &csvProvider{
+		databasePath: cfg.DatabasePath,
+		columns:      cfg.columns(),
+	}
+	if err := p.load(); err != nil {
+		return nil, err
+	}
+
+	if cfg.ReloadInterval > 0 {
+		watcher, err := filedb.NewWatcher(cfg.DatabasePath, cfg.ReloadInterval, logger, p.load)
+		if err != nil {
+			return nil, err
+		}
+		p.watcher = watcher
+		p.watcher.Start()
+	}
+	return p, nil
+}
+
+// Location implements provider.GeoIPProvider for CSV databases. An error is returned if the IP is not part of any range.
+func (p *csvProvider) Location(_ context.Context, ipAddress net.IP) (attribute.Set, error) {
+	ip := ipAddress.To16()
+	if ip == nil {
+		return attribute.Set{}, errNilIP
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	// ranges are sorted by their first address and are not expected to overlap:
+	// the candidate is the last range starting at or before the IP.
+	i := sort.Search(len(p.ranges), func(i int) bool {
+		return bytes.Compare(p.ranges[i].start, ip) > 0
+	}) - 1
+	if i < 0 || bytes.Compare(ip, p.ranges[i].end) > 0 || p.ranges[i].attrs.Len() == 0 {
+		return attribute.Set{}, errNoMetadataFound
+	}
+	return p.ranges[i].attrs, nil
+}
+
+// Close stops watching the database file.
+func (p *csvProvider) Close(context.Context) error {
+	if p.watcher != nil {
+		p.watcher.Stop()
+	}
+	return nil
+}
+
+func (p *csvProvider) load() error {
+	ranges, err := loadRanges(p.databasePath, p.columns)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ranges = ranges
+	return nil
+}
+
+// loadRanges reads the ranges of a CSV database, sorted by their first address. A header line is skipped.
+func loadRanges(path string, columns []string) ([]ipRange, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open geoip database: %w", err)
+	}
+	defer f.Close()
+
+	startIdx, endIdx := -1, -1
+	attrKeys := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case ColumnIPStart:
+			startIdx = i
+		case ColumnIPEnd:
+			endIdx = i
+		default:
+			attrKeys[i] = column
+		}
+	}
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var ranges []ipRange
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read geoip database: %w", err)
+		}
+		if len(record) <= startIdx || len(record) <= endIdx {
+			return nil, fmt.Errorf("line %d: expected at least %d columns, got %d", line, max(startIdx, endIdx)+1, len(record))
+		}
+
+		start, err := filedb.ParseIP(record[startIdx])
+		if err != nil {
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		end, err := filedb.ParseIP(record[endIdx])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if bytes.Compare(start, end) > 0 {
+			return nil, fmt.Errorf("line %d: range start %s is after range end %s", line, start, end)
+		}
+
+		attrs, err := filedb.Attributes(attrKeys, record)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		ranges = append(ranges, ipRange{start: start, end: end, attrs: attribute.NewSet(attrs...)})
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return bytes.Compare(ranges[i].start, ranges[j].start) < 0
+	})
+	return ranges, nil
+}
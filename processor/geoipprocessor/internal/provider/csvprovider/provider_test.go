@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package csvprovider
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+)
+
+func TestProviderLocation(t *testing.T) {
+	tests := []struct {
+		name               string
+		config             Config
+		sourceIP           net.IP
+		expectedAttributes attribute.Set
+		expectedErrMsg     string
+	}{
+		{
+			name:           "nil IP address",
+			config:         Config{DatabasePath: "testdata/dbip-city-lite.csv", Format: FormatDBIP},
+			expectedErrMsg: "IP passed to Location cannot be nil",
+		},
+		{
+			name:           "IP before the first range",
+			config:         Config{DatabasePath: "testdata/dbip-city-lite.csv", Format: FormatDBIP},
+			sourceIP:       net.IPv4(0, 0, 0, 1),
+			expectedErrMsg: "no geo IP metadata found",
+		},
+		{
+			name:           "IP between two ranges",
+			config:         Config{DatabasePath: "testdata/dbip-city-lite.csv", Format: FormatDBIP},
+			sourceIP:       net.IPv4(1, 1, 1, 1),
+			expectedErrMsg: "no geo IP metadata found",
+		},
+		{
+			name:     "IPv4 using the dbip format",
+			config:   Config{DatabasePath: "testdata/dbip-city-lite.csv", Format: FormatDBIP},
+			sourceIP: net.IPv4(1, 2, 3, 4),
+			expectedAttributes: attribute.NewSet(
+				attribute.String(conventions.AttributeGeoContinentCode, "EU"),
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "GB"),
+				attribute.String(conventions.AttributeGeoRegionName, "England"),
+				attribute.String(conventions.AttributeGeoCityName, "Boxford"),
+				attribute.Float64(conventions.AttributeGeoLocationLat, 51.75),
+				attribute.Float64(conventions.AttributeGeoLocationLon, -1.25),
+			),
+		},
+		{
+			name:     "IPv6 using the dbip format, without location",
+			config:   Config{DatabasePath: "testdata/dbip-city-lite.csv", Format: FormatDBIP},
+			sourceIP: net.ParseIP("2001:220::1"),
+			expectedAttributes: attribute.NewSet(
+				attribute.String(conventions.AttributeGeoContinentCode, "AS"),
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "KR"),
+				attribute.String(conventions.AttributeGeoRegionName, "Seoul"),
+				attribute.String(conventions.AttributeGeoCityName, "Seoul"),
+			),
+		},
+		{
+			name:     "decimal ranges using the ip2location format",
+			config:   Config{DatabasePath: "testdata/ip2location-db11.csv", Format: FormatIP2Location},
+			sourceIP: net.IPv4(1, 2, 3, 255),
+			expectedAttributes: attribute.NewSet(
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "GB"),
+				attribute.String(conventions.AttributeGeoCountryName, "United Kingdom of Great Britain and Northern Ireland"),
+				attribute.String(conventions.AttributeGeoRegionName, "England"),
+				attribute.String(conventions.AttributeGeoCityName, "Boxford"),
+				attribute.String(conventions.AttributeGeoTimezone, "+01:00"),
+				attribute.Float64(conventions.AttributeGeoLocationLat, 51.75),
+				attribute.Float64(conventions.AttributeGeoLocationLon, -1.25),
+			),
+		},
+		{
+			name: "custom columns",
+			config: Config{DatabasePath: "testdata/dbip-city-lite.csv", Columns: []string{
+				ColumnIPStart, ColumnIPEnd, "", conventions.AttributeGeoCountryIsoCode,
+			}},
+			sourceIP: net.IPv4(1, 0, 0, 0),
+			expectedAttributes: attribute.NewSet(
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "AU"),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.config.Validate())
+			provider, err := newCSVProvider(&tt.config, zap.NewNop())
+			require.NoError(t, err)
+			defer func() {
+				assert.NoError(t, provider.Close(context.Background()))
+			}()
+
+			actualAttributes, err := provider.Location(context.Background(), tt.sourceIP)
+			if tt.expectedErrMsg != "" {
+				assert.EqualError(t, err, tt.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, tt.expectedAttributes.Equals(&actualAttributes), "unexpected attributes: %v", actualAttributes.ToSlice())
+		})
+	}
+}
+
+func TestInvalidDatabase(t *testing.T) {
+	tests := []struct {
+		name           string
+		content        string
+		expectedErrMsg string
+	}{
+		{
+			name:           "invalid range end",
+			content:        "1.0.0.0,invalid,OC,AU,Queensland,South Brisbane,-27.4767,153.017\n",
+			expectedErrMsg: `line 1: invalid IP address: "invalid"`,
+		},
+		{
+			name:           "reversed range",
+			content:        "ip_start,ip_end\n1.0.0.255,1.0.0.0,OC,AU,Queensland,South Brisbane,-27.4767,153.017\n",
+			expectedErrMsg: "line 2: range start 1.0.0.255 is after range end 1.0.0.0",
+		},
+		{
+			name:           "invalid latitude",
+			content:        "1.0.0.0,1.0.0.255,OC,AU,Queensland,South Brisbane,north,153.017\n",
+			expectedErrMsg: `line 1: invalid geo.location.lat value "north": strconv.ParseFloat: parsing "north": invalid syntax`,
+		},
+		{
+			name:           "missing columns",
+			content:        "1.0.0.0\n",
+			expectedErrMsg: "line 1: expected at least 2 columns, got 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "db.csv")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+			_, err := newCSVProvider(&Config{DatabasePath: path, Format: FormatDBIP}, zap.NewNop())
+			assert.EqualError(t, err, tt.expectedErrMsg)
+		})
+	}
+
+	_, err := newCSVProvider(&Config{DatabasePath: "no valid path", Format: FormatDBIP}, zap.NewNop())
+	assert.ErrorContains(t, err, "could not open geoip database")
+}
+
+func TestProviderReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db.csv")
+	require.NoError(t, os.WriteFile(path, []byte("1.2.3.0,1.2.3.255,EU,GB,England,Boxford,51.75,-1.25\n"), 0o600))
+
+	provider, err := newCSVProvider(&Config{DatabasePath: path, Format: FormatDBIP, ReloadInterval: 10 * time.Millisecond}, zap.NewNop())
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, provider.Close(context.Background()))
+	}()
+
+	_, err = provider.Location(context.Background(), net.IPv4(5, 6, 7, 8))
+	require.EqualError(t, err, "no geo IP metadata found")
+
+	// an invalid database is not loaded, the previous one is kept
+	require.NoError(t, os.WriteFile(path, []byte("5.6.7.0,invalid\n"), 0o600))
+	time.Sleep(50 * time.Millisecond)
+	_, err = provider.Location(context.Background(), net.IPv4(1, 2, 3, 4))
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("5.6.7.0,5.6.7.255,EU,FR,Ile-de-France,Paris,48.85,2.35\n"), 0o600))
+	assert.Eventually(t, func() bool {
+		attrs, err := provider.Location(context.Background(), net.IPv4(5, 6, 7, 8))
+		if err != nil {
+			return false
+		}
+		city, _ := attrs.Value(conventions.AttributeGeoCityName)
+		return city.AsString() == "Paris"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         Config
+		expectedErrMsg string
+	}{
+		{
+			name:           "missing database path",
+			config:         Config{Format: FormatDBIP},
+			expectedErrMsg: "a local geoIP database path must be provided",
+		},
+		{
+			name:           "unknown format",
+			config:         Config{DatabasePath: "db.csv", Format: "unknown"},
+			expectedErrMsg: `unsupported database format "unknown", columns must be provided`,
+		},
+		{
+			name:           "missing range column",
+			config:         Config{DatabasePath: "db.csv", Columns: []string{ColumnIPStart, conventions.AttributeGeoCityName}},
+			expectedErrMsg: `columns must include "ip_start" and "ip_end"`,
+		},
+		{
+			name:           "unsupported column",
+			config:         Config{DatabasePath: "db.csv", Columns: []string{ColumnIPStart, ColumnIPEnd, "asn"}},
+			expectedErrMsg: `unsupported column: "asn"`,
+		},
+		{
+			name:           "negative reload interval",
+			config:         Config{DatabasePath: "db.csv", Format: FormatDBIP, ReloadInterval: -time.Second},
+			expectedErrMsg: "reload_interval must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.config.Validate(), tt.expectedErrMsg)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filedb // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/filedb"
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+)
+
+// emptyValue is used by some databases, e.g. IP2Location, in place of a missing value.
+const emptyValue = "-"
+
+// supportedAttributes lists the attributes that can be read from a database file, and whether they hold a float value.
+var supportedAttributes = map[string]bool{
+	conventions.AttributeGeoCityName:       false,
+	conventions.AttributeGeoPostalCode:     false,
+	conventions.AttributeGeoCountryName:    false,
+	conventions.AttributeGeoCountryIsoCode: false,
+	conventions.AttributeGeoContinentName:  false,
+	conventions.AttributeGeoContinentCode:  false,
+	conventions.AttributeGeoRegionName:     false,
+	conventions.AttributeGeoRegionIsoCode:  false,
+	conventions.AttributeGeoTimezone:       false,
+	conventions.AttributeGeoLocationLat:    true,
+	conventions.AttributeGeoLocationLon:    true,
+}
+
+// IsSupportedAttribute returns whether the given attribute key can be read from a database file.
+func IsSupportedAttribute(key string) bool {
+	_, ok := supportedAttributes[key]
+	return ok
+}
+
+// Attributes converts the values of a database record to attributes, keys[i] being the attribute key for values[i].
+// Empty keys are skipped, as well as empty values. As the MaxMind provider does, a (0, 0) location is not reported.
+func Attributes(keys []string, values []string) ([]attribute.KeyValue, error) {
+	attrs := make([]attribute.KeyValue, 0, len(keys))
+	var lat, lon *float64
+	for i, key := range keys {
+		if key == "" || i >= len(values) {
+			continue
+		}
+		value := strings.TrimSpace(values[i])
+		if value == "" || value == emptyValue {
+			continue
+		}
+
+		isFloat, ok := supportedAttributes[key]
+		if !ok {
+			return nil, fmt.Errorf("unsupported attribute: %s", key)
+		}
+		if !isFloat {
+			attrs = append(attrs, attribute.String(key, value))
+			continue
+		}
+
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %q: %w", key, value, err)
+		}
+		switch key {
+		case conventions.AttributeGeoLocationLat:
+			lat = &f
+		case conventions.AttributeGeoLocationLon:
+			lon = &f
+		}
+	}
+	if lat != nil && lon != nil && (*lat != 0 || *lon != 0) {
+		attrs = append(attrs, attribute.Float64(conventions.AttributeGeoLocationLat, *lat), attribute.Float64(conventions.AttributeGeoLocationLon, *lon))
+	}
+	return attrs, nil
+}
+
+// ParseIP parses an IP address written either in its textual form or as a decimal number, as IP2Location does.
+// The result is always 16 bytes long, IPv4 addresses being mapped to IPv6, so that addresses of both families can be compared.
+func ParseIP(s string) (net.IP, error) {
+	s = strings.TrimSpace(s)
+	if ip := net.ParseIP(s); ip != nil {
+		return ip.To16(), nil
+	}
+
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok || n.Sign() < 0 || n.BitLen() > 128 {
+		return nil, fmt.Errorf("invalid IP address: %q", s)
+	}
+	if n.BitLen() <= 32 {
+		ip := make(net.IP, net.IPv4len)
+		n.FillBytes(ip)
+		return ip.To16(), nil
+	}
+	ip := make(net.IP, net.IPv6len)
+	n.FillBytes(ip)
+	return ip, nil
+}
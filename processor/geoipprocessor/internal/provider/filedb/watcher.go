@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package filedb contains helpers shared by the providers reading their geographical metadata from a local file.
+package filedb // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/filedb"
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Watcher polls a database file and calls a reload function whenever the file is replaced or modified,
+// so a database can be swapped without restarting the collector.
+type Watcher struct {
+	path     string
+	interval time.Duration
+	reload   func() error
+	logger   *zap.Logger
+
+	modTime time.Time
+	size    int64
+
+	started  bool
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+// NewWatcher creates a Watcher for the file at path, checked every interval.
+// The current state of the file is used as the baseline, reload is only called for later changes.
+func NewWatcher(path string, interval time.Duration, logger *zap.Logger, reload func() error) (*Watcher, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not watch database file: %w", err)
+	}
+	return &Watcher{
+		path:     path,
+		interval: interval,
+		reload:   reload,
+		logger:   logger,
+		modTime:  info.ModTime(),
+		size:     info.Size(),
+		stopCh:   make(chan struct{}),
+		doneCh:   make(chan struct{}),
+	}, nil
+}
+
+// Start starts polling the file in the background.
+func (w *Watcher) Start() {
+	w.started = true
+	go func() {
+		defer close(w.doneCh)
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				w.check()
+			case <-w.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops polling the file and waits for any ongoing reload to complete.
+func (w *Watcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopCh)
+		if w.started {
+			<-w.doneCh
+		}
+	})
+}
+
+func (w *Watcher) check() {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		// The file may be in the middle of being replaced, keep the current database and retry later.
+		w.logger.Debug("Could not stat database file", zap.String("path", w.path), zap.Error(err))
+		return
+	}
+	if info.ModTime().Equal(w.modTime) && info.Size() == w.size {
+		return
+	}
+
+	if err = w.reload(); err != nil {
+		w.logger.Warn("Failed to reload database file, keeping the previous database", zap.String("path", w.path), zap.Error(err))
+		return
+	}
+	w.modTime = info.ModTime()
+	w.size = info.Size()
+	w.logger.Info("Reloaded database file", zap.String("path", w.path))
+}
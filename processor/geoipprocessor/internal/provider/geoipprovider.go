@@ -21,6 +21,9 @@ type Config interface {
 type GeoIPProvider interface {
 	// Location returns a set of attributes representing the geographical location for the given IP address. It requires a context for managing request lifetime.
 	Location(context.Context, net.IP) (attribute.Set, error)
+
+	// Close releases the resources held by the provider, e.g. database files or background reloads.
+	Close(context.Context) error
 }
 
 // GeoIPProviderFactory can create GeoIPProvider instances.
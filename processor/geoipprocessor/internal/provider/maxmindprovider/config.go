@@ -5,6 +5,7 @@ package maxmind // import "github.com/open-telemetry/opentelemetry-collector-con
 
 import (
 	"errors"
+	"time"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
 )
@@ -14,6 +15,10 @@ type Config struct {
 	// DatabasePath section allows specifying a local GeoIP database
 	// file to retrieve the geographical metadata from.
 	DatabasePath string `mapstructure:"database_path"`
+
+	// ReloadInterval is how often the database file is checked for changes. A changed file is loaded
+	// without restarting the collector. Zero disables reloading.
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
 }
 
 var _ provider.Config = (*Config)(nil)
@@ -23,5 +28,8 @@ func (c *Config) Validate() error {
 	if c.DatabasePath == "" {
 		return errors.New("a local geoIP database path must be provided")
 	}
+	if c.ReloadInterval < 0 {
+		return errors.New("reload_interval must not be negative")
+	}
 	return nil
 }
@@ -8,12 +8,15 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"sync"
 
 	"github.com/oschwald/geoip2-golang"
 	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
 
 	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/filedb"
 )
 
 var (
@@ -21,32 +24,78 @@ var (
 	defaultLanguageCode = "en"
 	geoIP2CityDBType    = "GeoIP2-City"
 	geoLite2CityDBType  = "GeoLite2-City"
+	// dbIPCityLiteDBType is the DB-IP "IP to City Lite" database, which follows the GeoIP2-City format.
+	dbIPCityLiteDBType = "DBIP-City-Lite"
 
 	errUnsupportedDB   = errors.New("unsupported geo IP database type")
 	errNoMetadataFound = errors.New("no geo IP metadata found")
 )
 
 type maxMindProvider struct {
-	geoReader *geoip2.Reader
+	databasePath string
+	watcher      *filedb.Watcher
 	// language code to be used in name retrieval, e.g. "en" or "pt-BR"
 	langCode string
+
+	// mu guards geoReader, which is replaced when the database file changes.
+	// The previous reader is closed once no lookup uses it anymore.
+	mu        sync.RWMutex
+	geoReader *geoip2.Reader
 }
 
 var _ provider.GeoIPProvider = (*maxMindProvider)(nil)
 
-func newMaxMindProvider(cfg *Config) (*maxMindProvider, error) {
+func newMaxMindProvider(cfg *Config, logger *zap.Logger) (*maxMindProvider, error) {
 	geoReader, err := geoip2.Open(cfg.DatabasePath)
 	if err != nil {
 		return nil, fmt.Errorf("could not open geoip database: %w", err)
 	}
 
-	return &maxMindProvider{geoReader: geoReader, langCode: defaultLanguageCode}, nil
+	g := &maxMindProvider{databasePath: cfg.DatabasePath, geoReader: geoReader, langCode: defaultLanguageCode}
+	if cfg.ReloadInterval > 0 {
+		watcher, err := filedb.NewWatcher(cfg.DatabasePath, cfg.ReloadInterval, logger, g.reload)
+		if err != nil {
+			_ = geoReader.Close()
+			return nil, err
+		}
+		g.watcher = watcher
+		g.watcher.Start()
+	}
+	return g, nil
+}
+
+// reload opens the database file again and swaps it with the current one.
+func (g *maxMindProvider) reload() error {
+	geoReader, err := geoip2.Open(g.databasePath)
+	if err != nil {
+		return fmt.Errorf("could not open geoip database: %w", err)
+	}
+
+	g.mu.Lock()
+	previous := g.geoReader
+	g.geoReader = geoReader
+	g.mu.Unlock()
+	return previous.Close()
+}
+
+// Close stops watching the database file and closes the database.
+func (g *maxMindProvider) Close(context.Context) error {
+	if g.watcher != nil {
+		g.watcher.Stop()
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.geoReader.Close()
 }
 
 // Location implements provider.GeoIPProvider for MaxMind. If a non City database type is used or no metadata is found in the database, an error will be returned.
 func (g *maxMindProvider) Location(_ context.Context, ipAddress net.IP) (attribute.Set, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
 	switch g.geoReader.Metadata().DatabaseType {
-	case geoIP2CityDBType, geoLite2CityDBType:
+	case geoIP2CityDBType, geoLite2CityDBType, dbIPCityLiteDBType:
 		attrs, err := g.cityAttributes(ipAddress)
 		if err != nil {
 			return attribute.Set{}, err
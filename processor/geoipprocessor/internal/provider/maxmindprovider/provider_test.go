@@ -5,28 +5,32 @@ package maxmind
 
 import (
 	"context"
+	"errors"
 	"net"
 	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
 
 	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider/testdata"
 )
 
 func TestInvalidNewProvider(t *testing.T) {
-	_, err := newMaxMindProvider(&Config{})
+	_, err := newMaxMindProvider(&Config{}, zap.NewNop())
 	expectedErrMsgSuffix := "no such file or directory"
 	if runtime.GOOS == "windows" {
 		expectedErrMsgSuffix = "The system cannot find the file specified."
 	}
 	require.ErrorContains(t, err, "could not open geoip database: open : "+expectedErrMsgSuffix)
 
-	_, err = newMaxMindProvider(&Config{DatabasePath: "no valid path"})
+	_, err = newMaxMindProvider(&Config{DatabasePath: "no valid path"}, zap.NewNop())
 	require.ErrorContains(t, err, "could not open geoip database: open no valid path: "+expectedErrMsgSuffix)
 }
 
@@ -98,8 +102,11 @@ func TestProviderLocation(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// prepare provider
-			provider, err := newMaxMindProvider(&Config{DatabasePath: tmpDBfiles + "/" + tt.testDatabase})
+			provider, err := newMaxMindProvider(&Config{DatabasePath: tmpDBfiles + "/" + tt.testDatabase}, zap.NewNop())
 			assert.NoError(t, err)
+			defer func() {
+				assert.NoError(t, provider.Close(context.Background()))
+			}()
 
 			// assert metrics
 			actualAttributes, err := provider.Location(context.Background(), tt.sourceIP)
@@ -112,3 +119,37 @@ func TestProviderLocation(t *testing.T) {
 		})
 	}
 }
+
+// TestProviderReload asserts that a replaced database file is used without recreating the provider.
+func TestProviderReload(t *testing.T) {
+	tmpDBfiles := testdata.GenerateLocalDB(t, "./testdata")
+	defer os.RemoveAll(tmpDBfiles)
+
+	dbPath := filepath.Join(t.TempDir(), "geo.mmdb")
+	copyFile(t, filepath.Join(tmpDBfiles, "GeoLite2-City-Test.mmdb"), dbPath)
+
+	provider, err := newMaxMindProvider(&Config{DatabasePath: dbPath, ReloadInterval: 10 * time.Millisecond}, zap.NewNop())
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, provider.Close(context.Background()))
+	}()
+
+	_, err = provider.Location(context.Background(), net.IPv4(1, 2, 3, 4))
+	require.NoError(t, err)
+
+	// write the new database next to the current one and rename it, as database updaters do
+	replacement := filepath.Join(filepath.Dir(dbPath), "geo.mmdb.tmp")
+	copyFile(t, filepath.Join(tmpDBfiles, "GeoIP2-ISP-Test.mmdb"), replacement)
+	require.NoError(t, os.Rename(replacement, dbPath))
+
+	assert.Eventually(t, func() bool {
+		_, err = provider.Location(context.Background(), net.IPv4(1, 2, 3, 4))
+		return errors.Is(err, errUnsupportedDB)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func copyFile(t *testing.T, src, dst string) {
+	data, err := os.ReadFile(src)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(dst, data, 0o600))
+}
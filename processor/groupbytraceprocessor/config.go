@@ -5,6 +5,8 @@ package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"time"
+
+	"go.opentelemetry.io/collector/component"
 )
 
 // Config is the configuration for the processor.
@@ -32,5 +34,11 @@ type Config struct {
 	// Useful when the duration to wait for traces to complete is high.
 	// Default: false.
 	// Not yet implemented, and an error will be returned when this option is used.
+	// Use StorageID instead.
 	StoreOnDisk bool `mapstructure:"store_on_disk"`
+
+	// StorageID is the ID of a storage extension in which the spans are kept while waiting for the duration,
+	// so that the traces that were not released yet survive restarts.
+	// Default: nil, the spans are kept in memory.
+	StorageID *component.ID `mapstructure:"storage"`
 }
@@ -32,6 +32,9 @@ const (
 
 	// traceID to be removed
 	traceRemoved
+
+	// traces recovered from the storage
+	traceRecovered
 )
 
 var (
@@ -59,6 +62,11 @@ type tracesWithID struct {
 	td ptrace.Traces
 }
 
+type recoveredTrace struct {
+	id         pcommon.TraceID
+	receivedAt time.Time
+}
+
 // eventMachine is a machine that accepts events in a typically non-blocking manner,
 // processing the events serially per worker scope, to ensure that data at the consumer is consistent.
 // Just like the machine itself is non-blocking, consumers are expected to also not block
@@ -70,12 +78,13 @@ type eventMachine struct {
 	metricsCollectionInterval time.Duration
 	shutdownTimeout           time.Duration
 
-	logger          *zap.Logger
-	telemetry       *metadata.TelemetryBuilder
-	onTraceReceived func(td tracesWithID, worker *eventMachineWorker) error
-	onTraceExpired  func(traceID pcommon.TraceID, worker *eventMachineWorker) error
-	onTraceReleased func(rss []ptrace.ResourceSpans) error
-	onTraceRemoved  func(traceID pcommon.TraceID) error
+	logger           *zap.Logger
+	telemetry        *metadata.TelemetryBuilder
+	onTraceReceived  func(td tracesWithID, worker *eventMachineWorker) error
+	onTraceExpired   func(traceID pcommon.TraceID, worker *eventMachineWorker) error
+	onTraceReleased  func(rss []ptrace.ResourceSpans) error
+	onTraceRemoved   func(traceID pcommon.TraceID) error
+	onTraceRecovered func(trace recoveredTrace, worker *eventMachineWorker) error
 
 	onError func(event)
 
@@ -206,6 +215,22 @@ func (em *eventMachine) handleEvent(e event, w *eventMachineWorker) {
 		em.handleEventWithObservability("onTraceRemoved", func() error {
 			return em.onTraceRemoved(payload)
 		})
+	case traceRecovered:
+		if em.onTraceRecovered == nil {
+			em.logger.Debug("onTraceRecovered not set, skipping event")
+			em.callOnError(e)
+			return
+		}
+		payload, ok := e.payload.(recoveredTrace)
+		if !ok {
+			// the payload had an unexpected type!
+			em.callOnError(e)
+			return
+		}
+
+		em.handleEventWithObservability("onTraceRecovered", func() error {
+			return em.onTraceRecovered(payload, w)
+		})
 	default:
 		em.logger.Info("unknown event type", zap.Any("event", e.typ))
 		em.callOnError(e)
@@ -234,6 +259,19 @@ func (em *eventMachine) consume(td ptrace.Traces) error {
 	return nil
 }
 
+// recover routes a trace found in the storage at start to the worker it belongs to.
+func (em *eventMachine) recover(traceID pcommon.TraceID, receivedAt time.Time) {
+	var bucket uint64
+	if len(em.workers) != 1 {
+		bucket = workerIndexForTraceID(traceID, len(em.workers))
+	}
+
+	em.workers[bucket].fire(event{
+		typ:     traceRecovered,
+		payload: recoveredTrace{id: traceID, receivedAt: receivedAt},
+	})
+}
+
 func workerIndexForTraceID(traceID pcommon.TraceID, numWorkers int) uint64 {
 	hash := hashPool.Get().(*maphash.Hash)
 	defer func() {
@@ -68,8 +68,11 @@ func createTracesProcessor(
 	}
 
 	processor := newGroupByTraceProcessor(params, nextConsumer, *oCfg)
-	// the only supported storage for now
-	st = newMemoryStorage(processor.telemetryBuilder)
+	if oCfg.StorageID != nil {
+		st = newPersistentStorage(params.ID, *oCfg.StorageID, params.Logger, processor.telemetryBuilder)
+	} else {
+		st = newMemoryStorage(processor.telemetryBuilder)
+	}
 	processor.st = st
 	return processor, nil
 }
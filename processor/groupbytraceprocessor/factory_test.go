@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/processor/processortest"
 )
@@ -35,6 +36,19 @@ func TestCreateTestProcessor(t *testing.T) {
 	assert.NotNil(t, p)
 }
 
+func TestCreateTestProcessorWithStorage(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	storageID := component.MustNewIDWithName("file_storage", "groupbytrace")
+	c.StorageID = &storageID
+
+	// test
+	p, err := createTracesProcessor(context.Background(), processortest.NewNopSettings(), c, consumertest.NewNop())
+
+	// verify
+	assert.NoError(t, err)
+	assert.IsType(t, &persistentStorage{}, p.(*groupByTraceProcessor).st)
+}
+
 func TestCreateTestProcessorWithNotImplementedOptions(t *testing.T) {
 	// prepare
 	f := NewFactory()
@@ -3,12 +3,14 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/processor/group
 go 1.21.0
 
 require (
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage v0.104.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal v0.104.0
 	github.com/stretchr/testify v1.9.0
 	go.opentelemetry.io/collector/component v0.104.1-0.20240709093154-e7ce1d50fb5e
 	go.opentelemetry.io/collector/config/configtelemetry v0.104.1-0.20240709093154-e7ce1d50fb5e
 	go.opentelemetry.io/collector/confmap v0.104.1-0.20240709093154-e7ce1d50fb5e
 	go.opentelemetry.io/collector/consumer v0.104.1-0.20240709093154-e7ce1d50fb5e
+	go.opentelemetry.io/collector/extension v0.104.1-0.20240709093154-e7ce1d50fb5e
 	go.opentelemetry.io/collector/pdata v1.11.1-0.20240709093154-e7ce1d50fb5e
 	go.opentelemetry.io/collector/processor v0.104.1-0.20240709093154-e7ce1d50fb5e
 	go.opentelemetry.io/otel v1.28.0
@@ -60,6 +62,8 @@ require (
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal => ../../pkg/batchpersignal
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage => ../../extension/storage
+
 retract (
 	v0.76.2
 	v0.76.1
@@ -71,6 +71,7 @@ func newGroupByTraceProcessor(set processor.Settings, nextConsumer consumer.Trac
 	eventMachine.onTraceExpired = sp.onTraceExpired
 	eventMachine.onTraceReleased = sp.onTraceReleased
 	eventMachine.onTraceRemoved = sp.onTraceRemoved
+	eventMachine.onTraceRecovered = sp.onTraceRecovered
 
 	return sp
 }
@@ -88,13 +89,23 @@ func (sp *groupByTraceProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+func (sp *groupByTraceProcessor) Start(ctx context.Context, host component.Host) error {
 	// start these metrics, as it might take a while for them to receive their first event
 	sp.telemetryBuilder.ProcessorGroupbytraceTracesEvicted.Add(context.Background(), 0)
 	sp.telemetryBuilder.ProcessorGroupbytraceIncompleteReleases.Add(context.Background(), 0)
 	sp.telemetryBuilder.ProcessorGroupbytraceConfNumTraces.Record(context.Background(), (int64(sp.config.NumTraces)))
+	if err := sp.st.start(ctx, host); err != nil {
+		return err
+	}
 	sp.eventMachine.startInBackground()
-	return sp.st.start()
+
+	// traces persisted by a previous run are scheduled to be released once their remaining wait duration expires
+	if rs, ok := sp.st.(recoverableStorage); ok {
+		for traceID, receivedAt := range rs.recoveredTraces() {
+			sp.eventMachine.recover(traceID, receivedAt)
+		}
+	}
+	return nil
 }
 
 // Shutdown is invoked during service shutdown.
@@ -151,6 +162,36 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 	return nil
 }
 
+func (sp *groupByTraceProcessor) onTraceRecovered(trace recoveredTrace, worker *eventMachineWorker) error {
+	if worker.buffer.contains(trace.id) {
+		return nil
+	}
+
+	evicted := worker.buffer.put(trace.id)
+	if !evicted.IsEmpty() {
+		worker.fire(event{
+			typ:     traceRemoved,
+			payload: evicted,
+		})
+		sp.telemetryBuilder.ProcessorGroupbytraceTracesEvicted.Add(context.Background(), 1)
+
+		sp.logger.Info("trace evicted while recovering traces from the storage: in order to avoid this in the future, adjust the number of traces to keep in memory",
+			zap.Stringer("traceID", evicted))
+	}
+
+	remaining := max(sp.config.WaitDuration-time.Since(trace.receivedAt), 0)
+	sp.logger.Debug("scheduled to release recovered trace", zap.Stringer("traceID", trace.id), zap.Duration("duration", remaining))
+
+	time.AfterFunc(remaining, func() {
+		// if the event machine has stopped, it will just discard the event
+		worker.fire(event{
+			typ:     traceExpired,
+			payload: trace.id,
+		})
+	})
+	return nil
+}
+
 func (sp *groupByTraceProcessor) onTraceExpired(traceID pcommon.TraceID, worker *eventMachineWorker) error {
 	sp.logger.Debug("processing expired", zap.Stringer("traceID", traceID))
 
@@ -637,7 +637,7 @@ func (st *mockStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans,
 	}
 	return nil, nil
 }
-func (st *mockStorage) start() error {
+func (st *mockStorage) start(context.Context, component.Host) error {
 	if st.onStart != nil {
 		return st.onStart()
 	}
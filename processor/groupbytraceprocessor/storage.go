@@ -4,6 +4,10 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
@@ -25,8 +29,17 @@ type storage interface {
 	delete(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
 
 	// start gives the storage the opportunity to initialize any resources or procedures
-	start() error
+	start(ctx context.Context, host component.Host) error
 
 	// shutdown signals the storage that the processor is shutting down
 	shutdown() error
 }
+
+// recoverableStorage is a storage that keeps the traces across restarts.
+type recoverableStorage interface {
+	storage
+
+	// recoveredTraces returns the traces found in the storage when it started, along with
+	// the time their first spans were received
+	recoveredTraces() map[pcommon.TraceID]time.Time
+}
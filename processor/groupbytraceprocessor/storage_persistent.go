@@ -0,0 +1,343 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor/internal/metadata"
+)
+
+const (
+	// lowestSeqKey and nextSeqKey delimit the range of sequence numbers that may still have a trace in the storage
+	lowestSeqKey   = "lowest_seq"
+	nextSeqKey     = "next_seq"
+	traceKeyPrefix = "trace_"
+
+	// the header of a persisted trace: the time its first spans were received, then its ID
+	traceHeaderSize = 8 + 16
+
+	// number of traces read in a single batch when recovering the storage
+	recoverBatchSize = 1000
+)
+
+var errStorageNotStarted = errors.New("the storage client has not been started")
+
+// persistentStorage keeps the spans of the traces in a storage extension, so that they survive restarts.
+// Only the trace IDs are kept in memory, each trace being persisted under a sequence number
+// assigned when its first spans are received.
+type persistentStorage struct {
+	sync.Mutex
+	id          component.ID
+	storageID   component.ID
+	logger      *zap.Logger
+	telemetry   *metadata.TelemetryBuilder
+	client      storage.Client
+	marshaler   ptrace.ProtoMarshaler
+	unmarshaler ptrace.ProtoUnmarshaler
+
+	traces    map[pcommon.TraceID]persistedTrace
+	seqs      map[uint64]struct{}
+	lowestSeq uint64
+	nextSeq   uint64
+
+	stopped                   bool
+	stoppedLock               sync.RWMutex
+	metricsCollectionInterval time.Duration
+}
+
+type persistedTrace struct {
+	seq        uint64
+	receivedAt time.Time
+}
+
+var _ recoverableStorage = (*persistentStorage)(nil)
+
+func newPersistentStorage(id component.ID, storageID component.ID, logger *zap.Logger, telemetry *metadata.TelemetryBuilder) *persistentStorage {
+	return &persistentStorage{
+		id:                        id,
+		storageID:                 storageID,
+		logger:                    logger,
+		telemetry:                 telemetry,
+		traces:                    make(map[pcommon.TraceID]persistedTrace),
+		seqs:                      make(map[uint64]struct{}),
+		metricsCollectionInterval: time.Second,
+	}
+}
+
+func (st *persistentStorage) createOrAppend(traceID pcommon.TraceID, td ptrace.Traces) error {
+	st.Lock()
+	defer st.Unlock()
+	if st.client == nil {
+		return errStorageNotStarted
+	}
+
+	pt, ok := st.traces[traceID]
+	if !ok {
+		pt = persistedTrace{seq: st.nextSeq, receivedAt: time.Now()}
+		value, err := st.encode(traceID, pt.receivedAt, td)
+		if err != nil {
+			return err
+		}
+		if err = st.client.Batch(context.Background(),
+			storage.SetOperation(traceKey(pt.seq), value),
+			storage.SetOperation(nextSeqKey, encodeSeq(pt.seq+1)),
+		); err != nil {
+			return err
+		}
+		st.traces[traceID] = pt
+		st.seqs[pt.seq] = struct{}{}
+		st.nextSeq++
+		return nil
+	}
+
+	existing, err := st.read(pt.seq)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		td.ResourceSpans().At(i).CopyTo(existing.ResourceSpans().AppendEmpty())
+	}
+	value, err := st.encode(traceID, pt.receivedAt, existing)
+	if err != nil {
+		return err
+	}
+	return st.client.Set(context.Background(), traceKey(pt.seq), value)
+}
+
+func (st *persistentStorage) get(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	defer st.Unlock()
+	if st.client == nil {
+		return nil, errStorageNotStarted
+	}
+
+	pt, ok := st.traces[traceID]
+	if !ok {
+		return nil, nil
+	}
+	td, err := st.read(pt.seq)
+	if err != nil {
+		return nil, err
+	}
+	return resourceSpansOf(td), nil
+}
+
+func (st *persistentStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	defer st.Unlock()
+	if st.client == nil {
+		return nil, errStorageNotStarted
+	}
+
+	pt, ok := st.traces[traceID]
+	if !ok {
+		return nil, nil
+	}
+	td, err := st.read(pt.seq)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(st.traces, traceID)
+	delete(st.seqs, pt.seq)
+	ops := []storage.Operation{storage.DeleteOperation(traceKey(pt.seq))}
+	if pt.seq == st.lowestSeq {
+		st.advanceLowestSeq()
+		ops = append(ops, storage.SetOperation(lowestSeqKey, encodeSeq(st.lowestSeq)))
+	}
+	if err = st.client.Batch(context.Background(), ops...); err != nil {
+		return nil, err
+	}
+	return resourceSpansOf(td), nil
+}
+
+// start acquires a client from the storage extension and loads the IDs of the traces persisted by a previous run.
+func (st *persistentStorage) start(ctx context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[st.storageID]
+	if !ok {
+		return fmt.Errorf("storage extension '%s' not found", st.storageID)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return fmt.Errorf("non-storage extension '%s' found", st.storageID)
+	}
+	client, err := storageExt.GetClient(ctx, component.KindProcessor, st.id, "")
+	if err != nil {
+		return err
+	}
+
+	st.Lock()
+	st.client = client
+	err = st.load(ctx)
+	st.Unlock()
+	if err != nil {
+		return err
+	}
+
+	go st.periodicMetrics()
+	return nil
+}
+
+func (st *persistentStorage) shutdown() error {
+	st.stoppedLock.Lock()
+	st.stopped = true
+	st.stoppedLock.Unlock()
+
+	st.Lock()
+	defer st.Unlock()
+	if st.client == nil {
+		return nil
+	}
+	err := st.client.Close(context.Background())
+	st.client = nil
+	return err
+}
+
+func (st *persistentStorage) recoveredTraces() map[pcommon.TraceID]time.Time {
+	st.Lock()
+	defer st.Unlock()
+
+	result := make(map[pcommon.TraceID]time.Time, len(st.traces))
+	for traceID, pt := range st.traces {
+		result[traceID] = pt.receivedAt
+	}
+	return result
+}
+
+// load reads the headers of all the traces between the lowest and next sequence numbers.
+func (st *persistentStorage) load(ctx context.Context) error {
+	ops := []storage.Operation{storage.GetOperation(lowestSeqKey), storage.GetOperation(nextSeqKey)}
+	if err := st.client.Batch(ctx, ops...); err != nil {
+		return fmt.Errorf("failed to read sequence numbers from storage: %w", err)
+	}
+	st.lowestSeq = decodeSeq(ops[0].Value)
+	st.nextSeq = decodeSeq(ops[1].Value)
+
+	for from := st.lowestSeq; from < st.nextSeq; from += recoverBatchSize {
+		to := min(from+recoverBatchSize, st.nextSeq)
+		ops = ops[:0]
+		for seq := from; seq < to; seq++ {
+			ops = append(ops, storage.GetOperation(traceKey(seq)))
+		}
+		if err := st.client.Batch(ctx, ops...); err != nil {
+			return fmt.Errorf("failed to read traces from storage: %w", err)
+		}
+		for i, op := range ops {
+			if op.Value == nil {
+				continue
+			}
+			seq := from + uint64(i)
+			if len(op.Value) < traceHeaderSize {
+				st.logger.Warn("Dropping unreadable trace", zap.Uint64("seq", seq))
+				continue
+			}
+			traceID, receivedAt := decodeHeader(op.Value)
+			st.traces[traceID] = persistedTrace{seq: seq, receivedAt: receivedAt}
+			st.seqs[seq] = struct{}{}
+		}
+	}
+
+	if _, ok := st.seqs[st.lowestSeq]; !ok && st.lowestSeq < st.nextSeq {
+		st.advanceLowestSeq()
+		if err := st.client.Set(ctx, lowestSeqKey, encodeSeq(st.lowestSeq)); err != nil {
+			return err
+		}
+	}
+	if len(st.traces) > 0 {
+		st.logger.Info("Recovered traces from storage", zap.Int("traces", len(st.traces)))
+	}
+	return nil
+}
+
+// advanceLowestSeq moves the lowest sequence number to the oldest trace still in the storage.
+func (st *persistentStorage) advanceLowestSeq() {
+	for st.lowestSeq < st.nextSeq {
+		if _, ok := st.seqs[st.lowestSeq]; ok {
+			return
+		}
+		st.lowestSeq++
+	}
+}
+
+func (st *persistentStorage) read(seq uint64) (ptrace.Traces, error) {
+	value, err := st.client.Get(context.Background(), traceKey(seq))
+	if err != nil {
+		return ptrace.Traces{}, err
+	}
+	if len(value) < traceHeaderSize {
+		return ptrace.Traces{}, fmt.Errorf("trace with sequence number %d is missing or corrupted in the storage", seq)
+	}
+	return st.unmarshaler.UnmarshalTraces(value[traceHeaderSize:])
+}
+
+func (st *persistentStorage) encode(traceID pcommon.TraceID, receivedAt time.Time, td ptrace.Traces) ([]byte, error) {
+	spans, err := st.marshaler.MarshalTraces(td)
+	if err != nil {
+		return nil, err
+	}
+	value := make([]byte, traceHeaderSize, traceHeaderSize+len(spans))
+	binary.BigEndian.PutUint64(value, uint64(receivedAt.UnixNano()))
+	copy(value[8:traceHeaderSize], traceID[:])
+	return append(value, spans...), nil
+}
+
+func decodeHeader(value []byte) (pcommon.TraceID, time.Time) {
+	var traceID pcommon.TraceID
+	copy(traceID[:], value[8:traceHeaderSize])
+	return traceID, time.Unix(0, int64(binary.BigEndian.Uint64(value)))
+}
+
+func (st *persistentStorage) periodicMetrics() {
+	st.Lock()
+	numTraces := len(st.traces)
+	st.Unlock()
+	st.telemetry.ProcessorGroupbytraceNumTracesInMemory.Record(context.Background(), int64(numTraces))
+
+	st.stoppedLock.RLock()
+	stopped := st.stopped
+	st.stoppedLock.RUnlock()
+	if stopped {
+		return
+	}
+
+	time.AfterFunc(st.metricsCollectionInterval, func() {
+		st.periodicMetrics()
+	})
+}
+
+func resourceSpansOf(td ptrace.Traces) []ptrace.ResourceSpans {
+	rss := make([]ptrace.ResourceSpans, td.ResourceSpans().Len())
+	for i := range rss {
+		rss[i] = td.ResourceSpans().At(i)
+	}
+	return rss
+}
+
+func traceKey(seq uint64) string {
+	return traceKeyPrefix + strconv.FormatUint(seq, 10)
+}
+
+func encodeSeq(seq uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, seq)
+}
+
+func decodeSeq(value []byte) uint64 {
+	if len(value) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(value)
+}
@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor/internal/metadata"
+)
+
+func newTestPersistentStorage(t *testing.T, host component.Host) *persistentStorage {
+	set := processortest.NewNopSettings()
+	tel, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	require.NoError(t, err)
+	st := newPersistentStorage(set.ID, storagetest.NewStorageID("test"), set.Logger, tel)
+	require.NoError(t, st.start(context.Background(), host))
+	return st
+}
+
+func traceWithSpanName(traceID pcommon.TraceID, name string) ptrace.Traces {
+	trace := ptrace.NewTraces()
+	span := trace.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetTraceID(traceID)
+	span.SetName(name)
+	return trace
+}
+
+func TestPersistentCreateAndGetTrace(t *testing.T) {
+	st := newTestPersistentStorage(t, storagetest.NewStorageHost().WithInMemoryStorageExtension("test"))
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	first := traceWithSpanName(traceID, "first")
+	second := traceWithSpanName(traceID, "second")
+
+	// test
+	require.NoError(t, st.createOrAppend(traceID, first))
+	require.NoError(t, st.createOrAppend(traceID, second))
+
+	// verify
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Equal(t, []ptrace.ResourceSpans{first.ResourceSpans().At(0), second.ResourceSpans().At(0)}, retrieved)
+
+	retrieved, err = st.get(pcommon.TraceID([16]byte{2, 3, 4, 5}))
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+}
+
+func TestPersistentDeleteTrace(t *testing.T) {
+	st := newTestPersistentStorage(t, storagetest.NewStorageHost().WithInMemoryStorageExtension("test"))
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	trace := traceWithSpanName(traceID, "span")
+	require.NoError(t, st.createOrAppend(traceID, trace))
+
+	// test
+	deleted, err := st.delete(traceID)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []ptrace.ResourceSpans{trace.ResourceSpans().At(0)}, deleted)
+
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+	assert.Equal(t, uint64(1), st.lowestSeq)
+}
+
+func TestPersistentStorageRecoversTraces(t *testing.T) {
+	dir := t.TempDir()
+	firstID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	secondID := pcommon.TraceID([16]byte{2, 3, 4, 5})
+	second := traceWithSpanName(secondID, "second")
+
+	st := newTestPersistentStorage(t, storagetest.NewStorageHost().WithFileBackedStorageExtension("test", dir))
+	require.NoError(t, st.createOrAppend(firstID, traceWithSpanName(firstID, "first")))
+	require.NoError(t, st.createOrAppend(secondID, second))
+	receivedAt := st.traces[secondID].receivedAt
+	_, err := st.delete(firstID)
+	require.NoError(t, err)
+	require.NoError(t, st.shutdown())
+
+	// test
+	st = newTestPersistentStorage(t, storagetest.NewStorageHost().WithFileBackedStorageExtension("test", dir))
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+
+	// verify
+	recovered := st.recoveredTraces()
+	require.Len(t, recovered, 1)
+	assert.True(t, receivedAt.Equal(recovered[secondID]))
+
+	retrieved, err := st.get(secondID)
+	require.NoError(t, err)
+	assert.Equal(t, []ptrace.ResourceSpans{second.ResourceSpans().At(0)}, retrieved)
+	assert.Equal(t, uint64(1), st.lowestSeq)
+	assert.Equal(t, uint64(2), st.nextSeq)
+}
+
+func TestPersistentStorageExtensionNotFound(t *testing.T) {
+	set := processortest.NewNopSettings()
+	tel, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	require.NoError(t, err)
+
+	st := newPersistentStorage(set.ID, storagetest.NewStorageID("test"), set.Logger, tel)
+	assert.ErrorContains(t, st.start(context.Background(), storagetest.NewStorageHost()), "storage extension 'test_storage/test' not found")
+
+	st = newPersistentStorage(set.ID, storagetest.NewNonStorageID("test"), set.Logger, tel)
+	assert.ErrorContains(t, st.start(context.Background(), storagetest.NewStorageHost().WithNonStorageExtension("test")), "non-storage extension")
+}
+
+func TestTraceIsDispatchedAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	traces := simpleTraces()
+	storageID := storagetest.NewStorageID("test")
+
+	// the trace is received, but the processor is shut down before the duration expires
+	config := Config{
+		WaitDuration: time.Hour,
+		NumTraces:    10,
+		NumWorkers:   1,
+		StorageID:    &storageID,
+	}
+	p := newGroupByTraceProcessor(processortest.NewNopSettings(), &mockProcessor{}, config)
+	p.st = newPersistentStorage(processortest.NewNopSettings().ID, storageID, p.logger, p.telemetryBuilder)
+	require.NoError(t, p.Start(ctx, storagetest.NewStorageHost().WithFileBackedStorageExtension("test", dir)))
+	require.NoError(t, p.ConsumeTraces(ctx, traces))
+	assert.Eventually(t, func() bool {
+		trace, err := p.st.get(pcommon.TraceID([16]byte{1, 2, 3, 4}))
+		return err == nil && trace != nil
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, p.Shutdown(ctx))
+
+	// test
+	wgReceived := &sync.WaitGroup{}
+	wgReceived.Add(1)
+	config.WaitDuration = time.Nanosecond
+	mockProcessor := &mockProcessor{
+		onTraces: func(_ context.Context, received ptrace.Traces) error {
+			assert.Equal(t, traces, received)
+			wgReceived.Done()
+			return nil
+		},
+	}
+	p = newGroupByTraceProcessor(processortest.NewNopSettings(), mockProcessor, config)
+	p.st = newPersistentStorage(processortest.NewNopSettings().ID, storageID, p.logger, p.telemetryBuilder)
+	require.NoError(t, p.Start(ctx, storagetest.NewStorageHost().WithFileBackedStorageExtension("test", dir)))
+	defer func() {
+		assert.NoError(t, p.Shutdown(ctx))
+	}()
+
+	// verify
+	wgReceived.Wait()
+}
@@ -5,13 +5,31 @@ package intervalprocessor // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 )
 
 var (
-	ErrInvalidIntervalValue = errors.New("invalid interval value")
+	ErrInvalidIntervalValue    = errors.New("invalid interval value")
+	ErrInvalidGaugeAggregation = errors.New("invalid gauge aggregation")
+)
+
+// GaugeAggregation is how the data points of a gauge received during an interval are combined.
+type GaugeAggregation string
+
+const (
+	// GaugeAggregationNone passes gauges through, unchanged.
+	GaugeAggregationNone GaugeAggregation = "none"
+	// GaugeAggregationLast keeps the most recent data point.
+	GaugeAggregationLast GaugeAggregation = "last"
+	// GaugeAggregationMin keeps the data point with the lowest value.
+	GaugeAggregationMin GaugeAggregation = "min"
+	// GaugeAggregationMax keeps the data point with the highest value.
+	GaugeAggregationMax GaugeAggregation = "max"
+	// GaugeAggregationMean reports the mean value of the data points.
+	GaugeAggregationMean GaugeAggregation = "mean"
 )
 
 var _ component.Config = (*Config)(nil)
@@ -20,6 +38,13 @@ var _ component.Config = (*Config)(nil)
 type Config struct {
 	// Interval is the time
 	Interval time.Duration `mapstructure:"interval"`
+
+	// GaugeAggregation is how gauges are aggregated over the interval: "none", "last", "min", "max" or "mean".
+	GaugeAggregation GaugeAggregation `mapstructure:"gauge_aggregation"`
+
+	// AggregateDeltaSums accumulates the data points of delta sums into a single delta per interval,
+	// instead of passing them through.
+	AggregateDeltaSums bool `mapstructure:"aggregate_delta_sums"`
 }
 
 // Validate checks whether the input configuration has all of the required fields for the processor.
@@ -29,5 +54,11 @@ func (config *Config) Validate() error {
 		return ErrInvalidIntervalValue
 	}
 
+	switch config.GaugeAggregation {
+	case "", GaugeAggregationNone, GaugeAggregationLast, GaugeAggregationMin, GaugeAggregationMax, GaugeAggregationMean:
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidGaugeAggregation, config.GaugeAggregation)
+	}
+
 	return nil
 }
@@ -25,7 +25,8 @@ func NewFactory() processor.Factory {
 
 func createDefaultConfig() component.Config {
 	return &Config{
-		Interval: 60 * time.Second,
+		Interval:         60 * time.Second,
+		GaugeAggregation: GaugeAggregationNone,
 	}
 }
 
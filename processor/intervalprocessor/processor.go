@@ -36,8 +36,11 @@ type Processor struct {
 	numberLookup       map[identity.Stream]pmetric.NumberDataPoint
 	histogramLookup    map[identity.Stream]pmetric.HistogramDataPoint
 	expHistogramLookup map[identity.Stream]pmetric.ExponentialHistogramDataPoint
+	gaugeMeanLookup    map[identity.Stream]*meanAccumulator
 
-	exportInterval time.Duration
+	exportInterval     time.Duration
+	gaugeAggregation   GaugeAggregation
+	aggregateDeltaSums bool
 
 	nextConsumer consumer.Metrics
 }
@@ -59,8 +62,11 @@ func newProcessor(config *Config, log *zap.Logger, nextConsumer consumer.Metrics
 		numberLookup:       map[identity.Stream]pmetric.NumberDataPoint{},
 		histogramLookup:    map[identity.Stream]pmetric.HistogramDataPoint{},
 		expHistogramLookup: map[identity.Stream]pmetric.ExponentialHistogramDataPoint{},
+		gaugeMeanLookup:    map[identity.Stream]*meanAccumulator{},
 
-		exportInterval: config.Interval,
+		exportInterval:     config.Interval,
+		gaugeAggregation:   config.GaugeAggregation,
+		aggregateDeltaSums: config.AggregateDeltaSums,
 
 		nextConsumer: nextConsumer,
 	}
@@ -102,12 +108,41 @@ func (p *Processor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) erro
 		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
 			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
 				switch m.Type() {
-				case pmetric.MetricTypeGauge, pmetric.MetricTypeSummary:
+				case pmetric.MetricTypeSummary:
 					return false
+				case pmetric.MetricTypeGauge:
+					if p.gaugeAggregation == "" || p.gaugeAggregation == GaugeAggregationNone {
+						return false
+					}
+
+					mClone, metricID := p.getOrCloneMetric(rm, sm, m)
+					cloneGauge := mClone.Gauge()
+
+					switch p.gaugeAggregation {
+					case GaugeAggregationLast:
+						aggregateDataPoints(m.Gauge().DataPoints(), cloneGauge.DataPoints(), metricID, p.numberLookup)
+					case GaugeAggregationMin:
+						aggregateGaugeDataPoints(m.Gauge().DataPoints(), cloneGauge.DataPoints(), metricID, p.numberLookup, func(value, existing float64) bool { return value < existing })
+					case GaugeAggregationMax:
+						aggregateGaugeDataPoints(m.Gauge().DataPoints(), cloneGauge.DataPoints(), metricID, p.numberLookup, func(value, existing float64) bool { return value > existing })
+					case GaugeAggregationMean:
+						p.aggregateGaugeMeans(m.Gauge().DataPoints(), cloneGauge.DataPoints(), metricID)
+					}
+					return true
 				case pmetric.MetricTypeSum:
 					// Check if we care about this value
 					sum := m.Sum()
 
+					if sum.AggregationTemporality() == pmetric.AggregationTemporalityDelta {
+						if !p.aggregateDeltaSums {
+							return false
+						}
+
+						mClone, metricID := p.getOrCloneMetric(rm, sm, m)
+						aggregateDeltaDataPoints(sum.DataPoints(), mClone.Sum().DataPoints(), metricID, p.numberLookup)
+						return true
+					}
+
 					if !sum.IsMonotonic() {
 						return false
 					}
@@ -185,6 +220,93 @@ func aggregateDataPoints[DPS metrics.DataPointSlice[DP], DP metrics.DataPoint[DP
 	}
 }
 
+// aggregateGaugeDataPoints keeps, for each stream, the data point whose value replaces the existing one
+// according to replaces, e.g. the lowest value. The timestamp of the most recent data point is kept.
+func aggregateGaugeDataPoints(dataPoints pmetric.NumberDataPointSlice, mCloneDataPoints pmetric.NumberDataPointSlice, metricID identity.Metric, dpLookup map[identity.Stream]pmetric.NumberDataPoint, replaces func(value, existing float64) bool) {
+	for i := 0; i < dataPoints.Len(); i++ {
+		dp := dataPoints.At(i)
+
+		streamID := identity.OfStream(metricID, dp)
+		existingDP, ok := dpLookup[streamID]
+		if !ok {
+			dpClone := mCloneDataPoints.AppendEmpty()
+			dp.CopyTo(dpClone)
+			dpLookup[streamID] = dpClone
+			continue
+		}
+
+		timestamp := max(dp.Timestamp(), existingDP.Timestamp())
+		if replaces(numberValue(dp), numberValue(existingDP)) {
+			dp.CopyTo(existingDP)
+		}
+		existingDP.SetTimestamp(timestamp)
+	}
+}
+
+// meanAccumulator holds the sum and the count of the values of a gauge stream received during the interval.
+type meanAccumulator struct {
+	dp    pmetric.NumberDataPoint
+	sum   float64
+	count int
+}
+
+// aggregateGaugeMeans accumulates the values of each stream, the mean is computed on export.
+// The attributes and timestamp of the most recent data point are kept.
+func (p *Processor) aggregateGaugeMeans(dataPoints pmetric.NumberDataPointSlice, mCloneDataPoints pmetric.NumberDataPointSlice, metricID identity.Metric) {
+	for i := 0; i < dataPoints.Len(); i++ {
+		dp := dataPoints.At(i)
+
+		streamID := identity.OfStream(metricID, dp)
+		acc, ok := p.gaugeMeanLookup[streamID]
+		if !ok {
+			acc = &meanAccumulator{dp: mCloneDataPoints.AppendEmpty()}
+			dp.CopyTo(acc.dp)
+			p.gaugeMeanLookup[streamID] = acc
+		} else if dp.Timestamp() > acc.dp.Timestamp() {
+			dp.CopyTo(acc.dp)
+		}
+
+		acc.sum += numberValue(dp)
+		acc.count++
+	}
+}
+
+// aggregateDeltaDataPoints adds up the data points of each stream into a single delta covering all of them.
+func aggregateDeltaDataPoints(dataPoints pmetric.NumberDataPointSlice, mCloneDataPoints pmetric.NumberDataPointSlice, metricID identity.Metric, dpLookup map[identity.Stream]pmetric.NumberDataPoint) {
+	for i := 0; i < dataPoints.Len(); i++ {
+		dp := dataPoints.At(i)
+
+		streamID := identity.OfStream(metricID, dp)
+		existingDP, ok := dpLookup[streamID]
+		if !ok {
+			dpClone := mCloneDataPoints.AppendEmpty()
+			dp.CopyTo(dpClone)
+			dpLookup[streamID] = dpClone
+			continue
+		}
+
+		if dp.ValueType() == pmetric.NumberDataPointValueTypeInt && existingDP.ValueType() == pmetric.NumberDataPointValueTypeInt {
+			existingDP.SetIntValue(existingDP.IntValue() + dp.IntValue())
+		} else {
+			existingDP.SetDoubleValue(numberValue(existingDP) + numberValue(dp))
+		}
+		if dp.StartTimestamp() < existingDP.StartTimestamp() {
+			existingDP.SetStartTimestamp(dp.StartTimestamp())
+		}
+		if dp.Timestamp() > existingDP.Timestamp() {
+			existingDP.SetTimestamp(dp.Timestamp())
+		}
+		dp.Exemplars().MoveAndAppendTo(existingDP.Exemplars())
+	}
+}
+
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
+
 func (p *Processor) exportMetrics() {
 	md := func() pmetric.Metrics {
 		p.stateLock.Lock()
@@ -195,6 +317,11 @@ func (p *Processor) exportMetrics() {
 		out := p.md
 		p.md = pmetric.NewMetrics()
 
+		// Gauge means are only known once the interval is over
+		for _, acc := range p.gaugeMeanLookup {
+			acc.dp.SetDoubleValue(acc.sum / float64(acc.count))
+		}
+
 		// Clear all the lookup references
 		clear(p.rmLookup)
 		clear(p.smLookup)
@@ -202,6 +329,7 @@ func (p *Processor) exportMetrics() {
 		clear(p.numberLookup)
 		clear(p.histogramLookup)
 		clear(p.expHistogramLookup)
+		clear(p.gaugeMeanLookup)
 
 		return out
 	}()
@@ -21,22 +21,50 @@ import (
 func TestAggregation(t *testing.T) {
 	t.Parallel()
 
-	testCases := []string{
-		"basic_aggregation",
-		"non_monotonic_sums_are_passed_through",
-		"summaries_are_passed_through",
-		"histograms_are_aggregated",
-		"exp_histograms_are_aggregated",
-		"all_delta_metrics_are_passed_through",
+	testCases := []struct {
+		name   string
+		config *Config
+	}{
+		{name: "basic_aggregation"},
+		{name: "non_monotonic_sums_are_passed_through"},
+		{name: "summaries_are_passed_through"},
+		{name: "histograms_are_aggregated"},
+		{name: "exp_histograms_are_aggregated"},
+		{name: "all_delta_metrics_are_passed_through"},
+		{name: "gauges_are_passed_through"},
+		{
+			name:   "gauges_are_aggregated_last",
+			config: &Config{Interval: time.Second, GaugeAggregation: GaugeAggregationLast},
+		},
+		{
+			name:   "gauges_are_aggregated_min",
+			config: &Config{Interval: time.Second, GaugeAggregation: GaugeAggregationMin},
+		},
+		{
+			name:   "gauges_are_aggregated_max",
+			config: &Config{Interval: time.Second, GaugeAggregation: GaugeAggregationMax},
+		},
+		{
+			name:   "gauges_are_aggregated_mean",
+			config: &Config{Interval: time.Second, GaugeAggregation: GaugeAggregationMean},
+		},
+		{
+			name:   "delta_sums_are_aggregated",
+			config: &Config{Interval: time.Second, AggregateDeltaSums: true},
+		},
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	config := &Config{Interval: time.Second}
+	defaultConfig := &Config{Interval: time.Second}
 
 	for _, tc := range testCases {
-		testName := tc
+		testName := tc.name
+		config := tc.config
+		if config == nil {
+			config = defaultConfig
+		}
 
 		t.Run(testName, func(t *testing.T) {
 			t.Parallel()
@@ -75,6 +103,7 @@ func TestAggregation(t *testing.T) {
 			require.Empty(t, processor.numberLookup)
 			require.Empty(t, processor.histogramLookup)
 			require.Empty(t, processor.expHistogramLookup)
+			require.Empty(t, processor.gaugeMeanLookup)
 
 			// Exporting again should return nothing
 			processor.exportMetrics()
@@ -3,6 +3,13 @@
 
 package redactionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
 
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
 type Config struct {
 
 	// AllowAllKeys is a flag to allow all span attribute keys. Setting this
@@ -24,6 +31,33 @@ type Config struct {
 	// allowed span attributes. Values that match are masked
 	BlockedValues []string `mapstructure:"blocked_values"`
 
+	// Detectors is a list of built-in detectors of sensitive values, applied
+	// like BlockedValues. Possible values are `email`, `credit_card`, `iban`
+	// and `jwt`. Credit card numbers are validated with the Luhn checksum and
+	// IBANs with their mod-97 check digits, to avoid redacting lookalikes.
+	Detectors []string `mapstructure:"detectors"`
+
+	// RedactionMode controls how the parts of the values matching
+	// BlockedValues or Detectors are redacted. Possible values are `mask`,
+	// `replace`, `hash` and `tokenize`.
+	RedactionMode string `mapstructure:"redaction_mode"`
+
+	// Replacement is the text the matched parts of the values are replaced
+	// with in the `replace` redaction mode.
+	Replacement string `mapstructure:"replacement"`
+
+	// HashSalt is the secret key of the HMAC used by the `hash` and
+	// `tokenize` redaction modes, so that the same value is always redacted
+	// the same way while it can't be recovered without the salt.
+	HashSalt configopaque.String `mapstructure:"hash_salt"`
+
+	// RedactSpanNames applies BlockedValues and Detectors to the span names.
+	RedactSpanNames bool `mapstructure:"redact_span_names"`
+
+	// RedactLogBodies applies BlockedValues and Detectors to the string log
+	// record bodies.
+	RedactLogBodies bool `mapstructure:"redact_log_bodies"`
+
 	// Summary controls the verbosity level of the diagnostic attributes that
 	// the processor adds to the spans when it redacts or masks other
 	// attributes. In some contexts a list of redacted attributes leaks
@@ -31,3 +65,24 @@ type Config struct {
 	// configuration. Possible values are `debug`, `info`, and `silent`.
 	Summary string `mapstructure:"summary"`
 }
+
+func (c *Config) Validate() error {
+	switch c.RedactionMode {
+	case "", maskMode, replaceMode:
+	case hashMode, tokenizeMode:
+		if c.HashSalt == "" {
+			return fmt.Errorf("hash_salt must be set with the %q redaction mode", c.RedactionMode)
+		}
+	default:
+		return fmt.Errorf("unknown redaction mode %q", c.RedactionMode)
+	}
+	for _, name := range c.Detectors {
+		if _, ok := builtinDetectors[name]; !ok {
+			return fmt.Errorf("unknown detector %q", name)
+		}
+	}
+	if c.Replacement != "" && c.RedactionMode != replaceMode {
+		return errors.New("replacement can only be set with the \"replace\" redaction mode")
+	}
+	return nil
+}
@@ -30,12 +30,24 @@ func TestLoadConfig(t *testing.T) {
 				IgnoredKeys:   []string{"safe_attribute"},
 				BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?", "(5[1-5][0-9]{14})"},
 				Summary:       debug,
+				RedactionMode: maskMode,
 			},
 		},
 		{
 			id:       component.NewIDWithName(metadata.Type, "empty"),
 			expected: createDefaultConfig(),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "hash"),
+			expected: &Config{
+				AllowAllKeys:    true,
+				Detectors:       []string{"email", "credit_card", "iban", "jwt"},
+				RedactionMode:   hashMode,
+				HashSalt:        "s3cr3t",
+				RedactSpanNames: true,
+				RedactLogBodies: true,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -55,3 +67,54 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    *Config
+		errMsg string
+	}{
+		{
+			name: "default",
+			cfg:  createDefaultConfig().(*Config),
+		},
+		{
+			name: "replace",
+			cfg:  &Config{RedactionMode: replaceMode, Replacement: "[REDACTED]"},
+		},
+		{
+			name: "tokenize",
+			cfg:  &Config{RedactionMode: tokenizeMode, HashSalt: "s3cr3t", Detectors: []string{"credit_card"}},
+		},
+		{
+			name:   "unknown mode",
+			cfg:    &Config{RedactionMode: "encrypt"},
+			errMsg: `unknown redaction mode "encrypt"`,
+		},
+		{
+			name:   "hash without salt",
+			cfg:    &Config{RedactionMode: hashMode},
+			errMsg: `hash_salt must be set with the "hash" redaction mode`,
+		},
+		{
+			name:   "unknown detector",
+			cfg:    &Config{RedactionMode: maskMode, Detectors: []string{"ssn"}},
+			errMsg: `unknown detector "ssn"`,
+		},
+		{
+			name:   "replacement without replace mode",
+			cfg:    &Config{RedactionMode: maskMode, Replacement: "[REDACTED]"},
+			errMsg: `replacement can only be set with the "replace" redaction mode`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redactionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
+
+import (
+	"regexp"
+	"strings"
+)
+
+// valueMatcher finds the parts of the values to redact. The matches of re
+// are only redacted if validate is nil or returns true for them.
+type valueMatcher struct {
+	re       *regexp.Regexp
+	validate func(string) bool
+}
+
+// builtinDetectors are the detectors that can be enabled by name in the
+// configuration
+var builtinDetectors = map[string]valueMatcher{
+	"email": {
+		re: regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`),
+	},
+	"credit_card": {
+		re:       regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`),
+		validate: luhnValid,
+	},
+	"iban": {
+		re:       regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]){11,30}\b`),
+		validate: ibanValid,
+	},
+	"jwt": {
+		re: regexp.MustCompile(`\beyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`),
+	},
+}
+
+// luhnValid returns whether the digits of the value, ignoring the separators,
+// have a valid Luhn checksum
+func luhnValid(value string) bool {
+	var sum, digits int
+	for i := len(value) - 1; i >= 0; i-- {
+		c := value[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if digits%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		digits++
+	}
+	return digits >= 13 && digits <= 19 && sum%10 == 0
+}
+
+// ibanValid returns whether the value, ignoring the spaces, is an IBAN with
+// valid mod-97 check digits
+func ibanValid(value string) bool {
+	iban := strings.ReplaceAll(value, " ", "")
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+	// Move the country code and check digits to the end, and compute the
+	// remainder digit by digit, the letters standing for 10 (A) to 35 (Z)
+	remainder := 0
+	for _, c := range iban[4:] + iban[:4] {
+		switch {
+		case c >= '0' && c <= '9':
+			remainder = (remainder*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			remainder = (remainder*100 + int(c-'A'+10)) % 97
+		default:
+			return false
+		}
+	}
+	return remainder == 1
+}
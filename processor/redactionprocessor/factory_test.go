@@ -26,3 +26,12 @@ func TestCreateTestProcessor(t *testing.T) {
 	assert.NotNil(t, tp)
 	assert.Equal(t, true, tp.Capabilities().MutatesData)
 }
+
+func TestCreateLogsProcessor(t *testing.T) {
+	cfg := &Config{}
+
+	lp, err := createLogsProcessor(context.Background(), processortest.NewNopSettings(), cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, lp)
+	assert.Equal(t, true, lp.Capabilities().MutatesData)
+}
@@ -11,5 +11,6 @@ var (
 )
 
 const (
+	LogsStability   = component.StabilityLevelAlpha
 	TracesStability = component.StabilityLevelBeta
 )
@@ -5,12 +5,16 @@ package redactionprocessor // import "github.com/open-telemetry/opentelemetry-co
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"regexp"
 	"sort"
 	"strings"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 )
@@ -24,6 +28,10 @@ type redaction struct {
 	ignoreList map[string]string
 	// Attribute values blocked in a span
 	blockRegexList map[string]*regexp.Regexp
+	// Built-in detectors of values blocked in a span
+	detectors []valueMatcher
+	// Returns the redacted form of a blocked value
+	replace func(string) string
 	// Redaction processor configuration
 	config *Config
 	// Logger
@@ -40,10 +48,21 @@ func newRedaction(ctx context.Context, config *Config, logger *zap.Logger) (*red
 		return nil, fmt.Errorf("failed to process block list: %w", err)
 	}
 
+	detectors := make([]valueMatcher, 0, len(config.Detectors))
+	for _, name := range config.Detectors {
+		detector, ok := builtinDetectors[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown detector %q", name)
+		}
+		detectors = append(detectors, detector)
+	}
+
 	return &redaction{
 		allowList:      allowList,
 		ignoreList:     ignoreList,
 		blockRegexList: blockRegexList,
+		detectors:      detectors,
+		replace:        makeReplacer(config),
 		config:         config,
 		logger:         logger,
 	}, nil
@@ -75,6 +94,41 @@ func (s *redaction) processResourceSpan(ctx context.Context, rs ptrace.ResourceS
 
 			// Attributes can also be part of span
 			s.processAttrs(ctx, spanAttrs)
+
+			if s.config.RedactSpanNames {
+				if name, redacted := s.redactValue(span.Name()); redacted {
+					span.SetName(name)
+				}
+			}
+		}
+	}
+}
+
+// processLogs implements ProcessLogsFunc. It processes the incoming data
+// and returns the data to be sent to the next component
+func (s *redaction) processLogs(ctx context.Context, logs plog.Logs) (plog.Logs, error) {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		rl := logs.ResourceLogs().At(i)
+		s.processResourceLog(ctx, rl)
+	}
+	return logs, nil
+}
+
+// processResourceLog processes the resource and all of its log records
+func (s *redaction) processResourceLog(ctx context.Context, rl plog.ResourceLogs) {
+	s.processAttrs(ctx, rl.Resource().Attributes())
+
+	for j := 0; j < rl.ScopeLogs().Len(); j++ {
+		sl := rl.ScopeLogs().At(j)
+		for k := 0; k < sl.LogRecords().Len(); k++ {
+			lr := sl.LogRecords().At(k)
+			s.processAttrs(ctx, lr.Attributes())
+
+			if s.config.RedactLogBodies && lr.Body().Type() == pcommon.ValueTypeStr {
+				if body, redacted := s.redactValue(lr.Body().Str()); redacted {
+					lr.Body().SetStr(body)
+				}
+			}
 		}
 	}
 }
@@ -112,20 +166,9 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 		}
 
 		// Mask any blocked values for the other attributes
-		strVal := value.Str()
-		var matched bool
-		for _, compiledRE := range s.blockRegexList {
-			match := compiledRE.MatchString(strVal)
-			if match {
-				if !matched {
-					matched = true
-					toBlock = append(toBlock, k)
-				}
-
-				maskedValue := compiledRE.ReplaceAllString(strVal, "****")
-				value.SetStr(maskedValue)
-				strVal = maskedValue
-			}
+		if maskedValue, matched := s.redactValue(value.Str()); matched {
+			toBlock = append(toBlock, k)
+			value.SetStr(maskedValue)
 		}
 		return true
 	})
@@ -140,6 +183,28 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 	s.addMetaAttrs(ignoring, attributes, "", ignoredKeyCount)
 }
 
+// redactValue redacts the parts of the value matching the blocked values or
+// the detectors, and returns whether anything was redacted
+func (s *redaction) redactValue(value string) (string, bool) {
+	var matched bool
+	for _, compiledRE := range s.blockRegexList {
+		if compiledRE.MatchString(value) {
+			matched = true
+			value = compiledRE.ReplaceAllStringFunc(value, s.replace)
+		}
+	}
+	for _, detector := range s.detectors {
+		value = detector.re.ReplaceAllStringFunc(value, func(match string) string {
+			if detector.validate != nil && !detector.validate(match) {
+				return match
+			}
+			matched = true
+			return s.replace(match)
+		})
+	}
+	return value, matched
+}
+
 // addMetaAttrs adds diagnostic information about redacted or masked attribute keys
 func (s *redaction) addMetaAttrs(redactedAttrs []string, attributes pcommon.Map, valuesAttr, countAttr string) {
 	redactedCount := int64(len(redactedAttrs))
@@ -166,6 +231,11 @@ func (s *redaction) addMetaAttrs(redactedAttrs []string, attributes pcommon.Map,
 const (
 	debug            = "debug"
 	info             = "info"
+	maskMode         = "mask"
+	replaceMode      = "replace"
+	hashMode         = "hash"
+	tokenizeMode     = "tokenize"
+	mask             = "****"
 	redactedKeys     = "redaction.redacted.keys"
 	redactedKeyCount = "redaction.redacted.count"
 	maskedValues     = "redaction.masked.keys"
@@ -220,3 +290,69 @@ func makeBlockRegexList(_ context.Context, config *Config) (map[string]*regexp.R
 	}
 	return blockRegexList, nil
 }
+
+// makeReplacer returns the function redacting the blocked values according to
+// the redaction mode
+func makeReplacer(c *Config) func(string) string {
+	switch c.RedactionMode {
+	case replaceMode:
+		return func(string) string {
+			return c.Replacement
+		}
+	case hashMode:
+		salt := []byte(c.HashSalt)
+		return func(value string) string {
+			mac := hmac.New(sha256.New, salt)
+			mac.Write([]byte(value))
+			return hex.EncodeToString(mac.Sum(nil))
+		}
+	case tokenizeMode:
+		salt := []byte(c.HashSalt)
+		return func(value string) string {
+			return tokenize(salt, value)
+		}
+	default:
+		return func(string) string {
+			return mask
+		}
+	}
+}
+
+// tokenize replaces each digit and letter of the value by another one of the
+// same kind and case, derived from the HMAC of the value, and keeps the other
+// characters. The token keeps the format of the value, e.g. the length and
+// the separators of a credit card number, and is the same for equal values.
+func tokenize(salt []byte, value string) string {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(value))
+	stream := mac.Sum(nil)
+	var pos int
+	nextByte := func() int {
+		if pos == len(stream) {
+			// Extend the stream for the values longer than the digest
+			mac.Reset()
+			mac.Write(stream)
+			stream = mac.Sum(nil)
+			pos = 0
+		}
+		b := stream[pos]
+		pos++
+		return int(b)
+	}
+
+	var token strings.Builder
+	token.Grow(len(value))
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			token.WriteRune('0' + rune(nextByte()%10))
+		case r >= 'a' && r <= 'z':
+			token.WriteRune('a' + rune(nextByte()%26))
+		case r >= 'A' && r <= 'Z':
+			token.WriteRune('A' + rune(nextByte()%26))
+		default:
+			token.WriteRune(r)
+		}
+	}
+	return token.String()
+}
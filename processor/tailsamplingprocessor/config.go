@@ -4,8 +4,12 @@
 package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
 
 import (
+	"errors"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
@@ -226,6 +230,42 @@ type DecisionCacheConfig struct {
 	// For effective use, this value should be at least an order of magnitude higher than Config.NumTraces.
 	// If left as default 0, a no-op DecisionCache will be used.
 	SampledCacheSize int `mapstructure:"sampled_cache_size"`
+	// NonSampledCacheSize specifies the size of the cache that holds the non-sampled trace IDs.
+	// Late spans of the traces in this cache are dropped without being evaluated again.
+	// If left as default 0, a no-op DecisionCache will be used.
+	NonSampledCacheSize int `mapstructure:"non_sampled_cache_size"`
+	// Sharing configures the exchange of the decisions with the other collector replicas.
+	Sharing DecisionSharingConfig `mapstructure:"sharing"`
+}
+
+// DecisionSharingConfig configures the exchange of the sampling decisions between collector replicas,
+// so that late spans reaching a replica that didn't take the decision for their trace follow it anyway.
+// Each replica listens on Endpoint, and posts the decisions it takes to all the Peers every FlushInterval.
+// Any client reaching the endpoint can make the replica keep or drop any trace, so it must not be exposed
+// outside of the network of the replicas, and should be secured with TLS and an authenticator.
+type DecisionSharingConfig struct {
+	// ServerConfig configures the server the decisions of the peers are received on.
+	// Sharing is disabled when its endpoint is left empty.
+	confighttp.ServerConfig `mapstructure:",squash"`
+	// Client configures the client the decisions are sent to the peers with, e.g. its TLS settings,
+	// authenticator and timeout. Its endpoint is ignored.
+	Client confighttp.ClientConfig `mapstructure:"client"`
+	// Peers are the addresses of the other replicas, e.g. "collector-1:4320" or "https://collector-1:4320".
+	Peers []string `mapstructure:"peers"`
+	// FlushInterval is the interval at which the decisions are sent to the peers, default 1s.
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+}
+
+// SpillConfig configures an overflow tier for the traces waiting for a sampling decision.
+// When the memory is full, the spans of the oldest pending traces are written to a storage
+// extension instead of being dropped, and are read back when the decision is taken.
+type SpillConfig struct {
+	// StorageID is the ID of the storage extension the spans are spilled to.
+	// If left unset, no spilling happens and the oldest traces are dropped when the memory is full.
+	StorageID *component.ID `mapstructure:"storage"`
+	// MaxTraces is the number of traces that can be spilled to the storage. Once reached,
+	// the oldest spilled trace is dropped.
+	MaxTraces uint64 `mapstructure:"max_traces"`
 }
 
 // Config holds the configuration for tail-based sampling.
@@ -244,4 +284,27 @@ type Config struct {
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
 	// DecisionCache holds configuration for the decision cache(s)
 	DecisionCache DecisionCacheConfig `mapstructure:"decision_cache"`
+	// Spill holds the configuration of the overflow tier used when more than NumTraces traces are pending.
+	Spill SpillConfig `mapstructure:"spill"`
+}
+
+var _ component.ConfigValidator = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Spill.StorageID != nil && cfg.Spill.MaxTraces == 0 {
+		return errors.New("spill::max_traces must be greater than zero when a spill storage is configured")
+	}
+	if sharing := cfg.DecisionCache.Sharing; sharing.Endpoint != "" {
+		if len(sharing.Peers) == 0 {
+			return errors.New("decision_cache::sharing::peers must not be empty when an endpoint is configured")
+		}
+		if cfg.DecisionCache.SampledCacheSize <= 0 && cfg.DecisionCache.NonSampledCacheSize <= 0 {
+			return errors.New("decision_cache::sharing requires sampled_cache_size or non_sampled_cache_size to be set")
+		}
+		if sharing.FlushInterval < 0 {
+			return errors.New("decision_cache::sharing::flush_interval must not be negative")
+		}
+	}
+	return nil
 }
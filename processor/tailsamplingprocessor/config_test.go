@@ -36,7 +36,7 @@ func TestLoadConfig(t *testing.T) {
 			DecisionWait:            10 * time.Second,
 			NumTraces:               100,
 			ExpectedNewTracesPerSec: 10,
-			DecisionCache:           DecisionCacheConfig{SampledCacheSize: 500},
+			DecisionCache:           DecisionCacheConfig{SampledCacheSize: 500, NonSampledCacheSize: 1000},
 			PolicyCfgs: []PolicyCfg{
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
@@ -187,3 +187,31 @@ func TestLoadConfig(t *testing.T) {
 			},
 		})
 }
+
+func TestValidateSpillConfig(t *testing.T) {
+	storageID := component.MustNewIDWithName("file_storage", "spill")
+
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Spill.StorageID = &storageID
+	assert.EqualError(t, cfg.Validate(), "spill::max_traces must be greater than zero when a spill storage is configured")
+
+	cfg.Spill.MaxTraces = 1000
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidateDecisionSharingConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.DecisionCache.Sharing.Endpoint = "0.0.0.0:4320"
+	assert.EqualError(t, cfg.Validate(), "decision_cache::sharing::peers must not be empty when an endpoint is configured")
+
+	cfg.DecisionCache.Sharing.Peers = []string{"collector-1:4320"}
+	assert.EqualError(t, cfg.Validate(), "decision_cache::sharing requires sampled_cache_size or non_sampled_cache_size to be set")
+
+	cfg.DecisionCache.NonSampledCacheSize = 1000
+	assert.NoError(t, cfg.Validate())
+
+	cfg.DecisionCache.Sharing.FlushInterval = -time.Second
+	assert.EqualError(t, cfg.Validate(), "decision_cache::sharing::flush_interval must not be negative")
+}
@@ -38,5 +38,9 @@ func createTracesProcessor(
 	nextConsumer consumer.Traces,
 ) (processor.Traces, error) {
 	tCfg := cfg.(*Config)
-	return newTracesProcessor(ctx, params.TelemetrySettings, nextConsumer, *tCfg)
+	var opts []Option
+	if tCfg.Spill.StorageID != nil {
+		opts = append(opts, withSpillStorage(newSpillStorage(params.ID, *tCfg.Spill.StorageID)))
+	}
+	return newTracesProcessor(ctx, params.TelemetrySettings, nextConsumer, *tCfg, opts...)
 }
@@ -30,6 +30,8 @@ type TelemetryBuilder struct {
 	ProcessorTailSamplingEarlyReleasesFromCacheDecision metric.Int64Counter
 	ProcessorTailSamplingGlobalCountTracesSampled       metric.Int64Counter
 	ProcessorTailSamplingNewTraceIDReceived             metric.Int64Counter
+	ProcessorTailSamplingPeerDecisionsReceived          metric.Int64Counter
+	ProcessorTailSamplingPeerDecisionsSent              metric.Int64Counter
 	ProcessorTailSamplingSamplingDecisionLatency        metric.Int64Histogram
 	ProcessorTailSamplingSamplingDecisionTimerLatency   metric.Int64Histogram
 	ProcessorTailSamplingSamplingLateSpanAge            metric.Int64Histogram
@@ -37,6 +39,8 @@ type TelemetryBuilder struct {
 	ProcessorTailSamplingSamplingTraceDroppedTooEarly   metric.Int64Counter
 	ProcessorTailSamplingSamplingTraceRemovalAge        metric.Int64Histogram
 	ProcessorTailSamplingSamplingTracesOnMemory         metric.Int64Gauge
+	ProcessorTailSamplingSamplingTracesOnStorage        metric.Int64Gauge
+	ProcessorTailSamplingSamplingTracesSpilled          metric.Int64Counter
 	level                                               configtelemetry.Level
 }
 
@@ -93,6 +97,18 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...teleme
 		metric.WithUnit("{traces}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorTailSamplingPeerDecisionsReceived, err = builder.meter.Int64Counter(
+		"processor_tail_sampling_peer_decisions_received",
+		metric.WithDescription("Count of sampling decisions received from the peers"),
+		metric.WithUnit("{traces}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ProcessorTailSamplingPeerDecisionsSent, err = builder.meter.Int64Counter(
+		"processor_tail_sampling_peer_decisions_sent",
+		metric.WithDescription("Count of sampling decisions sent to the peers"),
+		metric.WithUnit("{traces}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ProcessorTailSamplingSamplingDecisionLatency, err = builder.meter.Int64Histogram(
 		"processor_tail_sampling_sampling_decision_latency",
 		metric.WithDescription("Latency (in microseconds) of a given sampling policy"),
@@ -135,5 +151,17 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...teleme
 		metric.WithUnit("{traces}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorTailSamplingSamplingTracesOnStorage, err = builder.meter.Int64Gauge(
+		"processor_tail_sampling_sampling_traces_on_storage",
+		metric.WithDescription("Tracks the number of traces whose spans are currently spilled to the storage"),
+		metric.WithUnit("{traces}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ProcessorTailSamplingSamplingTracesSpilled, err = builder.meter.Int64Counter(
+		"processor_tail_sampling_sampling_traces_spilled",
+		metric.WithDescription("Count of traces whose spans were spilled to the storage because the memory was full"),
+		metric.WithUnit("{traces}"),
+	)
+	errs = errors.Join(errs, err)
 	return &builder, errs
 }
@@ -24,6 +24,9 @@ type TraceData struct {
 	SpanCount *atomic.Int64
 	// ReceivedBatches stores all the batches received for the trace.
 	ReceivedBatches ptrace.Traces
+	// Spilled is set when the received batches were moved to the spill storage, in which case
+	// ReceivedBatches only holds the batches received afterwards until the trace is read back.
+	Spilled bool
 	// FinalDecision.
 	FinalDecision Decision
 }
@@ -0,0 +1,267 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sharing exchanges the sampling decisions taken by collector replicas, so that late spans
+// reaching a replica that didn't take the decision for their trace follow it anyway.
+//
+// Any client able to reach the endpoint of an exchange can make it keep or drop any trace, so the
+// endpoint must only be reachable by the other replicas, and should be secured with TLS and authentication.
+package sharing // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sharing"
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
+)
+
+// DecisionsPath is the path peers post their decisions to.
+const DecisionsPath = "/v1/decisions"
+
+// maxRequestSize is the maximum size of the body of a request sent by a peer.
+const maxRequestSize = 16 << 20
+
+var (
+	attrSampledTrue  = metric.WithAttributes(attribute.String("sampled", "true"))
+	attrSampledFalse = metric.WithAttributes(attribute.String("sampled", "false"))
+)
+
+// decisions is the body of the requests exchanged between peers, holding hex encoded trace IDs.
+type decisions struct {
+	Sampled    []string `json:"sampled,omitempty"`
+	NotSampled []string `json:"not_sampled,omitempty"`
+}
+
+// Exchange sends the decisions published to it to the configured peers, and puts the decisions
+// received from the peers in the local caches.
+type Exchange struct {
+	serverConfig  confighttp.ServerConfig
+	clientConfig  confighttp.ClientConfig
+	peers         []string
+	flushInterval time.Duration
+	settings      component.TelemetrySettings
+	logger        *zap.Logger
+	telemetry     *metadata.TelemetryBuilder
+	client        *http.Client
+
+	sampled    cache.Cache[bool]
+	notSampled cache.Cache[bool]
+
+	mu      sync.Mutex
+	pending decisions
+
+	listener net.Listener
+	server   *http.Server
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// New creates an Exchange receiving the decisions of the peers with the server configured by serverConfig,
+// and sending its decisions to peers every flushInterval with the client configured by clientConfig, whose
+// endpoint is ignored. The decisions received from the peers are put in the given local caches.
+func New(serverConfig confighttp.ServerConfig, clientConfig confighttp.ClientConfig, peers []string, flushInterval time.Duration, settings component.TelemetrySettings, telemetry *metadata.TelemetryBuilder, sampled, notSampled cache.Cache[bool]) *Exchange {
+	urls := make([]string, len(peers))
+	for i, peer := range peers {
+		if !strings.Contains(peer, "://") {
+			peer = "http://" + peer
+		}
+		urls[i] = strings.TrimSuffix(peer, "/") + DecisionsPath
+	}
+	return &Exchange{
+		serverConfig:  serverConfig,
+		clientConfig:  clientConfig,
+		peers:         urls,
+		flushInterval: flushInterval,
+		settings:      settings,
+		logger:        settings.Logger,
+		telemetry:     telemetry,
+		sampled:       sampled,
+		notSampled:    notSampled,
+		stopCh:        make(chan struct{}),
+		doneCh:        make(chan struct{}),
+	}
+}
+
+// Start starts listening for the decisions of the peers, and sending them the local ones.
+func (e *Exchange) Start(ctx context.Context, host component.Host) error {
+	client, err := e.clientConfig.ToClient(ctx, host, e.settings)
+	if err != nil {
+		return fmt.Errorf("failed to create the client of the peers: %w", err)
+	}
+	if client.Timeout == 0 {
+		client.Timeout = e.flushInterval
+	}
+
+	ln, err := e.serverConfig.ToListener(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to listen for peer decisions on %s: %w", e.serverConfig.Endpoint, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(DecisionsPath, e.handleDecisions)
+	server, err := e.serverConfig.ToServer(ctx, host, e.settings, mux)
+	if err != nil {
+		return errors.Join(err, ln.Close())
+	}
+	e.client = client
+	e.listener = ln
+	e.server = server
+	go func() {
+		if err := e.server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			e.logger.Error("Failed to serve peer decisions", zap.Error(err))
+		}
+	}()
+
+	go e.flushLoop()
+	return nil
+}
+
+// Shutdown sends the pending decisions to the peers and stops the exchange.
+func (e *Exchange) Shutdown(ctx context.Context) error {
+	if e.server == nil {
+		return nil
+	}
+	e.stopOnce.Do(func() {
+		close(e.stopCh)
+		<-e.doneCh
+		e.client.CloseIdleConnections()
+	})
+	return e.server.Shutdown(ctx)
+}
+
+// Addr returns the address the exchange listens on, once started.
+func (e *Exchange) Addr() string {
+	return e.listener.Addr().String()
+}
+
+// Publish sends a decision taken locally to the peers with the next flush. It must only be called
+// once per decision, and never for the decisions received from the peers.
+func (e *Exchange) Publish(id pcommon.TraceID, sampled bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if sampled {
+		e.pending.Sampled = append(e.pending.Sampled, hex.EncodeToString(id[:]))
+	} else {
+		e.pending.NotSampled = append(e.pending.NotSampled, hex.EncodeToString(id[:]))
+	}
+}
+
+func (e *Exchange) flushLoop() {
+	defer close(e.doneCh)
+	ticker := time.NewTicker(e.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			e.flush()
+		case <-e.stopCh:
+			e.flush()
+			return
+		}
+	}
+}
+
+// flush sends the decisions taken since the previous flush to all the peers.
+// Decisions that can't be delivered to a peer are not retried.
+func (e *Exchange) flush() {
+	e.mu.Lock()
+	pending := e.pending
+	e.pending = decisions{}
+	e.mu.Unlock()
+
+	if len(pending.Sampled) == 0 && len(pending.NotSampled) == 0 {
+		return
+	}
+	body, err := json.Marshal(pending)
+	if err != nil {
+		e.logger.Warn("Failed to encode decisions for peers", zap.Error(err))
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, peer := range e.peers {
+		wg.Add(1)
+		go func(peer string) {
+			defer wg.Done()
+			if err := e.send(peer, body); err != nil {
+				e.logger.Warn("Failed to send decisions to peer", zap.String("peer", peer), zap.Error(err))
+				return
+			}
+			ctx := context.Background()
+			e.telemetry.ProcessorTailSamplingPeerDecisionsSent.Add(ctx, int64(len(pending.Sampled)), attrSampledTrue)
+			e.telemetry.ProcessorTailSamplingPeerDecisionsSent.Add(ctx, int64(len(pending.NotSampled)), attrSampledFalse)
+		}(peer)
+	}
+	wg.Wait()
+}
+
+func (e *Exchange) send(peer string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, peer, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (e *Exchange) handleDecisions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	var received decisions
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&received); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// The received decisions are only put in the local caches, they are never published again.
+	sampled := putAll(e.sampled, received.Sampled)
+	notSampled := putAll(e.notSampled, received.NotSampled)
+	e.telemetry.ProcessorTailSamplingPeerDecisionsReceived.Add(r.Context(), int64(sampled), attrSampledTrue)
+	e.telemetry.ProcessorTailSamplingPeerDecisionsReceived.Add(r.Context(), int64(notSampled), attrSampledFalse)
+	w.WriteHeader(http.StatusOK)
+}
+
+// putAll puts the valid trace IDs in the given cache, and returns how many there were.
+func putAll(c cache.Cache[bool], ids []string) int {
+	count := 0
+	for _, s := range ids {
+		var id pcommon.TraceID
+		if len(s) != hex.EncodedLen(len(id)) {
+			continue
+		}
+		if _, err := hex.Decode(id[:], []byte(s)); err != nil {
+			continue
+		}
+		c.Put(id, true)
+		count++
+	}
+	return count
+}
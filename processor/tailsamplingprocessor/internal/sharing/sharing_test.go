@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sharing
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
+)
+
+func newTestExchange(t *testing.T, peers []string) *Exchange {
+	telemetry, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	sampled, err := cache.NewLRUDecisionCache[bool](10)
+	require.NoError(t, err)
+	notSampled, err := cache.NewLRUDecisionCache[bool](10)
+	require.NoError(t, err)
+
+	e := New(confighttp.ServerConfig{Endpoint: "127.0.0.1:0"}, confighttp.ClientConfig{}, peers, 10*time.Millisecond, componenttest.NewNopTelemetrySettings(), telemetry, sampled, notSampled)
+	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, e.Shutdown(context.Background()))
+	})
+	return e
+}
+
+func TestDecisionsAreSentToPeers(t *testing.T) {
+	receiver := newTestExchange(t, nil)
+	sender := newTestExchange(t, []string{receiver.Addr()})
+
+	sampledID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	notSampledID := pcommon.TraceID([16]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1})
+	sender.Publish(sampledID, true)
+	sender.Publish(notSampledID, false)
+
+	require.Eventually(t, func() bool {
+		_, sampledOK := receiver.sampled.Get(sampledID)
+		_, notSampledOK := receiver.notSampled.Get(notSampledID)
+		return sampledOK && notSampledOK
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, ok := receiver.sampled.Get(notSampledID)
+	assert.False(t, ok)
+
+	// the received decisions aren't sent back to the peers
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	assert.Empty(t, receiver.pending.Sampled)
+	assert.Empty(t, receiver.pending.NotSampled)
+}
+
+func TestPendingDecisionsAreSentOnShutdown(t *testing.T) {
+	receiver := newTestExchange(t, nil)
+	telemetry, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	sender := New(confighttp.ServerConfig{Endpoint: "127.0.0.1:0"}, confighttp.ClientConfig{}, []string{"http://" + receiver.Addr() + "/"}, time.Hour, componenttest.NewNopTelemetrySettings(), telemetry, cache.NewNopDecisionCache[bool](), cache.NewNopDecisionCache[bool]())
+	require.NoError(t, sender.Start(context.Background(), componenttest.NewNopHost()))
+
+	id := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	sender.Publish(id, true)
+	require.NoError(t, sender.Shutdown(context.Background()))
+
+	_, ok := receiver.sampled.Get(id)
+	assert.True(t, ok)
+}
+
+func TestInvalidDecisions(t *testing.T) {
+	receiver := newTestExchange(t, nil)
+	url := "http://" + receiver.Addr() + DecisionsPath
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	resp, err = http.Post(url, "application/json", strings.NewReader("not json"))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// malformed trace IDs are skipped
+	resp, err = http.Post(url, "application/json", strings.NewReader(`{"sampled":["01020304","zz","01020304000000000000000000000000"]}`))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	_, ok := receiver.sampled.Get(pcommon.TraceID([16]byte{1, 2, 3, 4}))
+	assert.True(t, ok)
+}
+
+func TestStartFailsOnInvalidEndpoint(t *testing.T) {
+	telemetry, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	e := New(confighttp.ServerConfig{Endpoint: "invalid:endpoint:"}, confighttp.ClientConfig{}, nil, time.Second, componenttest.NewNopTelemetrySettings(), telemetry, cache.NewNopDecisionCache[bool](), cache.NewNopDecisionCache[bool]())
+	assert.ErrorContains(t, e.Start(context.Background(), componenttest.NewNopHost()), "failed to listen for peer decisions")
+	assert.NoError(t, e.Shutdown(context.Background()))
+}
+
+func TestDecisionsAreSentOverTLS(t *testing.T) {
+	telemetry, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	serverConfig := confighttp.ServerConfig{
+		Endpoint: "127.0.0.1:0",
+		TLSSetting: &configtls.ServerConfig{
+			Config: configtls.Config{
+				CertFile: filepath.Join("testdata", "server.crt"),
+				KeyFile:  filepath.Join("testdata", "server.key"),
+			},
+		},
+	}
+	sampled, err := cache.NewLRUDecisionCache[bool](10)
+	require.NoError(t, err)
+	receiver := New(serverConfig, confighttp.ClientConfig{}, nil, time.Hour, componenttest.NewNopTelemetrySettings(), telemetry, sampled, cache.NewNopDecisionCache[bool]())
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, receiver.Shutdown(context.Background()))
+	})
+
+	// plain HTTP requests are rejected
+	resp, err := http.Post("http://"+receiver.Addr()+DecisionsPath, "application/json", strings.NewReader(`{"sampled":["01020304000000000000000000000000"]}`))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	clientConfig := confighttp.ClientConfig{
+		TLSSetting: configtls.ClientConfig{
+			Config: configtls.Config{
+				// the server certificate is self-signed
+				CAFile: filepath.Join("testdata", "server.crt"),
+			},
+			ServerName: "localhost",
+		},
+	}
+	sender := New(confighttp.ServerConfig{Endpoint: "127.0.0.1:0"}, clientConfig, []string{"https://" + receiver.Addr()}, time.Hour, componenttest.NewNopTelemetrySettings(), telemetry, cache.NewNopDecisionCache[bool](), cache.NewNopDecisionCache[bool]())
+	require.NoError(t, sender.Start(context.Background(), componenttest.NewNopHost()))
+
+	id := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	sender.Publish(id, true)
+	require.NoError(t, sender.Shutdown(context.Background()))
+
+	_, ok := receiver.sampled.Get(id)
+	assert.True(t, ok)
+}
@@ -5,6 +5,7 @@ package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"runtime"
@@ -26,6 +27,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sharing"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/telemetry"
 )
 
@@ -48,16 +50,25 @@ type tailSamplingSpanProcessor struct {
 	telemetry *metadata.TelemetryBuilder
 	logger    *zap.Logger
 
-	nextConsumer    consumer.Traces
-	maxNumTraces    uint64
-	policies        []*policy
-	idToTrace       sync.Map
-	policyTicker    timeutils.TTicker
-	tickerFrequency time.Duration
-	decisionBatcher idbatcher.Batcher
-	sampledIDCache  cache.Cache[bool]
-	deleteChan      chan pcommon.TraceID
-	numTracesOnMap  *atomic.Uint64
+	nextConsumer      consumer.Traces
+	maxNumTraces      uint64
+	policies          []*policy
+	idToTrace         sync.Map
+	policyTicker      timeutils.TTicker
+	tickerFrequency   time.Duration
+	decisionBatcher   idbatcher.Batcher
+	sampledIDCache    cache.Cache[bool]
+	nonSampledIDCache cache.Cache[bool]
+	decisionSharing   *sharing.Exchange
+	deleteChan        chan pcommon.TraceID
+	numTracesOnMap    *atomic.Uint64
+
+	// spill is the storage the spans of the oldest pending traces are moved to when the
+	// memory is full, spillChan holding the IDs of the spilled traces in the order they were spilled.
+	spill             *spillStorage
+	spillChan         chan pcommon.TraceID
+	numTracesOnSpill  *atomic.Uint64
+	maxNumTracesSpill uint64
 }
 
 // spanAndScope a structure for holding information about span and its instrumentation scope.
@@ -95,16 +106,37 @@ func newTracesProcessor(ctx context.Context, settings component.TelemetrySetting
 			return nil, err
 		}
 	}
+	nonSampledDecisions := cache.NewNopDecisionCache[bool]()
+	if cfg.DecisionCache.NonSampledCacheSize > 0 {
+		nonSampledDecisions, err = cache.NewLRUDecisionCache[bool](cfg.DecisionCache.NonSampledCacheSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var decisionSharing *sharing.Exchange
+	if sharingCfg := cfg.DecisionCache.Sharing; sharingCfg.Endpoint != "" {
+		flushInterval := sharingCfg.FlushInterval
+		if flushInterval == 0 {
+			flushInterval = time.Second
+		}
+		decisionSharing = sharing.New(sharingCfg.ServerConfig, sharingCfg.Client, sharingCfg.Peers, flushInterval, settings, telemetry, sampledDecisions, nonSampledDecisions)
+	}
 
 	tsp := &tailSamplingSpanProcessor{
-		ctx:            ctx,
-		telemetry:      telemetry,
-		nextConsumer:   nextConsumer,
-		maxNumTraces:   cfg.NumTraces,
-		sampledIDCache: sampledDecisions,
-		logger:         settings.Logger,
-		numTracesOnMap: &atomic.Uint64{},
-		deleteChan:     make(chan pcommon.TraceID, cfg.NumTraces),
+		ctx:               ctx,
+		telemetry:         telemetry,
+		nextConsumer:      nextConsumer,
+		maxNumTraces:      cfg.NumTraces,
+		sampledIDCache:    sampledDecisions,
+		nonSampledIDCache: nonSampledDecisions,
+		decisionSharing:   decisionSharing,
+		logger:            settings.Logger,
+		numTracesOnMap:    &atomic.Uint64{},
+		deleteChan:        make(chan pcommon.TraceID, cfg.NumTraces),
+
+		numTracesOnSpill:  &atomic.Uint64{},
+		maxNumTracesSpill: cfg.Spill.MaxTraces,
 	}
 	tsp.policyTicker = &timeutils.PolicyTicker{OnTickFunc: tsp.samplingPolicyOnTick}
 
@@ -116,6 +148,10 @@ func newTracesProcessor(ctx context.Context, settings component.TelemetrySetting
 		tsp.tickerFrequency = time.Second
 	}
 
+	if tsp.spill != nil {
+		tsp.spillChan = make(chan pcommon.TraceID, tsp.maxNumTracesSpill)
+	}
+
 	if tsp.policies == nil {
 		policyNames := map[string]bool{}
 		tsp.policies = make([]*policy, len(cfg.PolicyCfgs))
@@ -175,6 +211,20 @@ func withTickerFrequency(frequency time.Duration) Option {
 	}
 }
 
+// withNonSampledDecisionCache sets the cache which the processor uses to store recently non-sampled trace IDs.
+func withNonSampledDecisionCache(c cache.Cache[bool]) Option {
+	return func(tsp *tailSamplingSpanProcessor) {
+		tsp.nonSampledIDCache = c
+	}
+}
+
+// withSpillStorage sets the storage the spans of the pending traces are spilled to when the memory is full.
+func withSpillStorage(s *spillStorage) Option {
+	return func(tsp *tailSamplingSpanProcessor) {
+		tsp.spill = s
+	}
+}
+
 // withSampledDecisionCache sets the cache which the processor uses to store recently sampled trace IDs.
 func withSampledDecisionCache(c cache.Cache[bool]) Option {
 	return func(tsp *tailSamplingSpanProcessor) {
@@ -253,13 +303,21 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 			continue
 		}
 		trace := d.(*sampling.TraceData)
+		trace.Lock()
 		trace.DecisionTime = time.Now()
+		if trace.Spilled {
+			tsp.loadSpilledTrace(id, trace)
+		}
+		trace.Unlock()
 
 		decision := tsp.makeDecision(id, trace, &metrics)
 		tsp.telemetry.ProcessorTailSamplingSamplingDecisionTimerLatency.Record(tsp.ctx, int64(time.Since(startTime)/time.Microsecond))
 		tsp.telemetry.ProcessorTailSamplingSamplingTraceDroppedTooEarly.Add(tsp.ctx, metrics.idNotFoundOnMapCount)
 		tsp.telemetry.ProcessorTailSamplingSamplingPolicyEvaluationError.Add(tsp.ctx, metrics.evaluateErrorCount)
 		tsp.telemetry.ProcessorTailSamplingSamplingTracesOnMemory.Record(tsp.ctx, int64(tsp.numTracesOnMap.Load()))
+		if tsp.spill != nil {
+			tsp.telemetry.ProcessorTailSamplingSamplingTracesOnStorage.Record(tsp.ctx, int64(tsp.numTracesOnSpill.Load()))
+		}
 		tsp.telemetry.ProcessorTailSamplingGlobalCountTracesSampled.Add(tsp.ctx, 1, decisionToAttribute[decision])
 
 		// Sampled or not, remove the batches
@@ -271,6 +329,12 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 
 		if decision == sampling.Sampled {
 			tsp.releaseSampledTrace(context.Background(), id, allSpans)
+		} else {
+			tsp.nonSampledIDCache.Put(id, true)
+		}
+		// Only the decisions taken here are shared, not the ones found in the caches.
+		if tsp.decisionSharing != nil {
+			tsp.decisionSharing.Publish(id, decision == sampling.Sampled)
 		}
 	}
 
@@ -368,6 +432,10 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 			tsp.telemetry.ProcessorTailSamplingEarlyReleasesFromCacheDecision.Add(tsp.ctx, int64(len(spans)))
 			continue
 		}
+		// If the trace ID is in the non-sampled cache, short circuit the decision
+		if _, ok := tsp.nonSampledIDCache.Get(id); ok {
+			continue
+		}
 
 		lenSpans := int64(len(spans))
 		lenPolicies := len(tsp.policies)
@@ -400,7 +468,7 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 					postDeletion = true
 				default:
 					traceKeyToDrop := <-tsp.deleteChan
-					tsp.dropTrace(traceKeyToDrop, currTime)
+					tsp.evictTrace(traceKeyToDrop, currTime)
 				}
 			}
 		}
@@ -439,16 +507,125 @@ func (tsp *tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) error {
+func (tsp *tailSamplingSpanProcessor) Start(ctx context.Context, host component.Host) error {
+	if tsp.spill != nil {
+		if err := tsp.spill.start(ctx, host); err != nil {
+			return err
+		}
+	}
+	if tsp.decisionSharing != nil {
+		if err := tsp.decisionSharing.Start(ctx, host); err != nil {
+			return err
+		}
+	}
 	tsp.policyTicker.Start(tsp.tickerFrequency)
 	return nil
 }
 
 // Shutdown is invoked during service shutdown.
-func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
+func (tsp *tailSamplingSpanProcessor) Shutdown(ctx context.Context) error {
 	tsp.decisionBatcher.Stop()
 	tsp.policyTicker.Stop()
-	return nil
+	var err error
+	if tsp.decisionSharing != nil {
+		err = tsp.decisionSharing.Shutdown(ctx)
+	}
+	if tsp.spill == nil {
+		return err
+	}
+	// The pending traces are lost on shutdown, remove their spans from the storage as well.
+	for {
+		select {
+		case id := <-tsp.spillChan:
+			tsp.dropSpilledTrace(id, time.Now())
+		default:
+			return errors.Join(err, tsp.spill.shutdown(ctx))
+		}
+	}
+}
+
+// evictTrace makes room in memory for a new trace. If a spill storage is configured, the spans of
+// the evicted trace are moved to it when its decision is still pending, otherwise the trace is dropped.
+func (tsp *tailSamplingSpanProcessor) evictTrace(traceID pcommon.TraceID, deletionTime time.Time) {
+	if tsp.spill == nil || !tsp.spillTrace(traceID) {
+		tsp.dropTrace(traceID, deletionTime)
+		return
+	}
+
+	for {
+		select {
+		case tsp.spillChan <- traceID:
+			return
+		default:
+			traceKeyToDrop := <-tsp.spillChan
+			tsp.dropSpilledTrace(traceKeyToDrop, deletionTime)
+		}
+	}
+}
+
+// spillTrace writes the spans of a pending trace to the spill storage, and reports whether it did so.
+func (tsp *tailSamplingSpanProcessor) spillTrace(traceID pcommon.TraceID) bool {
+	d, ok := tsp.idToTrace.Load(traceID)
+	if !ok {
+		return false
+	}
+	trace := d.(*sampling.TraceData)
+
+	trace.Lock()
+	defer trace.Unlock()
+	// Traces already decided, or being decided, have nothing worth keeping.
+	if trace.FinalDecision != sampling.Unspecified || !trace.DecisionTime.IsZero() {
+		return false
+	}
+	if err := tsp.spill.write(tsp.ctx, traceID, trace.ReceivedBatches); err != nil {
+		tsp.logger.Warn("Failed to spill trace to the storage, dropping it", zap.Stringer("traceID", traceID), zap.Error(err))
+		return false
+	}
+	trace.ReceivedBatches = ptrace.NewTraces()
+	trace.Spilled = true
+
+	// Subtract one from numTracesOnMap per https://godoc.org/sync/atomic#AddUint64
+	tsp.numTracesOnMap.Add(^uint64(0))
+	tsp.numTracesOnSpill.Add(1)
+	tsp.telemetry.ProcessorTailSamplingSamplingTracesSpilled.Add(tsp.ctx, 1)
+	return true
+}
+
+// loadSpilledTrace reads the spilled spans of a trace back, ahead of the ones received since it was spilled.
+// The caller must hold the lock of the trace.
+func (tsp *tailSamplingSpanProcessor) loadSpilledTrace(traceID pcommon.TraceID, trace *sampling.TraceData) {
+	trace.Spilled = false
+	tsp.numTracesOnSpill.Add(^uint64(0))
+
+	spilled, err := tsp.spill.take(tsp.ctx, traceID)
+	if err != nil {
+		tsp.logger.Warn("Failed to read spilled trace from the storage, deciding on the spans received since", zap.Stringer("traceID", traceID), zap.Error(err))
+		return
+	}
+	trace.ReceivedBatches.ResourceSpans().MoveAndAppendTo(spilled.ResourceSpans())
+	trace.ReceivedBatches = spilled
+}
+
+// dropSpilledTrace removes a trace evicted from the spill storage.
+func (tsp *tailSamplingSpanProcessor) dropSpilledTrace(traceID pcommon.TraceID, deletionTime time.Time) {
+	d, ok := tsp.idToTrace.LoadAndDelete(traceID)
+	if !ok {
+		tsp.logger.Debug("Attempt to delete traceID not on table")
+		return
+	}
+	trace := d.(*sampling.TraceData)
+
+	trace.Lock()
+	if trace.Spilled {
+		trace.Spilled = false
+		tsp.numTracesOnSpill.Add(^uint64(0))
+		if err := tsp.spill.remove(tsp.ctx, traceID); err != nil {
+			tsp.logger.Warn("Failed to remove spilled trace from the storage", zap.Stringer("traceID", traceID), zap.Error(err))
+		}
+	}
+	trace.Unlock()
+
+	tsp.telemetry.ProcessorTailSamplingSamplingTraceRemovalAge.Record(tsp.ctx, int64(deletionTime.Sub(trace.ArrivalTime)/time.Second))
 }
 
 func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletionTime time.Time) {
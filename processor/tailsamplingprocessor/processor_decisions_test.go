@@ -5,11 +5,18 @@ package tailsamplingprocessor
 
 import (
 	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/otel/attribute"
@@ -400,3 +407,155 @@ func TestLateArrivingSpanUsesDecisionCache(t *testing.T) {
 	require.EqualValues(t, 1, mpe.EvaluationCount)
 	require.EqualValues(t, 2, nextConsumer.SpanCount(), "original final decision not honored")
 }
+
+func TestLateArrivingSpanUsesNonSampledDecisionCache(t *testing.T) {
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait * 10,
+		NumTraces:    defaultNumTraces,
+	}
+	nextConsumer := new(consumertest.TracesSink)
+	s := setupTestTelemetry()
+	ct := s.NewSettings().TelemetrySettings
+	idb := newSyncIDBatcher()
+
+	mpe := &mockPolicyEvaluator{}
+	policies := []*policy{
+		{name: "mock-policy-1", evaluator: mpe, attribute: metric.WithAttributes(attribute.String("policy", "mock-policy-1"))},
+	}
+
+	// Use this instead of the default no-op cache
+	c, err := cache.NewLRUDecisionCache[bool](200)
+	require.NoError(t, err)
+	p, err := newTracesProcessor(context.Background(), ct, nextConsumer, cfg, withDecisionBatcher(idb), withPolicies(policies), withNonSampledDecisionCache(c))
+	require.NoError(t, err)
+
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	traceID := uInt64ToTraceID(1)
+	mpe.NextDecision = sampling.NotSampled
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+
+	tsp := p.(*tailSamplingSpanProcessor)
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+	require.EqualValues(t, 1, mpe.EvaluationCount)
+	require.EqualValues(t, 0, nextConsumer.SpanCount())
+
+	// Drop the trace to force cache to make decision
+	tsp.dropTrace(traceID, time.Now())
+
+	// The late span is dropped as per the cached decision, without the trace being tracked again
+	mpe.NextDecision = sampling.Sampled
+	require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+	_, ok := tsp.idToTrace.Load(traceID)
+	require.False(t, ok)
+
+	tsp.policyTicker.OnTick()
+	require.EqualValues(t, 1, mpe.EvaluationCount)
+	require.EqualValues(t, 0, nextConsumer.SpanCount(), "original final decision not honored")
+}
+
+// newSharingTestProcessor returns a started processor sharing its decisions with the peers.
+func newSharingTestProcessor(t *testing.T, nextConsumer consumer.Traces, mpe *mockPolicyEvaluator, peers []string) *tailSamplingSpanProcessor {
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait * 10,
+		NumTraces:    defaultNumTraces,
+		DecisionCache: DecisionCacheConfig{
+			SampledCacheSize:    200,
+			NonSampledCacheSize: 200,
+			Sharing: DecisionSharingConfig{
+				ServerConfig:  confighttp.ServerConfig{Endpoint: "127.0.0.1:0"},
+				Peers:         peers,
+				FlushInterval: 10 * time.Millisecond,
+			},
+		},
+	}
+	policies := []*policy{
+		{name: "mock-policy-1", evaluator: mpe, attribute: metric.WithAttributes(attribute.String("policy", "mock-policy-1"))},
+	}
+	p, err := newTracesProcessor(context.Background(), componenttest.NewNopTelemetrySettings(), nextConsumer, cfg, withDecisionBatcher(newSyncIDBatcher()), withPolicies(policies))
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	})
+	return p.(*tailSamplingSpanProcessor)
+}
+
+func TestLateArrivingSpanUsesPeerDecision(t *testing.T) {
+	lateConsumer := new(consumertest.TracesSink)
+	lateEvaluator := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	late := newSharingTestProcessor(t, lateConsumer, lateEvaluator, nil)
+	deciding := newSharingTestProcessor(t, consumertest.NewNop(), &mockPolicyEvaluator{NextDecision: sampling.NotSampled}, []string{late.decisionSharing.Addr()})
+
+	// The first replica decides not to sample the trace
+	traceID := uInt64ToTraceID(1)
+	require.NoError(t, deciding.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+	deciding.policyTicker.OnTick()
+	deciding.policyTicker.OnTick()
+
+	require.Eventually(t, func() bool {
+		_, ok := late.nonSampledIDCache.Get(traceID)
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// A late span reaching the other replica follows the decision of the first one
+	require.NoError(t, late.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+	late.policyTicker.OnTick()
+	late.policyTicker.OnTick()
+	require.EqualValues(t, 0, lateEvaluator.EvaluationCount)
+	require.EqualValues(t, 0, lateConsumer.SpanCount())
+}
+
+func TestPeerDecisionsAreNotSentBack(t *testing.T) {
+	var mu sync.Mutex
+	var received []string
+	peer := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		mu.Lock()
+		received = append(received, string(body))
+		mu.Unlock()
+	}))
+	defer peer.Close()
+
+	lateConsumer := new(consumertest.TracesSink)
+	late := newSharingTestProcessor(t, lateConsumer, &mockPolicyEvaluator{NextDecision: sampling.Sampled}, []string{peer.URL})
+	deciding := newSharingTestProcessor(t, consumertest.NewNop(), &mockPolicyEvaluator{NextDecision: sampling.Sampled}, []string{late.decisionSharing.Addr()})
+
+	// The first replica samples the trace
+	peerTraceID := uInt64ToTraceID(1)
+	require.NoError(t, deciding.ConsumeTraces(context.Background(), simpleTracesWithID(peerTraceID)))
+	deciding.policyTicker.OnTick()
+	deciding.policyTicker.OnTick()
+
+	require.Eventually(t, func() bool {
+		_, ok := late.sampledIDCache.Get(peerTraceID)
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The late spans reaching the other replica are released with the decision of the first one
+	require.NoError(t, late.ConsumeTraces(context.Background(), simpleTracesWithID(peerTraceID)))
+	require.NoError(t, late.ConsumeTraces(context.Background(), simpleTracesWithID(peerTraceID)))
+	require.Equal(t, 2, lateConsumer.SpanCount())
+
+	// Only the decisions taken by the other replica are sent to its peers
+	ownTraceID := uInt64ToTraceID(2)
+	require.NoError(t, late.ConsumeTraces(context.Background(), simpleTracesWithID(ownTraceID)))
+	late.policyTicker.OnTick()
+	late.policyTicker.OnTick()
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, received, 1)
+	assert.JSONEq(t, `{"sampled":["`+ownTraceID.String()+`"]}`, received[0])
+}
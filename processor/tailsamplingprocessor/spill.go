@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var errSpillStorageNotStarted = errors.New("the spill storage client has not been started")
+
+// spillStorage persists the spans of the traces waiting for a sampling decision in a storage extension,
+// each trace being stored under its ID.
+type spillStorage struct {
+	id          component.ID
+	storageID   component.ID
+	marshaler   ptrace.ProtoMarshaler
+	unmarshaler ptrace.ProtoUnmarshaler
+
+	mu     sync.RWMutex
+	client storage.Client
+}
+
+func newSpillStorage(id component.ID, storageID component.ID) *spillStorage {
+	return &spillStorage{
+		id:        id,
+		storageID: storageID,
+	}
+}
+
+// start acquires a client from the storage extension.
+func (s *spillStorage) start(ctx context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[s.storageID]
+	if !ok {
+		return fmt.Errorf("storage extension '%s' not found", s.storageID)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return fmt.Errorf("non-storage extension '%s' found", s.storageID)
+	}
+	client, err := storageExt.GetClient(ctx, component.KindProcessor, s.id, "")
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.client = client
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *spillStorage) shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.client == nil {
+		return nil
+	}
+	err := s.client.Close(ctx)
+	s.client = nil
+	return err
+}
+
+// write stores the spans of the given trace, replacing any spans previously stored for it.
+func (s *spillStorage) write(ctx context.Context, traceID pcommon.TraceID, td ptrace.Traces) error {
+	value, err := s.marshaler.MarshalTraces(td)
+	if err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.client == nil {
+		return errSpillStorageNotStarted
+	}
+	return s.client.Set(ctx, traceID.String(), value)
+}
+
+// take reads the spans of the given trace and removes them from the storage.
+func (s *spillStorage) take(ctx context.Context, traceID pcommon.TraceID) (ptrace.Traces, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.client == nil {
+		return ptrace.Traces{}, errSpillStorageNotStarted
+	}
+
+	get := storage.GetOperation(traceID.String())
+	if err := s.client.Batch(ctx, get, storage.DeleteOperation(traceID.String())); err != nil {
+		return ptrace.Traces{}, err
+	}
+	if get.Value == nil {
+		return ptrace.Traces{}, fmt.Errorf("spans of trace %s are missing from the spill storage", traceID)
+	}
+	return s.unmarshaler.UnmarshalTraces(get.Value)
+}
+
+// remove deletes the spans of the given trace from the storage.
+func (s *spillStorage) remove(ctx context.Context, traceID pcommon.TraceID) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.client == nil {
+		return errSpillStorageNotStarted
+	}
+	return s.client.Delete(ctx, traceID.String())
+}
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func newSpillingProcessor(t *testing.T, numTraces, maxSpilledTraces uint64, next *consumertest.TracesSink) processor.Traces {
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    numTraces,
+		PolicyCfgs: []PolicyCfg{
+			{
+				sharedPolicyCfg: sharedPolicyCfg{
+					Name: "always",
+					Type: AlwaysSample,
+				},
+			},
+		},
+		Spill: SpillConfig{MaxTraces: maxSpilledTraces},
+	}
+	spill := newSpillStorage(processortest.NewNopSettings().ID, storagetest.NewStorageID("spill"))
+	p, err := newTracesProcessor(context.Background(), componenttest.NewNopTelemetrySettings(), next, cfg, withDecisionBatcher(newSyncIDBatcher()), withSpillStorage(spill))
+	require.NoError(t, err)
+	return p
+}
+
+func TestSpillTracesWhenMemoryIsFull(t *testing.T) {
+	msp := new(consumertest.TracesSink)
+	p := newSpillingProcessor(t, 2, 10, msp)
+	require.NoError(t, p.Start(context.Background(), storagetest.NewStorageHost().WithInMemoryStorageExtension("spill")))
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	traceIDs, batches := generateIDsAndBatches(4)
+	for _, batch := range batches {
+		require.NoError(t, p.ConsumeTraces(context.Background(), batch))
+	}
+
+	// the first two traces don't fit in memory anymore and are spilled
+	tsp := p.(*tailSamplingSpanProcessor)
+	assert.EqualValues(t, 2, tsp.numTracesOnMap.Load())
+	assert.EqualValues(t, 2, tsp.numTracesOnSpill.Load())
+
+	// a late span of a spilled trace is kept in memory and merged back with the spilled ones
+	late := simpleTracesWithID(traceIDs[0])
+	late.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetSpanID(uInt64ToSpanID(100))
+	require.NoError(t, p.ConsumeTraces(context.Background(), late))
+
+	tsp.policyTicker.OnTick() // the first tick always gets an empty batch
+	tsp.policyTicker.OnTick()
+
+	require.Len(t, msp.AllTraces(), 4)
+	for i, traceID := range traceIDs {
+		expectedSpans := i + 1
+		if i == 0 {
+			expectedSpans++
+		}
+		trace := findTrace(t, msp.AllTraces(), traceID)
+		assert.EqualValues(t, expectedSpans, trace.SpanCount())
+	}
+	assert.EqualValues(t, 0, tsp.numTracesOnSpill.Load())
+}
+
+func TestSpillDropsOldestSpilledTrace(t *testing.T) {
+	msp := new(consumertest.TracesSink)
+	p := newSpillingProcessor(t, 1, 1, msp)
+	require.NoError(t, p.Start(context.Background(), storagetest.NewStorageHost().WithInMemoryStorageExtension("spill")))
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	traceIDs, batches := generateIDsAndBatches(3)
+	for _, batch := range batches {
+		require.NoError(t, p.ConsumeTraces(context.Background(), batch))
+	}
+
+	tsp := p.(*tailSamplingSpanProcessor)
+	_, ok := tsp.idToTrace.Load(traceIDs[0])
+	assert.False(t, ok, "the oldest spilled trace should have been dropped")
+	assert.EqualValues(t, 1, tsp.numTracesOnMap.Load())
+	assert.EqualValues(t, 1, tsp.numTracesOnSpill.Load())
+
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+
+	require.Len(t, msp.AllTraces(), 2)
+	assert.EqualValues(t, 2, findTrace(t, msp.AllTraces(), traceIDs[1]).SpanCount())
+	assert.EqualValues(t, 3, findTrace(t, msp.AllTraces(), traceIDs[2]).SpanCount())
+}
+
+func TestSpillDoesNotKeepDecidedTraces(t *testing.T) {
+	msp := new(consumertest.TracesSink)
+	p := newSpillingProcessor(t, 1, 10, msp)
+	require.NoError(t, p.Start(context.Background(), storagetest.NewStorageHost().WithInMemoryStorageExtension("spill")))
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	traceIDs, batches := generateIDsAndBatches(2)
+	require.NoError(t, p.ConsumeTraces(context.Background(), batches[0]))
+
+	tsp := p.(*tailSamplingSpanProcessor)
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+	require.Len(t, msp.AllTraces(), 1)
+
+	// the first trace is already decided when it gets evicted, so it is dropped rather than spilled
+	for _, batch := range batches[1:] {
+		require.NoError(t, p.ConsumeTraces(context.Background(), batch))
+	}
+	_, ok := tsp.idToTrace.Load(traceIDs[0])
+	assert.False(t, ok)
+	assert.EqualValues(t, 0, tsp.numTracesOnSpill.Load())
+}
+
+func TestSpillStorageNotFound(t *testing.T) {
+	p := newSpillingProcessor(t, 1, 1, new(consumertest.TracesSink))
+	assert.ErrorContains(t, p.Start(context.Background(), storagetest.NewStorageHost()), "storage extension 'test_storage/spill' not found")
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestSpillStorageIsEmptiedOnShutdown(t *testing.T) {
+	dir := t.TempDir()
+	p := newSpillingProcessor(t, 1, 10, new(consumertest.TracesSink))
+	require.NoError(t, p.Start(context.Background(), storagetest.NewStorageHost().WithFileBackedStorageExtension("spill", dir)))
+
+	traceIDs, batches := generateIDsAndBatches(2)
+	for _, batch := range batches {
+		require.NoError(t, p.ConsumeTraces(context.Background(), batch))
+	}
+	tsp := p.(*tailSamplingSpanProcessor)
+	require.EqualValues(t, 1, tsp.numTracesOnSpill.Load())
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	// the spans of the pending traces are not left behind in the storage
+	client := storagetest.NewFileBackedClient(component.KindProcessor, tsp.spill.id, "", dir)
+	value, err := client.Get(context.Background(), traceIDs[0].String())
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	assert.NoError(t, client.Close(context.Background()))
+}
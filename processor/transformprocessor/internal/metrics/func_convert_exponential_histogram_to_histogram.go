@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+)
+
+// the scales allowed by the OpenTelemetry SDKs for exponential histograms
+const (
+	minExponentialScale = -10
+	maxExponentialScale = 20
+)
+
+type convertExponentialHistogramToHistogramArguments struct {
+	Scale int64
+}
+
+func newConvertExponentialHistogramToHistogramFactory() ottl.Factory[ottlmetric.TransformContext] {
+	return ottl.NewFactory("convert_exponential_histogram_to_histogram", &convertExponentialHistogramToHistogramArguments{}, createConvertExponentialHistogramToHistogramFunction)
+}
+
+func createConvertExponentialHistogramToHistogramFunction(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[ottlmetric.TransformContext], error) {
+	args, ok := oArgs.(*convertExponentialHistogramToHistogramArguments)
+
+	if !ok {
+		return nil, fmt.Errorf("convertExponentialHistogramToHistogramFactory args must be of type *convertExponentialHistogramToHistogramArguments")
+	}
+
+	return convertExponentialHistogramToHistogram(args.Scale)
+}
+
+func convertExponentialHistogramToHistogram(scale int64) (ottl.ExprFunc[ottlmetric.TransformContext], error) {
+	if scale < minExponentialScale || scale > maxExponentialScale {
+		return nil, fmt.Errorf("convert_exponential_histogram_to_histogram scale must be between %d and %d, got %d", minExponentialScale, maxExponentialScale, scale)
+	}
+	return func(_ context.Context, tCtx ottlmetric.TransformContext) (any, error) {
+		metric := tCtx.GetMetric()
+		if metric.Type() != pmetric.MetricTypeExponentialHistogram {
+			return nil, nil
+		}
+
+		expHistogram := metric.ExponentialHistogram()
+		histogram := pmetric.NewHistogram()
+		histogram.SetAggregationTemporality(expHistogram.AggregationTemporality())
+
+		expDps := expHistogram.DataPoints()
+		for i := 0; i < expDps.Len(); i++ {
+			expDp := expDps.At(i)
+			dp := histogram.DataPoints().AppendEmpty()
+			expDp.Attributes().CopyTo(dp.Attributes())
+			dp.SetStartTimestamp(expDp.StartTimestamp())
+			dp.SetTimestamp(expDp.Timestamp())
+			dp.SetFlags(expDp.Flags())
+			dp.SetCount(expDp.Count())
+			if expDp.HasSum() {
+				dp.SetSum(expDp.Sum())
+			}
+			if expDp.HasMin() {
+				dp.SetMin(expDp.Min())
+			}
+			if expDp.HasMax() {
+				dp.SetMax(expDp.Max())
+			}
+			expDp.Exemplars().CopyTo(dp.Exemplars())
+
+			buckets := newExplicitBucketsFromExponential(expDp, int32(scale))
+			dp.ExplicitBounds().FromRaw(buckets.bounds)
+			dp.BucketCounts().FromRaw(buckets.counts)
+		}
+
+		histogram.MoveTo(metric.SetEmptyHistogram())
+		return nil, nil
+	}, nil
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+)
+
+func getTestExponentialHistogramMetricWithBuckets() pmetric.Metric {
+	metric := getTestExponentialHistogramMetric()
+	dp := metric.ExponentialHistogram().DataPoints().At(0)
+	dp.SetCount(14)
+	dp.SetZeroCount(1)
+	dp.Positive().BucketCounts().FromRaw([]uint64{1, 2, 3, 4})
+	dp.Negative().SetOffset(1)
+	dp.Negative().BucketCounts().FromRaw([]uint64{3})
+	dp.SetMin(-3)
+	dp.SetMax(3.5)
+	return metric
+}
+
+func Test_convertExponentialHistogramToHistogram(t *testing.T) {
+	tests := []struct {
+		name  string
+		input pmetric.Metric
+		scale int64
+		want  func(pmetric.Metric)
+	}{
+		{
+			name:  "convert exponential histogram with a lower scale",
+			input: getTestExponentialHistogramMetricWithBuckets(),
+			scale: 0,
+			want: func(metric pmetric.Metric) {
+				metric.SetName("exponential_histogram_metric")
+				metric.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+				dp := metric.Histogram().DataPoints().AppendEmpty()
+				getTestAttributes().CopyTo(dp.Attributes())
+				dp.SetCount(14)
+				dp.SetSum(12.34)
+				dp.SetMin(-3)
+				dp.SetMax(3.5)
+				dp.ExplicitBounds().FromRaw([]float64{-1, 0, 1, 2, 4})
+				dp.BucketCounts().FromRaw([]uint64{3, 1, 0, 3, 7, 0})
+			},
+		},
+		{
+			name:  "convert exponential histogram keeping its scale",
+			input: getTestExponentialHistogramMetricWithBuckets(),
+			scale: 20,
+			want: func(metric pmetric.Metric) {
+				metric.SetName("exponential_histogram_metric")
+				metric.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+				dp := metric.Histogram().DataPoints().AppendEmpty()
+				getTestAttributes().CopyTo(dp.Attributes())
+				dp.SetCount(14)
+				dp.SetSum(12.34)
+				dp.SetMin(-3)
+				dp.SetMax(3.5)
+				dp.ExplicitBounds().FromRaw([]float64{-math.Exp2(0.5), 0, 1, math.Exp2(0.5), 2, math.Exp2(1.5), 4})
+				dp.BucketCounts().FromRaw([]uint64{3, 1, 0, 1, 2, 3, 4, 0})
+			},
+		},
+		{
+			name:  "noop for histogram",
+			input: getTestHistogramMetric(),
+			scale: 0,
+			want: func(metric pmetric.Metric) {
+				getTestHistogramMetric().CopyTo(metric)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metric := pmetric.NewMetric()
+			tt.input.CopyTo(metric)
+
+			ctx := ottlmetric.NewTransformContext(metric, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource(), pmetric.NewScopeMetrics(), pmetric.NewResourceMetrics())
+
+			exprFunc, err := convertExponentialHistogramToHistogram(tt.scale)
+			require.NoError(t, err)
+
+			_, err = exprFunc(nil, ctx)
+			assert.NoError(t, err)
+
+			expected := pmetric.NewMetric()
+			tt.want(expected)
+
+			assert.Equal(t, expected, metric)
+		})
+	}
+}
+
+func Test_convertExponentialHistogramToHistogram_validation(t *testing.T) {
+	_, err := convertExponentialHistogramToHistogram(21)
+	assert.ErrorContains(t, err, "scale must be between -10 and 20")
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+)
+
+const quantileAttributeKey = "quantile"
+
+type extractQuantileMetricArguments struct {
+	Quantiles []float64
+}
+
+func newExtractQuantileMetricFactory() ottl.Factory[ottlmetric.TransformContext] {
+	return ottl.NewFactory("extract_quantile_metric", &extractQuantileMetricArguments{}, createExtractQuantileMetricFunction)
+}
+
+func createExtractQuantileMetricFunction(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[ottlmetric.TransformContext], error) {
+	args, ok := oArgs.(*extractQuantileMetricArguments)
+
+	if !ok {
+		return nil, fmt.Errorf("extractQuantileMetricFactory args must be of type *extractQuantileMetricArguments")
+	}
+
+	return extractQuantileMetric(args.Quantiles)
+}
+
+func extractQuantileMetric(quantiles []float64) (ottl.ExprFunc[ottlmetric.TransformContext], error) {
+	if len(quantiles) == 0 {
+		return nil, fmt.Errorf("extract_quantile_metric requires at least one quantile")
+	}
+	for _, q := range quantiles {
+		if !(q >= 0 && q <= 1) {
+			return nil, fmt.Errorf("extract_quantile_metric quantiles must be between 0 and 1, got %v", q)
+		}
+	}
+	return func(_ context.Context, tCtx ottlmetric.TransformContext) (any, error) {
+		metric := tCtx.GetMetric()
+
+		gaugeMetric := pmetric.NewMetric()
+		gaugeMetric.SetDescription(metric.Description())
+		gaugeMetric.SetName(metric.Name() + "_quantile")
+		gaugeMetric.SetUnit(metric.Unit())
+		gaugeDps := gaugeMetric.SetEmptyGauge().DataPoints()
+
+		switch metric.Type() {
+		case pmetric.MetricTypeHistogram:
+			dataPoints := metric.Histogram().DataPoints()
+			for i := 0; i < dataPoints.Len(); i++ {
+				dataPoint := dataPoints.At(i)
+				addQuantileDataPoints(dataPoint, newExplicitBuckets(dataPoint), quantiles, gaugeDps)
+			}
+		case pmetric.MetricTypeExponentialHistogram:
+			dataPoints := metric.ExponentialHistogram().DataPoints()
+			for i := 0; i < dataPoints.Len(); i++ {
+				dataPoint := dataPoints.At(i)
+				addQuantileDataPoints(dataPoint, newExplicitBucketsFromExponential(dataPoint, dataPoint.Scale()), quantiles, gaugeDps)
+			}
+		default:
+			return nil, fmt.Errorf("extract_quantile_metric requires an input metric of type Histogram or ExponentialHistogram, got %s", metric.Type())
+		}
+
+		if gaugeDps.Len() > 0 {
+			gaugeMetric.MoveTo(tCtx.GetMetrics().AppendEmpty())
+		}
+
+		return nil, nil
+	}, nil
+}
+
+func addQuantileDataPoints(dataPoint SumCountDataPoint, buckets explicitBuckets, quantiles []float64, destination pmetric.NumberDataPointSlice) {
+	for _, q := range quantiles {
+		value, ok := buckets.quantile(q)
+		if !ok {
+			continue
+		}
+		newDp := destination.AppendEmpty()
+		dataPoint.Attributes().CopyTo(newDp.Attributes())
+		newDp.Attributes().PutDouble(quantileAttributeKey, q)
+		newDp.SetDoubleValue(value)
+		newDp.SetStartTimestamp(dataPoint.StartTimestamp())
+		newDp.SetTimestamp(dataPoint.Timestamp())
+	}
+}
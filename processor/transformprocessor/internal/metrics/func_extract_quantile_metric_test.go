@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+)
+
+func addTestQuantileDataPoint(dps pmetric.NumberDataPointSlice, q float64, value float64) {
+	dp := dps.AppendEmpty()
+	getTestAttributes().CopyTo(dp.Attributes())
+	dp.Attributes().PutDouble("quantile", q)
+	dp.SetDoubleValue(value)
+}
+
+func Test_extractQuantileMetric(t *testing.T) {
+	histogramWithMinMax := getTestHistogramMetric()
+	histogramWithMinMax.Histogram().DataPoints().At(0).SetMin(0)
+	histogramWithMinMax.Histogram().DataPoints().At(0).SetMax(3)
+
+	tests := []struct {
+		name      string
+		input     pmetric.Metric
+		quantiles []float64
+		want      func(pmetric.MetricSlice)
+		wantErr   error
+	}{
+		{
+			name:      "histogram",
+			input:     histogramWithMinMax,
+			quantiles: []float64{0, 0.5, 1},
+			want: func(metrics pmetric.MetricSlice) {
+				histogramWithMinMax.CopyTo(metrics.AppendEmpty())
+				gaugeMetric := metrics.AppendEmpty()
+				gaugeMetric.SetName("histogram_metric_quantile")
+				dps := gaugeMetric.SetEmptyGauge().DataPoints()
+				addTestQuantileDataPoint(dps, 0, 0)
+				addTestQuantileDataPoint(dps, 0.5, 4.0/3)
+				addTestQuantileDataPoint(dps, 1, 3)
+			},
+		},
+		{
+			name:      "histogram without min and max",
+			input:     getTestHistogramMetric(),
+			quantiles: []float64{0.1, 0.9},
+			want: func(metrics pmetric.MetricSlice) {
+				getTestHistogramMetric().CopyTo(metrics.AppendEmpty())
+				gaugeMetric := metrics.AppendEmpty()
+				gaugeMetric.SetName("histogram_metric_quantile")
+				dps := gaugeMetric.SetEmptyGauge().DataPoints()
+				addTestQuantileDataPoint(dps, 0.1, 1)
+				addTestQuantileDataPoint(dps, 0.9, 1)
+			},
+		},
+		{
+			name:      "exponential histogram",
+			input:     getTestExponentialHistogramMetricWithBuckets(),
+			quantiles: []float64{0, 0.5, 1},
+			want: func(metrics pmetric.MetricSlice) {
+				getTestExponentialHistogramMetricWithBuckets().CopyTo(metrics.AppendEmpty())
+				gaugeMetric := metrics.AppendEmpty()
+				gaugeMetric.SetName("exponential_histogram_metric_quantile")
+				dps := gaugeMetric.SetEmptyGauge().DataPoints()
+				addTestQuantileDataPoint(dps, 0, -3)
+				addTestQuantileDataPoint(dps, 0.5, 2)
+				addTestQuantileDataPoint(dps, 1, 3.5)
+			},
+		},
+		{
+			name:      "empty exponential histogram",
+			input:     getTestExponentialHistogramMetric(),
+			quantiles: []float64{0.5},
+			want: func(metrics pmetric.MetricSlice) {
+				getTestExponentialHistogramMetric().CopyTo(metrics.AppendEmpty())
+			},
+		},
+		{
+			name:      "summary (error)",
+			input:     getTestSummaryMetric(),
+			quantiles: []float64{0.5},
+			wantErr:   fmt.Errorf("extract_quantile_metric requires an input metric of type Histogram or ExponentialHistogram, got Summary"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualMetrics := pmetric.NewMetricSlice()
+			tt.input.CopyTo(actualMetrics.AppendEmpty())
+
+			evaluate, err := extractQuantileMetric(tt.quantiles)
+			require.NoError(t, err)
+
+			_, err = evaluate(nil, ottlmetric.NewTransformContext(tt.input, actualMetrics, pcommon.NewInstrumentationScope(), pcommon.NewResource(), pmetric.NewScopeMetrics(), pmetric.NewResourceMetrics()))
+			assert.Equal(t, tt.wantErr, err)
+
+			if tt.want != nil {
+				expected := pmetric.NewMetricSlice()
+				tt.want(expected)
+				assert.Equal(t, expected, actualMetrics)
+			}
+		})
+	}
+}
+
+func Test_extractQuantileMetric_validation(t *testing.T) {
+	_, err := extractQuantileMetric([]float64{1.5})
+	assert.ErrorContains(t, err, "quantiles must be between 0 and 1")
+
+	_, err = extractQuantileMetric(nil)
+	assert.ErrorContains(t, err, "requires at least one quantile")
+}
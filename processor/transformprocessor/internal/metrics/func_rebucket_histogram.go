@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+)
+
+type rebucketHistogramArguments struct {
+	Bounds []float64
+}
+
+func newRebucketHistogramFactory() ottl.Factory[ottlmetric.TransformContext] {
+	return ottl.NewFactory("rebucket_histogram", &rebucketHistogramArguments{}, createRebucketHistogramFunction)
+}
+
+func createRebucketHistogramFunction(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[ottlmetric.TransformContext], error) {
+	args, ok := oArgs.(*rebucketHistogramArguments)
+
+	if !ok {
+		return nil, fmt.Errorf("rebucketHistogramFactory args must be of type *rebucketHistogramArguments")
+	}
+
+	return rebucketHistogram(args.Bounds)
+}
+
+func rebucketHistogram(bounds []float64) (ottl.ExprFunc[ottlmetric.TransformContext], error) {
+	if err := validateBounds(bounds); err != nil {
+		return nil, fmt.Errorf("invalid rebucket_histogram bounds: %w", err)
+	}
+	return func(_ context.Context, tCtx ottlmetric.TransformContext) (any, error) {
+		metric := tCtx.GetMetric()
+		if metric.Type() != pmetric.MetricTypeHistogram {
+			return nil, nil
+		}
+
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			counts := newExplicitBuckets(dp).rebucket(bounds)
+			dp.ExplicitBounds().FromRaw(bounds)
+			dp.BucketCounts().FromRaw(counts)
+		}
+		return nil, nil
+	}, nil
+}
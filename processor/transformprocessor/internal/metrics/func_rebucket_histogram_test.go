@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+)
+
+func Test_rebucketHistogram(t *testing.T) {
+	histogramWithMinMax := getTestHistogramMetric()
+	histogramWithMinMax.Histogram().DataPoints().At(0).SetMin(0)
+	histogramWithMinMax.Histogram().DataPoints().At(0).SetMax(3)
+
+	tests := []struct {
+		name   string
+		input  pmetric.Metric
+		bounds []float64
+		want   func(pmetric.Metric)
+	}{
+		{
+			name:   "rebucket histogram",
+			input:  getTestHistogramMetric(),
+			bounds: []float64{0.5, 1.0, 2.0},
+			want: func(metric pmetric.Metric) {
+				getTestHistogramMetric().CopyTo(metric)
+				dp := metric.Histogram().DataPoints().At(0)
+				dp.ExplicitBounds().FromRaw([]float64{0.5, 1.0, 2.0})
+				dp.BucketCounts().FromRaw([]uint64{0, 2, 3, 0})
+			},
+		},
+		{
+			name:   "rebucket histogram using min and max",
+			input:  histogramWithMinMax,
+			bounds: []float64{0.5, 1.0, 2.0},
+			want: func(metric pmetric.Metric) {
+				histogramWithMinMax.CopyTo(metric)
+				dp := metric.Histogram().DataPoints().At(0)
+				dp.ExplicitBounds().FromRaw([]float64{0.5, 1.0, 2.0})
+				dp.BucketCounts().FromRaw([]uint64{1, 1, 2, 1})
+			},
+		},
+		{
+			name:   "merge buckets",
+			input:  getTestHistogramMetric(),
+			bounds: []float64{},
+			want: func(metric pmetric.Metric) {
+				getTestHistogramMetric().CopyTo(metric)
+				dp := metric.Histogram().DataPoints().At(0)
+				dp.ExplicitBounds().FromRaw([]float64{})
+				dp.BucketCounts().FromRaw([]uint64{5})
+			},
+		},
+		{
+			name:   "noop for exponential histogram",
+			input:  getTestExponentialHistogramMetric(),
+			bounds: []float64{1.0},
+			want: func(metric pmetric.Metric) {
+				getTestExponentialHistogramMetric().CopyTo(metric)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metric := pmetric.NewMetric()
+			tt.input.CopyTo(metric)
+
+			ctx := ottlmetric.NewTransformContext(metric, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource(), pmetric.NewScopeMetrics(), pmetric.NewResourceMetrics())
+
+			exprFunc, err := rebucketHistogram(tt.bounds)
+			require.NoError(t, err)
+
+			_, err = exprFunc(nil, ctx)
+			assert.NoError(t, err)
+
+			expected := pmetric.NewMetric()
+			tt.want(expected)
+
+			assert.Equal(t, expected, metric)
+		})
+	}
+}
+
+func Test_rebucketHistogram_validation(t *testing.T) {
+	_, err := rebucketHistogram([]float64{2.0, 1.0})
+	assert.ErrorContains(t, err, "bounds must be strictly increasing")
+}
@@ -49,6 +49,9 @@ func MetricFunctions() map[string]ottl.Factory[ottlmetric.TransformContext] {
 		newExtractSumMetricFactory(),
 		newExtractCountMetricFactory(),
 		newCopyMetricFactory(),
+		newRebucketHistogramFactory(),
+		newConvertExponentialHistogramToHistogramFactory(),
+		newExtractQuantileMetricFactory(),
 	)
 
 	if useConvertBetweenSumAndGaugeMetricContext.IsEnabled() {
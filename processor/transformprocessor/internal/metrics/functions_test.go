@@ -37,6 +37,9 @@ func Test_MetricFunctions(t *testing.T) {
 	expected["extract_sum_metric"] = newExtractSumMetricFactory()
 	expected["extract_count_metric"] = newExtractCountMetricFactory()
 	expected["copy_metric"] = newCopyMetricFactory()
+	expected["rebucket_histogram"] = newRebucketHistogramFactory()
+	expected["convert_exponential_histogram_to_histogram"] = newConvertExponentialHistogramToHistogramFactory()
+	expected["extract_quantile_metric"] = newExtractQuantileMetricFactory()
 
 	defer testutil.SetFeatureGateForTest(t, useConvertBetweenSumAndGaugeMetricContext, true)()
 	actual := MetricFunctions()
@@ -0,0 +1,238 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"fmt"
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// explicitBuckets is the explicit bucket representation of a histogram data point,
+// counts[i] being the number of observations in (bounds[i-1], bounds[i]].
+// Observations are assumed to be evenly spread within a bucket, the min and max
+// of the data point being used, when known, to narrow the first and last buckets.
+type explicitBuckets struct {
+	bounds []float64
+	counts []uint64
+	min    float64
+	max    float64
+	hasMin bool
+	hasMax bool
+}
+
+func newExplicitBuckets(dp pmetric.HistogramDataPoint) explicitBuckets {
+	return explicitBuckets{
+		bounds: dp.ExplicitBounds().AsRaw(),
+		counts: dp.BucketCounts().AsRaw(),
+		min:    dp.Min(),
+		max:    dp.Max(),
+		hasMin: dp.HasMin(),
+		hasMax: dp.HasMax(),
+	}
+}
+
+// newExplicitBucketsFromExponential converts the buckets of an exponential histogram data point to explicit buckets.
+// Buckets of data points with a scale higher than the given one are first merged down to that scale.
+func newExplicitBucketsFromExponential(dp pmetric.ExponentialHistogramDataPoint, scale int32) explicitBuckets {
+	var shift int32
+	if dp.Scale() > scale {
+		shift = dp.Scale() - scale
+	}
+	scale = dp.Scale() - shift
+
+	type bucket struct {
+		lower, upper float64
+		count        uint64
+	}
+	var buckets []bucket
+
+	negOffset, negCounts := downscaleBuckets(dp.Negative(), shift)
+	for i := len(negCounts) - 1; i >= 0; i-- {
+		index := negOffset + int32(i)
+		buckets = append(buckets, bucket{
+			lower: -exponentialLowerBoundary(index+1, scale),
+			upper: -exponentialLowerBoundary(index, scale),
+			count: negCounts[i],
+		})
+	}
+	if dp.ZeroCount() > 0 {
+		buckets = append(buckets, bucket{
+			// 0 - threshold rather than -threshold, to not get a -0 bound for a zero threshold
+			lower: 0 - dp.ZeroThreshold(),
+			upper: dp.ZeroThreshold(),
+			count: dp.ZeroCount(),
+		})
+	}
+	posOffset, posCounts := downscaleBuckets(dp.Positive(), shift)
+	for i, count := range posCounts {
+		index := posOffset + int32(i)
+		buckets = append(buckets, bucket{
+			lower: exponentialLowerBoundary(index, scale),
+			upper: exponentialLowerBoundary(index+1, scale),
+			count: count,
+		})
+	}
+
+	eb := explicitBuckets{
+		min:    dp.Min(),
+		max:    dp.Max(),
+		hasMin: dp.HasMin(),
+		hasMax: dp.HasMax(),
+	}
+	for i, b := range buckets {
+		if i == 0 {
+			eb.bounds = append(eb.bounds, b.upper)
+			eb.counts = append(eb.counts, b.count)
+			continue
+		}
+		last := eb.bounds[len(eb.bounds)-1]
+		if b.lower > last {
+			// keep the gap between two non-adjacent buckets as an empty bucket
+			eb.bounds = append(eb.bounds, b.lower)
+			eb.counts = append(eb.counts, 0)
+			last = b.lower
+		}
+		if b.upper > last {
+			eb.bounds = append(eb.bounds, b.upper)
+			eb.counts = append(eb.counts, b.count)
+		} else {
+			eb.counts[len(eb.counts)-1] += b.count
+		}
+	}
+	// overflow bucket
+	eb.counts = append(eb.counts, 0)
+	return eb
+}
+
+// downscaleBuckets merges exponential histogram buckets so that 2^shift buckets become one.
+func downscaleBuckets(buckets pmetric.ExponentialHistogramDataPointBuckets, shift int32) (int32, []uint64) {
+	counts := buckets.BucketCounts()
+	if counts.Len() == 0 {
+		return 0, nil
+	}
+	offset := buckets.Offset() >> shift
+	var merged []uint64
+	for i := 0; i < counts.Len(); i++ {
+		index := int((buckets.Offset()+int32(i))>>shift - offset)
+		for len(merged) <= index {
+			merged = append(merged, 0)
+		}
+		merged[index] += counts.At(i)
+	}
+	return offset, merged
+}
+
+// exponentialLowerBoundary returns the lower boundary of the bucket with the given index at the given scale.
+func exponentialLowerBoundary(index int32, scale int32) float64 {
+	return math.Exp2(math.Ldexp(float64(index), -int(scale)))
+}
+
+// bucketRange returns the range of the values of the i-th bucket, narrowed by the min and max when known.
+func (eb explicitBuckets) bucketRange(i int) (float64, float64) {
+	lower, upper := math.Inf(-1), math.Inf(1)
+	if i > 0 {
+		lower = eb.bounds[i-1]
+	}
+	if i < len(eb.bounds) {
+		upper = eb.bounds[i]
+	}
+	if eb.hasMin && eb.min > lower {
+		lower = math.Min(eb.min, upper)
+	}
+	if eb.hasMax && eb.max < upper {
+		upper = math.Max(eb.max, lower)
+	}
+	return lower, upper
+}
+
+func (eb explicitBuckets) total() uint64 {
+	var total uint64
+	for _, count := range eb.counts {
+		total += count
+	}
+	return total
+}
+
+// cumulativeCount estimates the number of observations less than or equal to x.
+// Observations of a bucket without a finite lower bound are assumed to be at its upper bound,
+// and observations of a bucket without a finite upper bound just above its lower bound.
+func (eb explicitBuckets) cumulativeCount(x float64) float64 {
+	var cumulative float64
+	for i, count := range eb.counts {
+		lower, upper := eb.bucketRange(i)
+		if x >= upper {
+			cumulative += float64(count)
+			continue
+		}
+		switch {
+		case x <= lower, math.IsInf(lower, -1):
+			return cumulative
+		case math.IsInf(upper, 1):
+			return cumulative + float64(count)
+		default:
+			return cumulative + float64(count)*(x-lower)/(upper-lower)
+		}
+	}
+	return cumulative
+}
+
+// rebucket redistributes the observations onto the given bounds.
+func (eb explicitBuckets) rebucket(bounds []float64) []uint64 {
+	total := eb.total()
+	counts := make([]uint64, 0, len(bounds)+1)
+	var previous uint64
+	for _, bound := range bounds {
+		cumulative := uint64(math.Round(eb.cumulativeCount(bound)))
+		cumulative = min(max(cumulative, previous), total)
+		counts = append(counts, cumulative-previous)
+		previous = cumulative
+	}
+	return append(counts, total-previous)
+}
+
+// quantile estimates the value below which the given fraction of the observations fall.
+// It returns false if there are no observations, or if they are all in a bucket with no finite bound.
+func (eb explicitBuckets) quantile(q float64) (float64, bool) {
+	total := eb.total()
+	if total == 0 {
+		return 0, false
+	}
+	rank := q * float64(total)
+	var cumulative float64
+	for i, count := range eb.counts {
+		if count == 0 {
+			continue
+		}
+		if cumulative+float64(count) < rank {
+			cumulative += float64(count)
+			continue
+		}
+		lower, upper := eb.bucketRange(i)
+		switch {
+		case math.IsInf(lower, -1) && math.IsInf(upper, 1):
+			return 0, false
+		case math.IsInf(lower, -1):
+			return upper, true
+		case math.IsInf(upper, 1):
+			return lower, true
+		default:
+			return lower + (upper-lower)*(rank-cumulative)/float64(count), true
+		}
+	}
+	return 0, false
+}
+
+func validateBounds(bounds []float64) error {
+	for i, bound := range bounds {
+		if math.IsNaN(bound) || math.IsInf(bound, 0) {
+			return fmt.Errorf("bounds must be finite, got %v", bound)
+		}
+		if i > 0 && bound <= bounds[i-1] {
+			return fmt.Errorf("bounds must be strictly increasing, got %v after %v", bound, bounds[i-1])
+		}
+	}
+	return nil
+}
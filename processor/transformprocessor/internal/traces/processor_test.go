@@ -435,6 +435,44 @@ func Test_ProcessTraces_SpanEventContext(t *testing.T) {
 	}
 }
 
+func Test_ProcessTraces_SpanLinkContext(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      func(td ptrace.Traces)
+	}{
+		{
+			statement: `set(attributes["test"], "pass") where dropped_attributes_count == 4`,
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Links().At(0).Attributes().PutStr("test", "pass")
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Links().At(1).Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["span.name"], span.name)`,
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Links().At(0).Attributes().PutStr("span.name", "operationB")
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Links().At(1).Attributes().PutStr("span.name", "operationB")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			td := constructTraces()
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "spanlink", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings())
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessTraces(context.Background(), td)
+			assert.NoError(t, err)
+
+			exTd := constructTraces()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
 func Test_ProcessTraces_MixContext(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -569,6 +607,9 @@ func Test_ProcessTraces_Error(t *testing.T) {
 		{
 			context: "spanevent",
 		},
+		{
+			context: "spanlink",
+		},
 	}
 
 	for _, tt := range tests {
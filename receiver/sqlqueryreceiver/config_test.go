@@ -134,6 +134,44 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			fname: "config-logs-tracking-columns.yaml",
+			id:    component.NewIDWithName(metadata.Type, ""),
+			expected: &Config{
+				Config: sqlquery.Config{
+					ControllerConfig: scraperhelper.ControllerConfig{
+						CollectionInterval: 10 * time.Second,
+						InitialDelay:       time.Second,
+					},
+					Driver:     "mydriver",
+					DataSource: "host=localhost port=5432 user=me password=s3cr3t sslmode=disable",
+					Queries: []sqlquery.Query{
+						{
+							SQL:                 "select * from test_logs where (log_time, log_id) > (?, ?) order by log_time, log_id",
+							CollectionInterval:  time.Minute,
+							TrackingColumns:     []string{"log_time", "log_id"},
+							TrackingStartValues: []string{"2024-01-01T00:00:00Z", "0"},
+							TrackingRetention:   24 * time.Hour,
+							Logs: []sqlquery.LogsCfg{
+								{
+									BodyColumn: "log_body",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			fname:        "config-logs-invalid-tracking-columns.yaml",
+			id:           component.NewIDWithName(metadata.Type, ""),
+			errorMessage: "'tracking_column' and 'tracking_columns' cannot be both set",
+		},
+		{
+			fname:        "config-logs-invalid-tracking-start-values.yaml",
+			id:           component.NewIDWithName(metadata.Type, ""),
+			errorMessage: "'tracking_start_values' must have one value per tracking column, got 1 values for 2 columns",
+		},
 		{
 			fname:        "config-logs-missing-body-column.yaml",
 			id:           component.NewIDWithName(metadata.Type, ""),
@@ -3,9 +3,14 @@
 package metadata
 
 import (
-	"go.opentelemetry.io/collector/component"
+	"errors"
+
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
 )
 
 func Meter(settings component.TelemetrySettings) metric.Meter {
@@ -15,3 +20,57 @@ func Meter(settings component.TelemetrySettings) metric.Meter {
 func Tracer(settings component.TelemetrySettings) trace.Tracer {
 	return settings.TracerProvider.Tracer("otelcol/sqlqueryreceiver")
 }
+
+// TelemetryBuilder provides an interface for components to report telemetry
+// as defined in metadata and user config.
+type TelemetryBuilder struct {
+	meter                            metric.Meter
+	ReceiverSqlqueryQueryDuration    metric.Int64Histogram
+	ReceiverSqlqueryRowsRead         metric.Int64Counter
+	ReceiverSqlqueryTrackingValueLag metric.Int64Gauge
+	level                            configtelemetry.Level
+}
+
+// telemetryBuilderOption applies changes to default builder.
+type telemetryBuilderOption func(*TelemetryBuilder)
+
+// WithLevel sets the current telemetry level for the component.
+func WithLevel(lvl configtelemetry.Level) telemetryBuilderOption {
+	return func(builder *TelemetryBuilder) {
+		builder.level = lvl
+	}
+}
+
+// NewTelemetryBuilder provides a struct with methods to update all internal telemetry
+// for a component
+func NewTelemetryBuilder(settings component.TelemetrySettings, options ...telemetryBuilderOption) (*TelemetryBuilder, error) {
+	builder := TelemetryBuilder{level: configtelemetry.LevelBasic}
+	for _, op := range options {
+		op(&builder)
+	}
+	var err, errs error
+	if builder.level >= configtelemetry.LevelBasic {
+		builder.meter = Meter(settings)
+	} else {
+		builder.meter = noop.Meter{}
+	}
+	builder.ReceiverSqlqueryQueryDuration, err = builder.meter.Int64Histogram(
+		"receiver_sqlquery_query_duration",
+		metric.WithDescription("Duration (in milliseconds) of the queries of the logs receiver"),
+		metric.WithUnit("ms"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ReceiverSqlqueryRowsRead, err = builder.meter.Int64Counter(
+		"receiver_sqlquery_rows_read",
+		metric.WithDescription("Number of rows read by the queries of the logs receiver"),
+		metric.WithUnit("{rows}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ReceiverSqlqueryTrackingValueLag, err = builder.meter.Int64Gauge(
+		"receiver_sqlquery_tracking_value_lag",
+		metric.WithDescription("Time (in seconds) between now and the tracking value of the queries of the logs receiver, when their first tracking column holds a timestamp"),
+		metric.WithUnit("s"),
+	)
+	errs = errors.Join(errs, err)
+	return &builder, errs
+}
@@ -6,8 +6,10 @@ package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-coll
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -17,6 +19,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
@@ -32,13 +36,14 @@ type logsReceiver struct {
 	queryReceivers   []*logsQueryReceiver
 	nextConsumer     consumer.Logs
 
-	isStarted                bool
-	collectionIntervalTicker *time.Ticker
-	shutdownRequested        chan struct{}
+	isStarted         bool
+	collectionWg      sync.WaitGroup
+	shutdownRequested chan struct{}
 
 	id            component.ID
 	storageClient storage.Client
 	obsrecv       *receiverhelper.ObsReport
+	telemetry     *metadata.TelemetryBuilder
 }
 
 func newLogsReceiver(
@@ -56,6 +61,10 @@ func newLogsReceiver(
 	if err != nil {
 		return nil, err
 	}
+	telemetry, err := metadata.NewTelemetryBuilder(settings.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
 
 	receiver := &logsReceiver{
 		config:   config,
@@ -68,6 +77,7 @@ func newLogsReceiver(
 		shutdownRequested: make(chan struct{}),
 		id:                settings.ID,
 		obsrecv:           obsr,
+		telemetry:         telemetry,
 	}
 
 	return receiver, nil
@@ -119,53 +129,62 @@ func (receiver *logsReceiver) createQueryReceivers() error {
 			receiver.config.Telemetry,
 			receiver.storageClient,
 		)
+		queryReceiver.metrics = receiver.telemetry
+		queryReceiver.metricAttributes = metric.WithAttributes(attribute.String("query", fmt.Sprintf("query-%d", i)))
 		receiver.queryReceivers = append(receiver.queryReceivers, queryReceiver)
 	}
 	return nil
 }
 
+// startCollecting runs each query at its own collection interval, the one of the receiver by default.
 func (receiver *logsReceiver) startCollecting() {
-	receiver.collectionIntervalTicker = time.NewTicker(receiver.config.CollectionInterval)
-
-	go func() {
-		for {
-			select {
-			case <-receiver.collectionIntervalTicker.C:
-				receiver.collect()
-			case <-receiver.shutdownRequested:
-				return
-			}
+	for _, queryReceiver := range receiver.queryReceivers {
+		interval := queryReceiver.query.CollectionInterval
+		if interval == 0 {
+			interval = receiver.config.CollectionInterval
 		}
-	}()
-}
 
-func (receiver *logsReceiver) collect() {
-	logsChannel := make(chan plog.Logs)
-	for _, queryReceiver := range receiver.queryReceivers {
+		receiver.collectionWg.Add(1)
 		go func(queryReceiver *logsQueryReceiver) {
-			logs, err := queryReceiver.collect(context.Background())
-			if err != nil {
-				receiver.settings.Logger.Error("error collecting logs", zap.Error(err), zap.String("query", queryReceiver.ID()))
+			defer receiver.collectionWg.Done()
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					receiver.collect(queryReceiver)
+				case <-receiver.shutdownRequested:
+					return
+				}
 			}
-			logsChannel <- logs
 		}(queryReceiver)
 	}
+}
 
-	allLogs := plog.NewLogs()
-	for range receiver.queryReceivers {
-		logs := <-logsChannel
-		logs.ResourceLogs().MoveAndAppendTo(allLogs.ResourceLogs())
+// collect runs a query and forwards the resulting logs. The tracking values of the query only move
+// forward once the logs have been accepted by the next consumer, so that rows aren't lost if they're not.
+func (receiver *logsReceiver) collect(queryReceiver *logsQueryReceiver) {
+	ctx := context.Background()
+	logs, err := queryReceiver.collect(ctx)
+	if err != nil {
+		receiver.settings.Logger.Error("error collecting logs", zap.Error(err), zap.String("query", queryReceiver.ID()))
 	}
 
-	logRecordCount := allLogs.LogRecordCount()
+	logRecordCount := logs.LogRecordCount()
 	if logRecordCount > 0 {
-		ctx := receiver.obsrecv.StartLogsOp(context.Background())
-		err := receiver.nextConsumer.ConsumeLogs(context.Background(), allLogs)
-		receiver.obsrecv.EndLogsOp(ctx, metadata.Type.String(), logRecordCount, err)
+		obsCtx := receiver.obsrecv.StartLogsOp(ctx)
+		err = receiver.nextConsumer.ConsumeLogs(ctx, logs)
+		receiver.obsrecv.EndLogsOp(obsCtx, metadata.Type.String(), logRecordCount, err)
 		if err != nil {
-			receiver.settings.Logger.Error("failed to send logs: %w", zap.Error(err))
+			receiver.settings.Logger.Error("failed to send logs", zap.Error(err), zap.String("query", queryReceiver.ID()))
+			queryReceiver.discardTrackingValues()
+			return
 		}
 	}
+
+	if err = queryReceiver.commitTrackingValues(ctx); err != nil {
+		receiver.settings.Logger.Error("failed to store tracking values", zap.Error(err), zap.String("query", queryReceiver.ID()))
+	}
 }
 
 func (receiver *logsReceiver) Shutdown(ctx context.Context) error {
@@ -192,28 +211,37 @@ func (receiver *logsReceiver) Shutdown(ctx context.Context) error {
 }
 
 func (receiver *logsReceiver) stopCollecting() {
-	if receiver.collectionIntervalTicker != nil {
-		receiver.collectionIntervalTicker.Stop()
-	}
 	close(receiver.shutdownRequested)
+	receiver.collectionWg.Wait()
 }
 
 type logsQueryReceiver struct {
-	id           string
-	query        sqlquery.Query
-	createDb     sqlquery.DbProviderFunc
-	createClient sqlquery.ClientProviderFunc
-	logger       *zap.Logger
-	telemetry    sqlquery.TelemetryConfig
-
-	db            *sql.DB
-	client        sqlquery.DbClient
-	trackingValue string
+	id               string
+	query            sqlquery.Query
+	createDb         sqlquery.DbProviderFunc
+	createClient     sqlquery.ClientProviderFunc
+	logger           *zap.Logger
+	telemetry        sqlquery.TelemetryConfig
+	metrics          *metadata.TelemetryBuilder
+	metricAttributes metric.MeasurementOption
+
+	db              *sql.DB
+	client          sqlquery.DbClient
+	trackingColumns []string
+	trackingValues  []string
+	// pendingTrackingValues are the tracking values of the last collected rows, not forwarded yet
+	pendingTrackingValues []string
 	// TODO: Extract persistence into its own component
 	storageClient           storage.Client
 	trackingValueStorageKey string
 }
 
+// trackingState is the representation of the tracking values in the storage.
+type trackingState struct {
+	Values    []string  `json:"values"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
 func newLogsQueryReceiver(
 	id string,
 	query sqlquery.Query,
@@ -224,15 +252,16 @@ func newLogsQueryReceiver(
 	storageClient storage.Client,
 ) *logsQueryReceiver {
 	queryReceiver := &logsQueryReceiver{
-		id:            id,
-		query:         query,
-		createDb:      dbProviderFunc,
-		createClient:  clientProviderFunc,
-		logger:        logger,
-		telemetry:     telemetry,
-		storageClient: storageClient,
-	}
-	queryReceiver.trackingValue = queryReceiver.query.TrackingStartValue
+		id:              id,
+		query:           query,
+		createDb:        dbProviderFunc,
+		createClient:    clientProviderFunc,
+		logger:          logger,
+		telemetry:       telemetry,
+		storageClient:   storageClient,
+		trackingColumns: query.TrackingColumnNames(),
+	}
+	queryReceiver.trackingValues = queryReceiver.query.TrackingStartValueList()
 	queryReceiver.trackingValueStorageKey = fmt.Sprintf("%s.%s", queryReceiver.id, "trackingValue")
 	return queryReceiver
 }
@@ -249,26 +278,42 @@ func (queryReceiver *logsQueryReceiver) start(ctx context.Context) error {
 	}
 	queryReceiver.client = queryReceiver.createClient(sqlquery.DbWrapper{Db: queryReceiver.db}, queryReceiver.query.SQL, queryReceiver.logger, queryReceiver.telemetry)
 
-	queryReceiver.trackingValue = queryReceiver.retrieveTrackingValue(ctx)
+	queryReceiver.trackingValues = queryReceiver.retrieveTrackingValues(ctx)
 
 	return nil
 }
 
-// retrieveTrackingValue retrieves the tracking value from storage, if storage is configured.
-// Otherwise, it returns the tracking value configured in `tracking_start_value`.
-func (queryReceiver *logsQueryReceiver) retrieveTrackingValue(ctx context.Context) string {
-	trackingValueFromConfig := queryReceiver.query.TrackingStartValue
+// retrieveTrackingValues retrieves the tracking values from storage, if storage is configured.
+// Otherwise, or if the stored values are older than `tracking_retention`, it returns the
+// tracking values configured in `tracking_start_value(s)`.
+func (queryReceiver *logsQueryReceiver) retrieveTrackingValues(ctx context.Context) []string {
+	trackingValuesFromConfig := queryReceiver.query.TrackingStartValueList()
 	if queryReceiver.storageClient == nil {
-		return trackingValueFromConfig
+		return trackingValuesFromConfig
 	}
 
 	storedTrackingValueBytes, err := queryReceiver.storageClient.Get(ctx, queryReceiver.trackingValueStorageKey)
 	if err != nil || storedTrackingValueBytes == nil {
-		return trackingValueFromConfig
+		return trackingValuesFromConfig
 	}
 
-	return string(storedTrackingValueBytes)
-
+	var state trackingState
+	if err = json.Unmarshal(storedTrackingValueBytes, &state); err != nil {
+		// tracking values stored by previous versions are the raw value of the single tracking column
+		if len(trackingValuesFromConfig) != 1 {
+			return trackingValuesFromConfig
+		}
+		return []string{string(storedTrackingValueBytes)}
+	}
+	if len(state.Values) != len(trackingValuesFromConfig) {
+		queryReceiver.logger.Warn("Ignoring stored tracking values not matching the tracking columns", zap.String("query", queryReceiver.id))
+		return trackingValuesFromConfig
+	}
+	if retention := queryReceiver.query.TrackingRetention; retention > 0 && time.Since(state.UpdatedAt) > retention {
+		queryReceiver.logger.Info("Ignoring stored tracking values older than the tracking retention", zap.String("query", queryReceiver.id), zap.Time("updated_at", state.UpdatedAt))
+		return trackingValuesFromConfig
+	}
+	return state.Values
 }
 
 func (queryReceiver *logsQueryReceiver) collect(ctx context.Context) (plog.Logs, error) {
@@ -277,44 +322,88 @@ func (queryReceiver *logsQueryReceiver) collect(ctx context.Context) (plog.Logs,
 	var rows []sqlquery.StringMap
 	var err error
 	observedAt := pcommon.NewTimestampFromTime(time.Now())
-	if queryReceiver.query.TrackingColumn != "" {
-		rows, err = queryReceiver.client.QueryRows(ctx, queryReceiver.trackingValue)
-	} else {
-		rows, err = queryReceiver.client.QueryRows(ctx)
+	args := make([]any, len(queryReceiver.trackingValues))
+	for i, value := range queryReceiver.trackingValues {
+		args[i] = value
 	}
+	rows, err = queryReceiver.client.QueryRows(ctx, args...)
+	queryReceiver.recordQuery(ctx, observedAt.AsTime(), len(rows))
 	if err != nil {
 		return logs, fmt.Errorf("error getting rows: %w", err)
 	}
 
-	var errs []error
 	scopeLogs := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
-	for logsConfigIndex, logsConfig := range queryReceiver.query.Logs {
+	for _, logsConfig := range queryReceiver.query.Logs {
 		for _, row := range rows {
 			logRecord := scopeLogs.AppendEmpty()
 			rowToLog(row, logsConfig, logRecord)
 			logRecord.SetObservedTimestamp(observedAt)
-			if logsConfigIndex == 0 {
-				errs = append(errs, queryReceiver.storeTrackingValue(ctx, row))
-			}
 		}
 	}
-	return logs, errors.Join(errs...)
+	if len(rows) > 0 && len(queryReceiver.trackingColumns) > 0 {
+		lastRow := rows[len(rows)-1]
+		queryReceiver.pendingTrackingValues = make([]string, len(queryReceiver.trackingColumns))
+		for i, column := range queryReceiver.trackingColumns {
+			queryReceiver.pendingTrackingValues[i] = lastRow[column]
+		}
+	}
+	return logs, nil
 }
 
-func (queryReceiver *logsQueryReceiver) storeTrackingValue(ctx context.Context, row sqlquery.StringMap) error {
-	if queryReceiver.query.TrackingColumn == "" {
-		return nil
+func (queryReceiver *logsQueryReceiver) recordQuery(ctx context.Context, startedAt time.Time, rowCount int) {
+	if queryReceiver.metrics == nil {
+		return
 	}
-	queryReceiver.trackingValue = row[queryReceiver.query.TrackingColumn]
-	if queryReceiver.storageClient != nil {
-		err := queryReceiver.storageClient.Set(ctx, queryReceiver.trackingValueStorageKey, []byte(queryReceiver.trackingValue))
-		if err != nil {
+	queryReceiver.metrics.ReceiverSqlqueryQueryDuration.Record(ctx, time.Since(startedAt).Milliseconds(), queryReceiver.metricAttributes)
+	queryReceiver.metrics.ReceiverSqlqueryRowsRead.Add(ctx, int64(rowCount), queryReceiver.metricAttributes)
+}
+
+// commitTrackingValues makes the tracking values of the last collected rows the ones the next query starts from.
+func (queryReceiver *logsQueryReceiver) commitTrackingValues(ctx context.Context) error {
+	if queryReceiver.pendingTrackingValues != nil {
+		queryReceiver.trackingValues = queryReceiver.pendingTrackingValues
+		queryReceiver.pendingTrackingValues = nil
+		if err := queryReceiver.storeTrackingValues(ctx); err != nil {
 			return err
 		}
 	}
+	queryReceiver.recordTrackingValueLag(ctx)
 	return nil
 }
 
+// discardTrackingValues forgets the tracking values of the last collected rows, so they are read again.
+func (queryReceiver *logsQueryReceiver) discardTrackingValues() {
+	queryReceiver.pendingTrackingValues = nil
+}
+
+func (queryReceiver *logsQueryReceiver) storeTrackingValues(ctx context.Context) error {
+	if queryReceiver.storageClient == nil {
+		return nil
+	}
+	value, err := json.Marshal(trackingState{Values: queryReceiver.trackingValues, UpdatedAt: time.Now()})
+	if err != nil {
+		return err
+	}
+	return queryReceiver.storageClient.Set(ctx, queryReceiver.trackingValueStorageKey, value)
+}
+
+// recordTrackingValueLag records how far behind the first tracking value is, when it holds a timestamp.
+func (queryReceiver *logsQueryReceiver) recordTrackingValueLag(ctx context.Context) {
+	if queryReceiver.metrics == nil || len(queryReceiver.trackingValues) == 0 {
+		return
+	}
+	for _, layout := range trackingTimeLayouts {
+		if t, err := time.Parse(layout, queryReceiver.trackingValues[0]); err == nil {
+			queryReceiver.metrics.ReceiverSqlqueryTrackingValueLag.Record(ctx, int64(time.Since(t)/time.Second), queryReceiver.metricAttributes)
+			return
+		}
+	}
+}
+
+// trackingTimeLayouts are the layouts a tracking value is parsed with to compute its lag,
+// RFC3339 being the one timestamp columns are read with.
+var trackingTimeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999"}
+
 func rowToLog(row sqlquery.StringMap, config sqlquery.LogsCfg, logRecord plog.LogRecord) {
 	logRecord.Body().SetStr(row[config.BodyColumn])
 }
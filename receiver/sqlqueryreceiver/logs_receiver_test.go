@@ -5,12 +5,25 @@ package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-coll
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
 )
 
@@ -51,3 +64,168 @@ func TestLogsQueryReceiver_Collect(t *testing.T) {
 		"Observed timestamps of all log records collected in a single scrape should be equal",
 	)
 }
+
+// argsFakeClient returns the given rows, and records the arguments of each query.
+type argsFakeClient struct {
+	rows [][]sqlquery.StringMap
+	args [][]any
+}
+
+func (c *argsFakeClient) QueryRows(_ context.Context, args ...any) ([]sqlquery.StringMap, error) {
+	idx := len(c.args)
+	c.args = append(c.args, args)
+	if idx >= len(c.rows) {
+		return nil, nil
+	}
+	return c.rows[idx], nil
+}
+
+func newTestLogsReceiver(t *testing.T, settings receiver.Settings, nextConsumer consumer.Logs, query sqlquery.Query, client sqlquery.DbClient, storageClient storage.Client) (*logsReceiver, *logsQueryReceiver) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Queries = []sqlquery.Query{query}
+	receiver, err := newLogsReceiver(cfg, settings, fakeDBConnect, mkFakeClient, nextConsumer)
+	require.NoError(t, err)
+	receiver.storageClient = storageClient
+	require.NoError(t, receiver.createQueryReceivers())
+	queryReceiver := receiver.queryReceivers[0]
+	queryReceiver.trackingValues = queryReceiver.retrieveTrackingValues(context.Background())
+	queryReceiver.client = client
+	return receiver, queryReceiver
+}
+
+func TestLogsReceiver_CompositeTracking(t *testing.T) {
+	client := &argsFakeClient{
+		rows: [][]sqlquery.StringMap{
+			{{"ts": "2024-01-01T00:00:00Z", "id": "1", "body": "a"}, {"ts": "2024-01-01T00:00:00Z", "id": "2", "body": "b"}},
+			{{"ts": "2024-01-01T00:00:01Z", "id": "1", "body": "c"}},
+		},
+	}
+	sink := new(consumertest.LogsSink)
+	storageClient := storagetest.NewInMemoryClient(component.KindReceiver, component.MustNewID("sqlquery"), "")
+	receiver, queryReceiver := newTestLogsReceiver(t, receivertest.NewNopSettings(), sink, sqlquery.Query{
+		SQL:                 "select * from t where (ts, id) > ($1, $2) order by ts, id",
+		Logs:                []sqlquery.LogsCfg{{BodyColumn: "body"}},
+		TrackingColumns:     []string{"ts", "id"},
+		TrackingStartValues: []string{"2023-01-01T00:00:00Z", "0"},
+	}, client, storageClient)
+
+	receiver.collect(queryReceiver)
+	receiver.collect(queryReceiver)
+	receiver.collect(queryReceiver)
+
+	assert.Equal(t, [][]any{
+		{"2023-01-01T00:00:00Z", "0"},
+		{"2024-01-01T00:00:00Z", "2"},
+		{"2024-01-01T00:00:01Z", "1"},
+	}, client.args)
+	assert.Equal(t, 3, sink.LogRecordCount())
+
+	stored, err := storageClient.Get(context.Background(), queryReceiver.trackingValueStorageKey)
+	require.NoError(t, err)
+	var state trackingState
+	require.NoError(t, json.Unmarshal(stored, &state))
+	assert.Equal(t, []string{"2024-01-01T00:00:01Z", "1"}, state.Values)
+}
+
+func TestLogsReceiver_TrackingValuesNotCommittedOnConsumerError(t *testing.T) {
+	client := &argsFakeClient{
+		rows: [][]sqlquery.StringMap{
+			{{"id": "1", "body": "a"}},
+			{{"id": "1", "body": "a"}},
+		},
+	}
+	receiver, queryReceiver := newTestLogsReceiver(t, receivertest.NewNopSettings(), consumertest.NewErr(errors.New("rejected")), sqlquery.Query{
+		SQL:                "select * from t where id > $1 order by id",
+		Logs:               []sqlquery.LogsCfg{{BodyColumn: "body"}},
+		TrackingColumn:     "id",
+		TrackingStartValue: "0",
+	}, client, nil)
+
+	receiver.collect(queryReceiver)
+	receiver.collect(queryReceiver)
+
+	// the rows rejected by the next consumer are read again
+	assert.Equal(t, [][]any{{"0"}, {"0"}}, client.args)
+	assert.Equal(t, []string{"0"}, queryReceiver.trackingValues)
+}
+
+func TestLogsQueryReceiver_RetrieveTrackingValues(t *testing.T) {
+	query := sqlquery.Query{
+		TrackingColumns:     []string{"ts", "id"},
+		TrackingStartValues: []string{"start", "0"},
+		TrackingRetention:   time.Hour,
+	}
+	storeState := func(t *testing.T, client storage.Client, key string, state trackingState) {
+		value, err := json.Marshal(state)
+		require.NoError(t, err)
+		require.NoError(t, client.Set(context.Background(), key, value))
+	}
+
+	t.Run("no stored values", func(t *testing.T) {
+		storageClient := storagetest.NewInMemoryClient(component.KindReceiver, component.MustNewID("sqlquery"), "")
+		queryReceiver := newLogsQueryReceiver("query-0", query, nil, nil, zap.NewNop(), sqlquery.TelemetryConfig{}, storageClient)
+		assert.Equal(t, []string{"start", "0"}, queryReceiver.retrieveTrackingValues(context.Background()))
+	})
+
+	t.Run("stored values within retention", func(t *testing.T) {
+		storageClient := storagetest.NewInMemoryClient(component.KindReceiver, component.MustNewID("sqlquery"), "")
+		queryReceiver := newLogsQueryReceiver("query-0", query, nil, nil, zap.NewNop(), sqlquery.TelemetryConfig{}, storageClient)
+		storeState(t, storageClient, queryReceiver.trackingValueStorageKey, trackingState{Values: []string{"ts", "42"}, UpdatedAt: time.Now().Add(-time.Minute)})
+		assert.Equal(t, []string{"ts", "42"}, queryReceiver.retrieveTrackingValues(context.Background()))
+	})
+
+	t.Run("stored values past retention", func(t *testing.T) {
+		storageClient := storagetest.NewInMemoryClient(component.KindReceiver, component.MustNewID("sqlquery"), "")
+		queryReceiver := newLogsQueryReceiver("query-0", query, nil, nil, zap.NewNop(), sqlquery.TelemetryConfig{}, storageClient)
+		storeState(t, storageClient, queryReceiver.trackingValueStorageKey, trackingState{Values: []string{"ts", "42"}, UpdatedAt: time.Now().Add(-2 * time.Hour)})
+		assert.Equal(t, []string{"start", "0"}, queryReceiver.retrieveTrackingValues(context.Background()))
+	})
+
+	t.Run("stored value of a previous version", func(t *testing.T) {
+		storageClient := storagetest.NewInMemoryClient(component.KindReceiver, component.MustNewID("sqlquery"), "")
+		queryReceiver := newLogsQueryReceiver("query-0", sqlquery.Query{TrackingColumn: "id", TrackingStartValue: "0"}, nil, nil, zap.NewNop(), sqlquery.TelemetryConfig{}, storageClient)
+		require.NoError(t, storageClient.Set(context.Background(), queryReceiver.trackingValueStorageKey, []byte("42")))
+		assert.Equal(t, []string{"42"}, queryReceiver.retrieveTrackingValues(context.Background()))
+	})
+}
+
+func TestLogsReceiver_Telemetry(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	settings := receivertest.NewNopSettings()
+	settings.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	trackedAt := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	client := &argsFakeClient{
+		rows: [][]sqlquery.StringMap{
+			{{"ts": trackedAt, "body": "a"}, {"ts": trackedAt, "body": "b"}},
+		},
+	}
+	receiver, queryReceiver := newTestLogsReceiver(t, settings, consumertest.NewNop(), sqlquery.Query{
+		SQL:            "select * from t where ts > $1 order by ts",
+		Logs:           []sqlquery.LogsCfg{{BodyColumn: "body"}},
+		TrackingColumn: "ts",
+	}, client, nil)
+	receiver.collect(queryReceiver)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	metrics := map[string]metricdata.Metrics{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+
+	rowsRead := metrics["receiver_sqlquery_rows_read"].Data.(metricdata.Sum[int64])
+	require.Len(t, rowsRead.DataPoints, 1)
+	assert.EqualValues(t, 2, rowsRead.DataPoints[0].Value)
+	query, _ := rowsRead.DataPoints[0].Attributes.Value("query")
+	assert.Equal(t, "query-0", query.AsString())
+
+	duration := metrics["receiver_sqlquery_query_duration"].Data.(metricdata.Histogram[int64])
+	require.Len(t, duration.DataPoints, 1)
+	assert.EqualValues(t, 1, duration.DataPoints[0].Count)
+
+	lag := metrics["receiver_sqlquery_tracking_value_lag"].Data.(metricdata.Gauge[int64])
+	require.Len(t, lag.DataPoints, 1)
+	assert.GreaterOrEqual(t, lag.DataPoints[0].Value, int64(60))
+}
@@ -6,7 +6,9 @@ package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-coll
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -36,7 +38,9 @@ func createMetricsReceiverFunc(sqlOpenerFunc sqlquery.SQLOpenerFunc, clientProvi
 		consumer consumer.Metrics,
 	) (receiver.Metrics, error) {
 		sqlCfg := cfg.(*Config)
-		var opts []scraperhelper.ScraperControllerOption
+		// queries are grouped by collection interval, each group being scraped by its own controller
+		var intervals []time.Duration
+		optsByInterval := map[time.Duration][]scraperhelper.ScraperControllerOption{}
 		for i, query := range sqlCfg.Queries {
 			if len(query.Metrics) == 0 {
 				continue
@@ -47,14 +51,58 @@ func createMetricsReceiverFunc(sqlOpenerFunc sqlquery.SQLOpenerFunc, clientProvi
 			}
 			mp := sqlquery.NewScraper(id, query, sqlCfg.ControllerConfig, settings.TelemetrySettings.Logger, sqlCfg.Config.Telemetry, dbProviderFunc, clientProviderFunc)
 
-			opt := scraperhelper.AddScraper(mp)
-			opts = append(opts, opt)
+			interval := query.CollectionInterval
+			if interval == 0 {
+				interval = sqlCfg.CollectionInterval
+			}
+			if _, ok := optsByInterval[interval]; !ok {
+				intervals = append(intervals, interval)
+			}
+			optsByInterval[interval] = append(optsByInterval[interval], scraperhelper.AddScraper(mp))
+		}
+		if len(intervals) <= 1 {
+			controllerCfg := sqlCfg.ControllerConfig
+			if len(intervals) == 1 {
+				controllerCfg.CollectionInterval = intervals[0]
+			}
+			return scraperhelper.NewScraperControllerReceiver(
+				&controllerCfg,
+				settings,
+				consumer,
+				optsByInterval[controllerCfg.CollectionInterval]...,
+			)
 		}
-		return scraperhelper.NewScraperControllerReceiver(
-			&sqlCfg.ControllerConfig,
-			settings,
-			consumer,
-			opts...,
-		)
+
+		receivers := make(multiMetricsReceiver, 0, len(intervals))
+		for _, interval := range intervals {
+			controllerCfg := sqlCfg.ControllerConfig
+			controllerCfg.CollectionInterval = interval
+			r, err := scraperhelper.NewScraperControllerReceiver(&controllerCfg, settings, consumer, optsByInterval[interval]...)
+			if err != nil {
+				return nil, err
+			}
+			receivers = append(receivers, r)
+		}
+		return receivers, nil
+	}
+}
+
+// multiMetricsReceiver runs the controllers of queries with different collection intervals.
+type multiMetricsReceiver []receiver.Metrics
+
+func (r multiMetricsReceiver) Start(ctx context.Context, host component.Host) error {
+	for _, rcvr := range r {
+		if err := rcvr.Start(ctx, host); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r multiMetricsReceiver) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, rcvr := range r {
+		errs = append(errs, rcvr.Shutdown(ctx))
 	}
+	return errors.Join(errs...)
 }
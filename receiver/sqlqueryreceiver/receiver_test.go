@@ -79,6 +79,48 @@ func TestCreateMetricsReceiver(t *testing.T) {
 	require.NoError(t, receiver.Shutdown(ctx))
 }
 
+func TestCreateMetricsReceiverWithQueryCollectionIntervals(t *testing.T) {
+	createReceiver := createMetricsReceiverFunc(fakeDBConnect, mkFakeClient)
+	ctx := context.Background()
+	receiver, err := createReceiver(
+		ctx,
+		receivertest.NewNopSettings(),
+		&Config{
+			Config: sqlquery.Config{
+				ControllerConfig: scraperhelper.ControllerConfig{
+					CollectionInterval: 10 * time.Second,
+					InitialDelay:       time.Second,
+				},
+				Driver:     "mydriver",
+				DataSource: "my-datasource",
+				Queries: []sqlquery.Query{
+					{
+						SQL: "select * from foo",
+						Metrics: []sqlquery.MetricCfg{{
+							MetricName:  "my-metric",
+							ValueColumn: "my-column",
+						}},
+					},
+					{
+						SQL:                "select * from bar",
+						CollectionInterval: time.Minute,
+						Metrics: []sqlquery.MetricCfg{{
+							MetricName:  "my-other-metric",
+							ValueColumn: "my-column",
+						}},
+					},
+				},
+			},
+		},
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+	require.Len(t, receiver, 2)
+	err = receiver.Start(ctx, componenttest.NewNopHost())
+	require.NoError(t, err)
+	require.NoError(t, receiver.Shutdown(ctx))
+}
+
 func fakeDBConnect(string, string) (*sql.DB, error) {
 	return nil, nil
 }
@@ -22,6 +22,7 @@ type Config struct {
 	EnableSimpleTags      bool                             `mapstructure:"enable_simple_tags"`
 	IsMonotonicCounter    bool                             `mapstructure:"is_monotonic_counter"`
 	TimerHistogramMapping []protocol.TimerHistogramMapping `mapstructure:"timer_histogram_mapping"`
+	Protocol              protocol.Protocol                `mapstructure:"protocol"`
 }
 
 func (c *Config) Validate() error {
@@ -31,6 +32,13 @@ func (c *Config) Validate() error {
 		errs = multierr.Append(errs, fmt.Errorf("aggregation_interval must be a positive duration"))
 	}
 
+	switch c.Protocol {
+	case "", protocol.StatsDProtocol, protocol.DogStatsDProtocol:
+		// do nothing
+	default:
+		errs = multierr.Append(errs, fmt.Errorf("protocol is not supported: %s", c.Protocol))
+	}
+
 	var TimerHistogramMappingMissingObjectName bool
 	for _, eachMap := range c.TimerHistogramMapping {
 
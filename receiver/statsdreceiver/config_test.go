@@ -42,6 +42,7 @@ func TestLoadConfig(t *testing.T) {
 					Transport: confignet.TransportTypeUDP6,
 				},
 				AggregationInterval: 70 * time.Second,
+				Protocol:            protocol.DogStatsDProtocol,
 				TimerHistogramMapping: []protocol.TimerHistogramMapping{
 					{
 						StatsdType:   "histogram",
@@ -94,6 +95,7 @@ func TestValidate(t *testing.T) {
 		statsdTypeNotSupportErr        = "statsd_type is not a supported mapping for histogram and timing metrics: %s"
 		observerTypeNotSupportErr      = "observer_type is not supported for histogram and timing metrics: %s"
 		invalidHistogramErr            = "histogram configuration requires observer_type: histogram"
+		protocolNotSupportErr          = "protocol is not supported: %s"
 	)
 
 	tests := []test{
@@ -163,6 +165,14 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: invalidHistogramErr,
 		},
+		{
+			name: "ProtocolNotSupport",
+			cfg: &Config{
+				AggregationInterval: 10,
+				Protocol:            "graphite",
+			},
+			expectedErr: fmt.Sprintf(protocolNotSupportErr, "graphite"),
+		},
 		{
 			name: "negativeAggregationInterval",
 			cfg: &Config{
@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/protocol"
 )
@@ -21,6 +22,7 @@ const (
 	defaultAggregationInterval = 60 * time.Second
 	defaultEnableMetricType    = false
 	defaultIsMonotonicCounter  = false
+	defaultProtocol            = protocol.StatsDProtocol
 )
 
 var (
@@ -33,6 +35,7 @@ func NewFactory() receiver.Factory {
 		metadata.Type,
 		createDefaultConfig,
 		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
 	)
 }
 
@@ -46,6 +49,7 @@ func createDefaultConfig() component.Config {
 		EnableMetricType:      defaultEnableMetricType,
 		IsMonotonicCounter:    defaultIsMonotonicCounter,
 		TimerHistogramMapping: defaultTimerHistogramMapping,
+		Protocol:              defaultProtocol,
 	}
 }
 
@@ -55,6 +59,40 @@ func createMetricsReceiver(
 	cfg component.Config,
 	consumer consumer.Metrics,
 ) (receiver.Metrics, error) {
+	var err error
 	c := cfg.(*Config)
-	return newReceiver(params, *c, consumer)
+	r := receivers.GetOrAdd(cfg, func() (rcv component.Component) {
+		rcv, err = newReceiver(params, *c)
+		return rcv
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.Unwrap().(*statsdReceiver).nextMetricsConsumer = consumer
+	return r, nil
 }
+
+func createLogsReceiver(
+	_ context.Context,
+	params receiver.Settings,
+	cfg component.Config,
+	consumer consumer.Logs,
+) (receiver.Logs, error) {
+	var err error
+	c := cfg.(*Config)
+	r := receivers.GetOrAdd(cfg, func() (rcv component.Component) {
+		rcv, err = newReceiver(params, *c)
+		return rcv
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.Unwrap().(*statsdReceiver).nextLogsConsumer = consumer
+	return r, nil
+}
+
+// The metrics and logs receivers share the same listener, events and service checks
+// are received alongside the metrics.
+var receivers = sharedcomponent.NewSharedComponents()
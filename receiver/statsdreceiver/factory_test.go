@@ -11,6 +11,8 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/protocol"
 )
 
 func TestCreateDefaultConfig(t *testing.T) {
@@ -29,3 +31,16 @@ func TestCreateReceiver(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, tReceiver, "receiver creation failed")
 }
+
+func TestCreateLogsReceiverSharesMetricsReceiver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = "localhost:0" // Endpoint is required, not going to be used here.
+	cfg.Protocol = protocol.DogStatsDProtocol
+
+	params := receivertest.NewNopSettings()
+	mReceiver, err := createMetricsReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	lReceiver, err := createLogsReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.Same(t, mReceiver, lReceiver, "metrics and logs receivers should share the same listener")
+}
@@ -12,4 +12,5 @@ var (
 
 const (
 	MetricsStability = component.StabilityLevelBeta
+	LogsStability    = component.StabilityLevelDevelopment
 )
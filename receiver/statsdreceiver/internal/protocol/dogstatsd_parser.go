@@ -0,0 +1,282 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protocol // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/protocol"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	semconv "go.opentelemetry.io/collector/semconv/v1.22.0"
+)
+
+// Events and service checks are DogStatsD datagrams that are not metrics.
+// They are turned into log records, as described in
+// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/?tab=events
+// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/?tab=servicechecks
+const (
+	eventPrefix        = "_e{"
+	serviceCheckPrefix = "_sc|"
+
+	attrDogStatsDType          = "dogstatsd.type"
+	attrEventTitle             = "dogstatsd.event.title"
+	attrEventPriority          = "dogstatsd.event.priority"
+	attrEventAlertType         = "dogstatsd.event.alert_type"
+	attrEventSourceTypeName    = "dogstatsd.event.source_type_name"
+	attrEventAggregationKey    = "dogstatsd.event.aggregation_key"
+	attrServiceCheckName       = "dogstatsd.service_check.name"
+	attrServiceCheckStatus     = "dogstatsd.service_check.status"
+	dogStatsDTypeEvent         = "event"
+	dogStatsDTypeServiceCheck  = "service_check"
+	defaultEventAlertType      = "info"
+	defaultEventPriority       = "normal"
+	serviceCheckStatusOK       = "ok"
+	serviceCheckStatusWarning  = "warning"
+	serviceCheckStatusCritical = "critical"
+	serviceCheckStatusUnknown  = "unknown"
+)
+
+var errEmptyServiceCheckName = errors.New("empty service check name")
+
+type logInstruments struct {
+	addr    net.Addr
+	records plog.LogRecordSlice
+}
+
+// GetLogs gets the events and service checks received since the last call and reset the state.
+func (p *StatsDParser) GetLogs() []BatchLogs {
+	batchLogs := make([]BatchLogs, 0, len(p.logsByAddress))
+	for _, instrument := range p.logsByAddress {
+		batch := BatchLogs{
+			Info: client.Info{
+				Addr: instrument.addr,
+			},
+			Logs: plog.NewLogs(),
+		}
+		sl := batch.Logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+		p.setVersionAndNameScope(sl.Scope())
+		instrument.records.MoveAndAppendTo(sl.LogRecords())
+
+		batchLogs = append(batchLogs, batch)
+	}
+	p.logsByAddress = make(map[netAddr]*logInstruments)
+	return batchLogs
+}
+
+func (p *StatsDParser) newLogRecord(addr net.Addr) plog.LogRecord {
+	addrKey := newNetAddr(addr)
+	instrument, ok := p.logsByAddress[addrKey]
+	if !ok {
+		instrument = &logInstruments{
+			addr:    addr,
+			records: plog.NewLogRecordSlice(),
+		}
+		p.logsByAddress[addrKey] = instrument
+	}
+	lr := instrument.records.AppendEmpty()
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(timeNowFunc()))
+	return lr
+}
+
+// aggregateEvent parses an event of the form:
+// _e{<TITLE_LENGTH>,<TEXT_LENGTH>}:<TITLE>|<TEXT>|d:<TIMESTAMP>|h:<HOSTNAME>|p:<PRIORITY>|t:<ALERT_TYPE>|s:<SOURCE_TYPE_NAME>|k:<AGGREGATION_KEY>|#<TAGS>|c:<CONTAINER_ID>
+func (p *StatsDParser) aggregateEvent(line string, addr net.Addr) error {
+	lengths, rest, found := strings.Cut(strings.TrimPrefix(line, eventPrefix), "}:")
+	if !found {
+		return fmt.Errorf("invalid event format: %s", line)
+	}
+	titleLenStr, textLenStr, found := strings.Cut(lengths, ",")
+	if !found {
+		return fmt.Errorf("invalid event lengths: %s", lengths)
+	}
+	titleLen, err := strconv.Atoi(titleLenStr)
+	if err != nil || titleLen <= 0 {
+		return fmt.Errorf("invalid event title length: %s", titleLenStr)
+	}
+	textLen, err := strconv.Atoi(textLenStr)
+	if err != nil || textLen < 0 {
+		return fmt.Errorf("invalid event text length: %s", textLenStr)
+	}
+	// lengths are expressed in bytes, the title and the text are separated by a '|'. Each length is
+	// checked separately so that their sum can't overflow.
+	if titleLen >= len(rest) || textLen > len(rest)-titleLen-1 || rest[titleLen] != '|' {
+		return fmt.Errorf("event title and text do not match their lengths: %s", line)
+	}
+	title := rest[:titleLen]
+	text := rest[titleLen+1 : titleLen+1+textLen]
+	rest = rest[titleLen+1+textLen:]
+
+	attrs := pcommon.NewMap()
+	attrs.PutStr(attrDogStatsDType, dogStatsDTypeEvent)
+	attrs.PutStr(attrEventTitle, strings.ReplaceAll(title, `\n`, "\n"))
+	attrs.PutStr(attrEventPriority, defaultEventPriority)
+	attrs.PutStr(attrEventAlertType, defaultEventAlertType)
+
+	var timestamp pcommon.Timestamp
+	if rest != "" {
+		if rest[0] != '|' {
+			return fmt.Errorf("event title and text do not match their lengths: %s", line)
+		}
+		for _, part := range strings.Split(rest[1:], "|") {
+			switch {
+			case strings.HasPrefix(part, "d:"):
+				if timestamp, err = parseDogStatsDTimestamp(strings.TrimPrefix(part, "d:")); err != nil {
+					return err
+				}
+			case strings.HasPrefix(part, "h:"):
+				attrs.PutStr(semconv.AttributeHostName, strings.TrimPrefix(part, "h:"))
+			case strings.HasPrefix(part, "p:"):
+				priority := strings.TrimPrefix(part, "p:")
+				if priority != "normal" && priority != "low" {
+					return fmt.Errorf("invalid event priority: %s", priority)
+				}
+				attrs.PutStr(attrEventPriority, priority)
+			case strings.HasPrefix(part, "t:"):
+				alertType := strings.TrimPrefix(part, "t:")
+				if _, ok := eventSeverities[alertType]; !ok {
+					return fmt.Errorf("invalid event alert type: %s", alertType)
+				}
+				attrs.PutStr(attrEventAlertType, alertType)
+			case strings.HasPrefix(part, "s:"):
+				attrs.PutStr(attrEventSourceTypeName, strings.TrimPrefix(part, "s:"))
+			case strings.HasPrefix(part, "k:"):
+				attrs.PutStr(attrEventAggregationKey, strings.TrimPrefix(part, "k:"))
+			case strings.HasPrefix(part, "#"):
+				if err = p.putTags(attrs, strings.TrimPrefix(part, "#")); err != nil {
+					return err
+				}
+			case strings.HasPrefix(part, "c:"):
+				if containerID := strings.TrimPrefix(part, "c:"); containerID != "" {
+					attrs.PutStr(semconv.AttributeContainerID, containerID)
+				}
+			default:
+				return fmt.Errorf("unrecognized event part: %s", part)
+			}
+		}
+	}
+
+	alertType, _ := attrs.Get(attrEventAlertType)
+	severity := eventSeverities[alertType.Str()]
+
+	lr := p.newLogRecord(addr)
+	lr.Body().SetStr(strings.ReplaceAll(text, `\n`, "\n"))
+	lr.SetSeverityNumber(severity)
+	lr.SetSeverityText(alertType.Str())
+	if timestamp != 0 {
+		lr.SetTimestamp(timestamp)
+	}
+	attrs.MoveTo(lr.Attributes())
+	return nil
+}
+
+// aggregateServiceCheck parses a service check of the form:
+// _sc|<NAME>|<STATUS>|d:<TIMESTAMP>|h:<HOSTNAME>|#<TAGS>|c:<CONTAINER_ID>|m:<SERVICE_CHECK_MESSAGE>
+func (p *StatsDParser) aggregateServiceCheck(line string, addr net.Addr) error {
+	name, rest, _ := strings.Cut(strings.TrimPrefix(line, serviceCheckPrefix), "|")
+	if name == "" {
+		return errEmptyServiceCheckName
+	}
+	statusStr, rest, _ := strings.Cut(rest, "|")
+	status, ok := serviceCheckStatuses[statusStr]
+	if !ok {
+		return fmt.Errorf("invalid service check status: %s", statusStr)
+	}
+
+	attrs := pcommon.NewMap()
+	attrs.PutStr(attrDogStatsDType, dogStatsDTypeServiceCheck)
+	attrs.PutStr(attrServiceCheckName, name)
+	attrs.PutStr(attrServiceCheckStatus, status.name)
+
+	var (
+		timestamp pcommon.Timestamp
+		message   string
+		err       error
+		part      string
+	)
+	for part, rest, _ = strings.Cut(rest, "|"); len(part) > 0; part, rest, _ = strings.Cut(rest, "|") {
+		switch {
+		case strings.HasPrefix(part, "d:"):
+			if timestamp, err = parseDogStatsDTimestamp(strings.TrimPrefix(part, "d:")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(part, "h:"):
+			attrs.PutStr(semconv.AttributeHostName, strings.TrimPrefix(part, "h:"))
+		case strings.HasPrefix(part, "#"):
+			if err = p.putTags(attrs, strings.TrimPrefix(part, "#")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(part, "c:"):
+			if containerID := strings.TrimPrefix(part, "c:"); containerID != "" {
+				attrs.PutStr(semconv.AttributeContainerID, containerID)
+			}
+		case strings.HasPrefix(part, "m:"):
+			// the message is always the last field and may contain '|'
+			message = strings.TrimPrefix(part, "m:")
+			if rest != "" {
+				message += "|" + rest
+			}
+			rest = ""
+		default:
+			return fmt.Errorf("unrecognized service check part: %s", part)
+		}
+	}
+
+	lr := p.newLogRecord(addr)
+	lr.Body().SetStr(strings.ReplaceAll(message, `\n`, "\n"))
+	lr.SetSeverityNumber(status.severity)
+	lr.SetSeverityText(status.name)
+	if timestamp != 0 {
+		lr.SetTimestamp(timestamp)
+	}
+	attrs.MoveTo(lr.Attributes())
+	return nil
+}
+
+func (p *StatsDParser) putTags(attrs pcommon.Map, tagsStr string) error {
+	tags, err := parseTags(tagsStr, p.enableSimpleTags)
+	if err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		attrs.PutStr(string(tag.Key), tag.Value.AsString())
+	}
+	return nil
+}
+
+func parseDogStatsDTimestamp(timestampStr string) (pcommon.Timestamp, error) {
+	timestampSeconds, err := strconv.ParseUint(timestampStr, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timestamp: %s", timestampStr)
+	}
+	if timestampSeconds > math.MaxUint64/uint64(time.Second) {
+		return 0, fmt.Errorf("timestamp out of range: %s", timestampStr)
+	}
+	return pcommon.Timestamp(timestampSeconds * uint64(time.Second)), nil
+}
+
+var eventSeverities = map[string]plog.SeverityNumber{
+	"info":    plog.SeverityNumberInfo,
+	"success": plog.SeverityNumberInfo,
+	"warning": plog.SeverityNumberWarn,
+	"error":   plog.SeverityNumberError,
+}
+
+type serviceCheckStatus struct {
+	name     string
+	severity plog.SeverityNumber
+}
+
+var serviceCheckStatuses = map[string]serviceCheckStatus{
+	"0": {name: serviceCheckStatusOK, severity: plog.SeverityNumberInfo},
+	"1": {name: serviceCheckStatusWarning, severity: plog.SeverityNumberWarn},
+	"2": {name: serviceCheckStatusCritical, severity: plog.SeverityNumberError},
+	"3": {name: serviceCheckStatusUnknown, severity: plog.SeverityNumberUnspecified},
+}
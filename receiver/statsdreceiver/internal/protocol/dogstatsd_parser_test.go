@@ -0,0 +1,340 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protocol
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func newTestDogStatsDParser(t *testing.T) *StatsDParser {
+	p := &StatsDParser{Protocol: DogStatsDProtocol}
+	require.NoError(t, p.Initialize(false, false, false, []TimerHistogramMapping{{StatsdType: "timer", ObserverType: "gauge"}, {StatsdType: "histogram", ObserverType: "gauge"}}))
+	return p
+}
+
+func TestDogStatsDParser_AggregateSet(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+
+	p := newTestDogStatsDParser(t)
+	for _, line := range []string{
+		"users.uniques:alice|s|#mykey:myvalue",
+		"users.uniques:bob|s|#mykey:myvalue",
+		"users.uniques:alice|s|#mykey:myvalue",
+		"users.uniques:carol|s|#mykey:othervalue",
+	} {
+		require.NoError(t, p.Aggregate(line, addr))
+	}
+
+	batches := p.GetMetrics()
+	require.Len(t, batches, 1)
+	metrics := batches[0].Metrics
+	require.Equal(t, 2, metrics.MetricCount())
+
+	counts := map[string]int64{}
+	sms := metrics.ResourceMetrics().At(0).ScopeMetrics()
+	for i := 0; i < sms.Len(); i++ {
+		m := sms.At(i).Metrics().At(0)
+		assert.Equal(t, "users.uniques", m.Name())
+		require.Equal(t, pmetric.MetricTypeGauge, m.Type())
+		dp := m.Gauge().DataPoints().At(0)
+		assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(711, 0)), dp.Timestamp())
+		v, ok := dp.Attributes().Get("mykey")
+		require.True(t, ok)
+		counts[v.Str()] = dp.IntValue()
+	}
+	assert.Equal(t, map[string]int64{"myvalue": 2, "othervalue": 1}, counts)
+
+	// The unique values are reset after each interval.
+	require.NoError(t, p.Aggregate("users.uniques:alice|s|#mykey:myvalue", addr))
+	batches = p.GetMetrics()
+	require.Len(t, batches, 1)
+	assert.Equal(t, int64(1), batches[0].Metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).IntValue())
+}
+
+func TestStatsDParser_SetsEventsAndServiceChecksNeedDogStatsD(t *testing.T) {
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+
+	p := &StatsDParser{}
+	require.NoError(t, p.Initialize(false, false, false, []TimerHistogramMapping{{StatsdType: "timer", ObserverType: "gauge"}, {StatsdType: "histogram", ObserverType: "gauge"}}))
+
+	assert.EqualError(t, p.Aggregate("users.uniques:alice|s", addr), "unsupported metric type: s")
+	assert.Error(t, p.Aggregate("_e{5,4}:title|text", addr))
+	assert.Error(t, p.Aggregate("_sc|my.check|0", addr))
+	assert.Empty(t, p.GetLogs())
+}
+
+func TestDogStatsDParser_AggregateEvent(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+
+	tests := []struct {
+		name     string
+		input    string
+		expected func() plog.LogRecord
+		err      string
+	}{
+		{
+			name:  "minimal",
+			input: "_e{5,4}:title|text",
+			expected: func() plog.LogRecord {
+				lr := plog.NewLogRecord()
+				lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Unix(711, 0)))
+				lr.Body().SetStr("text")
+				lr.SetSeverityNumber(plog.SeverityNumberInfo)
+				lr.SetSeverityText("info")
+				lr.Attributes().PutStr("dogstatsd.type", "event")
+				lr.Attributes().PutStr("dogstatsd.event.title", "title")
+				lr.Attributes().PutStr("dogstatsd.event.priority", "normal")
+				lr.Attributes().PutStr("dogstatsd.event.alert_type", "info")
+				return lr
+			},
+		},
+		{
+			name:  "all fields",
+			input: `_e{9,13}:the title|first\nsecond|d:1700000000|h:my-host|p:low|t:error|s:my-source|k:my-key|#mykey:myvalue,other:value|c:container-id`,
+			expected: func() plog.LogRecord {
+				lr := plog.NewLogRecord()
+				lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Unix(711, 0)))
+				lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(1700000000, 0)))
+				lr.Body().SetStr("first\nsecond")
+				lr.SetSeverityNumber(plog.SeverityNumberError)
+				lr.SetSeverityText("error")
+				lr.Attributes().PutStr("dogstatsd.type", "event")
+				lr.Attributes().PutStr("dogstatsd.event.title", "the title")
+				lr.Attributes().PutStr("dogstatsd.event.priority", "low")
+				lr.Attributes().PutStr("dogstatsd.event.alert_type", "error")
+				lr.Attributes().PutStr("host.name", "my-host")
+				lr.Attributes().PutStr("dogstatsd.event.source_type_name", "my-source")
+				lr.Attributes().PutStr("dogstatsd.event.aggregation_key", "my-key")
+				lr.Attributes().PutStr("mykey", "myvalue")
+				lr.Attributes().PutStr("other", "value")
+				lr.Attributes().PutStr("container.id", "container-id")
+				return lr
+			},
+		},
+		{
+			name:  "text containing a separator",
+			input: "_e{5,9}:title|some|text|t:warning",
+			expected: func() plog.LogRecord {
+				lr := plog.NewLogRecord()
+				lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Unix(711, 0)))
+				lr.Body().SetStr("some|text")
+				lr.SetSeverityNumber(plog.SeverityNumberWarn)
+				lr.SetSeverityText("warning")
+				lr.Attributes().PutStr("dogstatsd.type", "event")
+				lr.Attributes().PutStr("dogstatsd.event.title", "title")
+				lr.Attributes().PutStr("dogstatsd.event.priority", "normal")
+				lr.Attributes().PutStr("dogstatsd.event.alert_type", "warning")
+				return lr
+			},
+		},
+		{
+			name:  "lengths too long",
+			input: "_e{5,40}:title|text",
+			err:   "event title and text do not match their lengths: _e{5,40}:title|text",
+		},
+		{
+			name:  "overflowing lengths",
+			input: "_e{9223372036854775807,1}:a|b",
+			err:   "event title and text do not match their lengths: _e{9223372036854775807,1}:a|b",
+		},
+		{
+			name:  "overflowing text length",
+			input: "_e{1,9223372036854775807}:a|b",
+			err:   "event title and text do not match their lengths: _e{1,9223372036854775807}:a|b",
+		},
+		{
+			name:  "lengths too short",
+			input: "_e{5,2}:title|text",
+			err:   "event title and text do not match their lengths: _e{5,2}:title|text",
+		},
+		{
+			name:  "invalid title length",
+			input: "_e{abc,4}:title|text",
+			err:   "invalid event title length: abc",
+		},
+		{
+			name:  "invalid alert type",
+			input: "_e{5,4}:title|text|t:fatal",
+			err:   "invalid event alert type: fatal",
+		},
+		{
+			name:  "invalid priority",
+			input: "_e{5,4}:title|text|p:high",
+			err:   "invalid event priority: high",
+		},
+		{
+			name:  "invalid timestamp",
+			input: "_e{5,4}:title|text|d:yesterday",
+			err:   "invalid timestamp: yesterday",
+		},
+		{
+			name:  "timestamp out of range",
+			input: "_e{5,4}:title|text|d:18446744074",
+			err:   "timestamp out of range: 18446744074",
+		},
+		{
+			name:  "unknown field",
+			input: "_e{5,4}:title|text|x:unknown",
+			err:   "unrecognized event part: x:unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestDogStatsDParser(t)
+			err := p.Aggregate(tt.input, addr)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				assert.Empty(t, p.GetLogs())
+				return
+			}
+			require.NoError(t, err)
+			assertSingleLogRecord(t, p, addr, tt.expected())
+		})
+	}
+}
+
+func TestDogStatsDParser_AggregateServiceCheck(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+
+	tests := []struct {
+		name     string
+		input    string
+		expected func() plog.LogRecord
+		err      string
+	}{
+		{
+			name:  "minimal",
+			input: "_sc|my.check|0",
+			expected: func() plog.LogRecord {
+				lr := plog.NewLogRecord()
+				lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Unix(711, 0)))
+				lr.Body().SetStr("")
+				lr.SetSeverityNumber(plog.SeverityNumberInfo)
+				lr.SetSeverityText("ok")
+				lr.Attributes().PutStr("dogstatsd.type", "service_check")
+				lr.Attributes().PutStr("dogstatsd.service_check.name", "my.check")
+				lr.Attributes().PutStr("dogstatsd.service_check.status", "ok")
+				return lr
+			},
+		},
+		{
+			name:  "all fields",
+			input: "_sc|my.check|2|d:1700000000|h:my-host|#mykey:myvalue|c:container-id|m:it is|broken",
+			expected: func() plog.LogRecord {
+				lr := plog.NewLogRecord()
+				lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Unix(711, 0)))
+				lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(1700000000, 0)))
+				lr.Body().SetStr("it is|broken")
+				lr.SetSeverityNumber(plog.SeverityNumberError)
+				lr.SetSeverityText("critical")
+				lr.Attributes().PutStr("dogstatsd.type", "service_check")
+				lr.Attributes().PutStr("dogstatsd.service_check.name", "my.check")
+				lr.Attributes().PutStr("dogstatsd.service_check.status", "critical")
+				lr.Attributes().PutStr("host.name", "my-host")
+				lr.Attributes().PutStr("mykey", "myvalue")
+				lr.Attributes().PutStr("container.id", "container-id")
+				return lr
+			},
+		},
+		{
+			name:  "unknown status",
+			input: "_sc|my.check|3|m:no data",
+			expected: func() plog.LogRecord {
+				lr := plog.NewLogRecord()
+				lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Unix(711, 0)))
+				lr.Body().SetStr("no data")
+				lr.SetSeverityText("unknown")
+				lr.Attributes().PutStr("dogstatsd.type", "service_check")
+				lr.Attributes().PutStr("dogstatsd.service_check.name", "my.check")
+				lr.Attributes().PutStr("dogstatsd.service_check.status", "unknown")
+				return lr
+			},
+		},
+		{
+			name:  "missing name",
+			input: "_sc||0",
+			err:   "empty service check name",
+		},
+		{
+			name:  "invalid status",
+			input: "_sc|my.check|4",
+			err:   "invalid service check status: 4",
+		},
+		{
+			name:  "unknown field",
+			input: "_sc|my.check|0|x:unknown",
+			err:   "unrecognized service check part: x:unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestDogStatsDParser(t)
+			err := p.Aggregate(tt.input, addr)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				assert.Empty(t, p.GetLogs())
+				return
+			}
+			require.NoError(t, err)
+			assertSingleLogRecord(t, p, addr, tt.expected())
+		})
+	}
+}
+
+func TestDogStatsDParser_GetLogsByAddress(t *testing.T) {
+	first, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+	second, _ := net.ResolveUDPAddr("udp", "5.6.7.8:5678")
+
+	p := newTestDogStatsDParser(t)
+	require.NoError(t, p.Aggregate("_e{5,4}:title|text", first))
+	require.NoError(t, p.Aggregate("_sc|my.check|0", first))
+	require.NoError(t, p.Aggregate("_sc|my.check|1", second))
+
+	counts := map[string]int{}
+	for _, batch := range p.GetLogs() {
+		counts[batch.Info.Addr.String()] = batch.Logs.LogRecordCount()
+	}
+	assert.Equal(t, map[string]int{first.String(): 2, second.String(): 1}, counts)
+
+	// Logs are only sent once.
+	assert.Empty(t, p.GetLogs())
+}
+
+func assertSingleLogRecord(t *testing.T, p *StatsDParser, addr net.Addr, expected plog.LogRecord) {
+	batches := p.GetLogs()
+	require.Len(t, batches, 1)
+	assert.Equal(t, addr, batches[0].Info.Addr)
+
+	logs := batches[0].Logs
+	require.Equal(t, 1, logs.LogRecordCount())
+	sl := logs.ResourceLogs().At(0).ScopeLogs().At(0)
+	assert.Equal(t, receiverName, sl.Scope().Name())
+
+	actual := sl.LogRecords().At(0)
+	assert.Equal(t, expected.Body().AsRaw(), actual.Body().AsRaw())
+	assert.Equal(t, expected.Timestamp(), actual.Timestamp())
+	assert.Equal(t, expected.ObservedTimestamp(), actual.ObservedTimestamp())
+	assert.Equal(t, expected.SeverityNumber(), actual.SeverityNumber())
+	assert.Equal(t, expected.SeverityText(), actual.SeverityText())
+	assert.Equal(t, expected.Attributes().AsRaw(), actual.Attributes().AsRaw())
+}
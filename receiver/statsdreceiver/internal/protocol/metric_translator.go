@@ -67,6 +67,18 @@ func buildGaugeMetric(parsedMetric statsDMetric, timeNow time.Time) pmetric.Scop
 	return ilm
 }
 
+func buildSetMetric(desc statsDMetricDescription, set setMetric, timeNow time.Time, ilm pmetric.ScopeMetrics) {
+	nm := ilm.Metrics().AppendEmpty()
+	nm.SetName(desc.name)
+	dp := nm.SetEmptyGauge().DataPoints().AppendEmpty()
+	// As with DogStatsD, a set is reported as the number of unique values seen during the interval.
+	dp.SetIntValue(int64(len(set)))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(timeNow))
+	for i := desc.attrs.Iter(); i.Next(); {
+		dp.Attributes().PutStr(string(i.Attribute().Key), i.Attribute().Value.AsString())
+	}
+}
+
 func buildSummaryMetric(desc statsDMetricDescription, summary summaryMetric, startTime, timeNow time.Time, percentiles []float64, ilm pmetric.ScopeMetrics) {
 	nm := ilm.Metrics().AppendEmpty()
 	nm.SetName(desc.name)
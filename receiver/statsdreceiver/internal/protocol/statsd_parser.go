@@ -39,6 +39,7 @@ const (
 	HistogramType    MetricType = "h"
 	TimingType       MetricType = "ms"
 	DistributionType MetricType = "d"
+	SetType          MetricType = "s"
 
 	CounterTypeName      TypeName = "counter"
 	GaugeTypeName        TypeName = "gauge"
@@ -46,6 +47,7 @@ const (
 	TimingTypeName       TypeName = "timing"
 	TimingAltTypeName    TypeName = "timer"
 	DistributionTypeName TypeName = "distribution"
+	SetTypeName          TypeName = "set"
 
 	GaugeObserver     ObserverType = "gauge"
 	SummaryObserver   ObserverType = "summary"
@@ -54,9 +56,18 @@ const (
 
 	DefaultObserverType = DisableObserver
 
+	// StatsDProtocol is the plain StatsD protocol, with the tags, container ID and
+	// timestamp extensions for metrics.
+	StatsDProtocol Protocol = "statsd"
+	// DogStatsDProtocol adds support for sets, events and service checks on top of StatsDProtocol.
+	DogStatsDProtocol Protocol = "dogstatsd"
+
 	receiverName = "otelcol/statsdreceiver"
 )
 
+// Protocol selects the flavor of StatsD the parser accepts.
+type Protocol string
+
 type TimerHistogramMapping struct {
 	StatsdType   TypeName        `mapstructure:"statsd_type"`
 	ObserverType ObserverType    `mapstructure:"observer_type"`
@@ -79,6 +90,7 @@ var defaultObserverCategory = ObserverCategory{
 // StatsDParser supports the Parse method for parsing StatsD messages with Tags.
 type StatsDParser struct {
 	instrumentsByAddress map[netAddr]*instruments
+	logsByAddress        map[netAddr]*logInstruments
 	enableMetricType     bool
 	enableSimpleTags     bool
 	isMonotonicCounter   bool
@@ -86,6 +98,7 @@ type StatsDParser struct {
 	histogramEvents      ObserverCategory
 	lastIntervalTime     time.Time
 	BuildInfo            component.BuildInfo
+	Protocol             Protocol
 }
 
 type instruments struct {
@@ -94,6 +107,7 @@ type instruments struct {
 	counters               map[statsDMetricDescription]pmetric.ScopeMetrics
 	summaries              map[statsDMetricDescription]summaryMetric
 	histograms             map[statsDMetricDescription]histogramMetric
+	sets                   map[statsDMetricDescription]setMetric
 	timersAndDistributions []pmetric.ScopeMetrics
 }
 
@@ -104,6 +118,7 @@ func newInstruments(addr net.Addr) *instruments {
 		counters:   make(map[statsDMetricDescription]pmetric.ScopeMetrics),
 		summaries:  make(map[statsDMetricDescription]summaryMetric),
 		histograms: make(map[statsDMetricDescription]histogramMetric),
+		sets:       make(map[statsDMetricDescription]setMetric),
 	}
 }
 
@@ -123,6 +138,9 @@ type histogramMetric struct {
 	agg *histogramStructure
 }
 
+// setMetric holds the unique values observed for a set during the interval.
+type setMetric map[string]struct{}
+
 type statsDMetric struct {
 	description statsDMetricDescription
 	asFloat     float64
@@ -130,6 +148,8 @@ type statsDMetric struct {
 	unit        string
 	sampleRate  float64
 	timestamp   uint64
+	// setValue is the raw value of a set member, which does not need to be numerical.
+	setValue string
 }
 
 type statsDMetricDescription struct {
@@ -150,6 +170,8 @@ func (t MetricType) FullName() TypeName {
 		return HistogramTypeName
 	case DistributionType:
 		return DistributionTypeName
+	case SetType:
+		return SetTypeName
 	}
 	return TypeName(fmt.Sprintf("unknown(%s)", t))
 }
@@ -161,6 +183,7 @@ func (p *StatsDParser) resetState(when time.Time) {
 
 func (p *StatsDParser) Initialize(enableMetricType bool, enableSimpleTags bool, isMonotonicCounter bool, sendTimerHistogram []TimerHistogramMapping) error {
 	p.resetState(timeNowFunc())
+	p.logsByAddress = make(map[netAddr]*logInstruments)
 
 	p.histogramEvents = defaultObserverCategory
 	p.timerEvents = defaultObserverCategory
@@ -229,6 +252,13 @@ func (p *StatsDParser) GetMetrics() []BatchMetrics {
 			)
 		}
 
+		for desc, setMetric := range instrument.sets {
+			ilm := rm.ScopeMetrics().AppendEmpty()
+			p.setVersionAndNameScope(ilm.Scope())
+
+			buildSetMetric(desc, setMetric, now, ilm)
+		}
+
 		for desc, histogramMetric := range instrument.histograms {
 			ilm := rm.ScopeMetrics().AppendEmpty()
 			p.setVersionAndNameScope(ilm.Scope())
@@ -267,17 +297,29 @@ func (p *StatsDParser) observerCategoryFor(t MetricType) ObserverCategory {
 		return p.histogramEvents
 	case TimingType:
 		return p.timerEvents
-	case CounterType, GaugeType:
+	case CounterType, GaugeType, SetType:
 	}
 	return defaultObserverCategory
 }
 
 // Aggregate for each metric line.
 func (p *StatsDParser) Aggregate(line string, addr net.Addr) error {
+	if p.Protocol == DogStatsDProtocol {
+		switch {
+		case strings.HasPrefix(line, eventPrefix):
+			return p.aggregateEvent(line, addr)
+		case strings.HasPrefix(line, serviceCheckPrefix):
+			return p.aggregateServiceCheck(line, addr)
+		}
+	}
+
 	parsedMetric, err := parseMessageToMetric(line, p.enableMetricType, p.enableSimpleTags)
 	if err != nil {
 		return err
 	}
+	if parsedMetric.description.metricType == SetType && p.Protocol != DogStatsDProtocol {
+		return fmt.Errorf("unsupported metric type: %s", SetType)
+	}
 
 	addrKey := newNetAddr(addr)
 	instrument, ok := p.instrumentsByAddress[addrKey]
@@ -348,6 +390,14 @@ func (p *StatsDParser) Aggregate(line string, addr net.Addr) error {
 		case DisableObserver:
 			// No action.
 		}
+
+	case SetType:
+		set, ok := instrument.sets[parsedMetric.description]
+		if !ok {
+			set = make(setMetric)
+			instrument.sets[parsedMetric.description] = set
+		}
+		set[parsedMetric.setValue] = struct{}{}
 	}
 
 	return nil
@@ -380,7 +430,7 @@ func parseMessageToMetric(line string, enableMetricType bool, enableSimpleTags b
 	var metricType, additionalParts, _ = strings.Cut(rest, "|")
 	inType := MetricType(metricType)
 	switch inType {
-	case CounterType, GaugeType, HistogramType, TimingType, DistributionType:
+	case CounterType, GaugeType, HistogramType, TimingType, DistributionType, SetType:
 		result.description.metricType = inType
 	default:
 		return result, fmt.Errorf("unsupported metric type: %s", inType)
@@ -402,30 +452,11 @@ func parseMessageToMetric(line string, enableMetricType bool, enableSimpleTags b
 
 			result.sampleRate = f
 		case strings.HasPrefix(part, "#"):
-			var tagsStr = strings.TrimPrefix(part, "#")
-
-			// handle an empty tag set
-			// where the tags part was still sent (some clients do this)
-			if len(tagsStr) == 0 {
-				continue
-			}
-
-			var tagSet string
-			tagSet, tagsStr, _ = strings.Cut(tagsStr, ",")
-			for ; len(tagSet) > 0; tagSet, tagsStr, _ = strings.Cut(tagsStr, ",") {
-				k, v, _ := strings.Cut(tagSet, ":")
-				if k == "" {
-					return result, fmt.Errorf("invalid tag format: %q", tagSet)
-				}
-
-				// support both simple tags (w/o value) and dimension tags (w/ value).
-				// dogstatsd notably allows simple tags.
-				if v == "" && !enableSimpleTags {
-					return result, fmt.Errorf("invalid tag format: %q", tagSet)
-				}
-
-				kvs = append(kvs, attribute.String(k, v))
+			tags, err := parseTags(strings.TrimPrefix(part, "#"), enableSimpleTags)
+			if err != nil {
+				return result, err
 			}
+			kvs = append(kvs, tags...)
 		case strings.HasPrefix(part, "c:"):
 			// As per DogStatD protocol v1.2:
 			// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/?tab=metrics#dogstatsd-protocol-v12
@@ -441,21 +472,25 @@ func parseMessageToMetric(line string, enableMetricType bool, enableSimpleTags b
 				return result, fmt.Errorf("only GAUGE and COUNT metrics support a timestamp")
 			}
 
-			timestampStr := strings.TrimPrefix(part, "T")
-			timestampSeconds, err := strconv.ParseUint(timestampStr, 10, 64)
+			timestamp, err := parseDogStatsDTimestamp(strings.TrimPrefix(part, "T"))
 			if err != nil {
-				return result, fmt.Errorf("invalid timestamp: %s", timestampStr)
+				return result, err
 			}
 
-			result.timestamp = timestampSeconds * 1e9 // Convert seconds to nanoseconds
+			result.timestamp = uint64(timestamp)
 		default:
 			return result, fmt.Errorf("unrecognized message part: %s", part)
 		}
 	}
-	var err error
-	result.asFloat, err = strconv.ParseFloat(valueStr, 64)
-	if err != nil {
-		return result, fmt.Errorf("parse metric value string: %s", valueStr)
+	if inType == SetType {
+		// set members are only counted, they can be any string
+		result.setValue = valueStr
+	} else {
+		var err error
+		result.asFloat, err = strconv.ParseFloat(valueStr, 64)
+		if err != nil {
+			return result, fmt.Errorf("parse metric value string: %s", valueStr)
+		}
 	}
 
 	// add metric_type dimension for all metrics
@@ -472,6 +507,31 @@ func parseMessageToMetric(line string, enableMetricType bool, enableSimpleTags b
 	return result, nil
 }
 
+// parseTags parses a comma separated list of tags, without the leading '#'.
+func parseTags(tagsStr string, enableSimpleTags bool) ([]attribute.KeyValue, error) {
+	var kvs []attribute.KeyValue
+
+	// handle an empty tag set
+	// where the tags part was still sent (some clients do this)
+	var tagSet string
+	tagSet, tagsStr, _ = strings.Cut(tagsStr, ",")
+	for ; len(tagSet) > 0; tagSet, tagsStr, _ = strings.Cut(tagsStr, ",") {
+		k, v, _ := strings.Cut(tagSet, ":")
+		if k == "" {
+			return nil, fmt.Errorf("invalid tag format: %q", tagSet)
+		}
+
+		// support both simple tags (w/o value) and dimension tags (w/ value).
+		// dogstatsd notably allows simple tags.
+		if v == "" && !enableSimpleTags {
+			return nil, fmt.Errorf("invalid tag format: %q", tagSet)
+		}
+
+		kvs = append(kvs, attribute.String(k, v))
+	}
+	return kvs, nil
+}
+
 type netAddr struct {
 	Network string
 	String  string
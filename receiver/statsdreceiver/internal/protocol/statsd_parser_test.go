@@ -90,6 +90,11 @@ func Test_ParseMessageToMetric(t *testing.T) {
 			input: "test.metric:42|unhandled_type",
 			err:   errors.New("unsupported metric type: unhandled_type"),
 		},
+		{
+			name:  "timestamp out of range",
+			input: "test.metric:42|c|T18446744074",
+			err:   errors.New("timestamp out of range: 18446744074"),
+		},
 		{
 			name:  "counter metric with sample rate and tag",
 			input: "test.metric:42|c|@0.1|#key:value",
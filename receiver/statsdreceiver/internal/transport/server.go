@@ -6,8 +6,6 @@ package transport // import "github.com/open-telemetry/opentelemetry-collector-c
 import (
 	"errors"
 	"net"
-
-	"go.opentelemetry.io/collector/consumer"
 )
 
 var errNilListenAndServeParameters = errors.New("no parameter of ListenAndServe can be nil")
@@ -17,9 +15,8 @@ var errNilListenAndServeParameters = errors.New("no parameter of ListenAndServe
 type Server interface {
 	// ListenAndServe is a blocking call that starts to listen for client messages
 	// on the specific transport, and prepares the message to be processed by
-	// the Parser and passed to the next consumer.
+	// the Parser and passed to the next consumers.
 	ListenAndServe(
-		mc consumer.Metrics,
 		r Reporter,
 		transferChan chan<- Metric,
 	) error
@@ -7,8 +7,6 @@ import (
 	"errors"
 	"fmt"
 	"net"
-
-	"go.opentelemetry.io/collector/consumer"
 )
 
 type udpServer struct {
@@ -38,11 +36,10 @@ func NewUDPServer(transport Transport, address string) (Server, error) {
 
 // ListenAndServe starts the server ready to receive metrics.
 func (u *udpServer) ListenAndServe(
-	nextConsumer consumer.Metrics,
 	reporter Reporter,
 	transferChan chan<- Metric,
 ) error {
-	if nextConsumer == nil || reporter == nil {
+	if reporter == nil {
 		return errNilListenAndServeParameters
 	}
 
@@ -24,27 +24,30 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/transport"
 )
 
-var _ receiver.Metrics = (*statsdReceiver)(nil)
+var (
+	_ receiver.Metrics = (*statsdReceiver)(nil)
+	_ receiver.Logs    = (*statsdReceiver)(nil)
+)
 
-// statsdReceiver implements the receiver.Metrics for StatsD protocol.
+// statsdReceiver implements the receiver.Metrics and receiver.Logs for StatsD protocol.
 type statsdReceiver struct {
 	settings receiver.Settings
 	config   *Config
 
-	server       transport.Server
-	reporter     *reporter
-	obsrecv      *receiverhelper.ObsReport
-	parser       protocol.Parser
-	nextConsumer consumer.Metrics
-	cancel       context.CancelFunc
+	server              transport.Server
+	reporter            *reporter
+	obsrecv             *receiverhelper.ObsReport
+	parser              protocol.Parser
+	nextMetricsConsumer consumer.Metrics
+	nextLogsConsumer    consumer.Logs
+	cancel              context.CancelFunc
 }
 
 // newReceiver creates the StatsD receiver with the given parameters.
 func newReceiver(
 	set receiver.Settings,
 	config Config,
-	nextConsumer consumer.Metrics,
-) (receiver.Metrics, error) {
+) (component.Component, error) {
 
 	if config.NetAddr.Endpoint == "" {
 		config.NetAddr.Endpoint = "localhost:8125"
@@ -67,13 +70,13 @@ func newReceiver(
 	}
 
 	r := &statsdReceiver{
-		settings:     set,
-		config:       &config,
-		nextConsumer: nextConsumer,
-		obsrecv:      obsrecv,
-		reporter:     rep,
+		settings: set,
+		config:   &config,
+		obsrecv:  obsrecv,
+		reporter: rep,
 		parser: &protocol.StatsDParser{
 			BuildInfo: set.BuildInfo,
+			Protocol:  config.Protocol,
 		},
 	}
 	return r, nil
@@ -112,7 +115,7 @@ func (r *statsdReceiver) Start(ctx context.Context, _ component.Host) error {
 		return err
 	}
 	go func() {
-		if err := r.server.ListenAndServe(r.nextConsumer, r.reporter, transferChan); err != nil {
+		if err := r.server.ListenAndServe(r.reporter, transferChan); err != nil {
 			if !errors.Is(err, net.ErrClosed) {
 				r.settings.TelemetrySettings.ReportStatus(component.NewFatalErrorEvent(err))
 			}
@@ -124,15 +127,30 @@ func (r *statsdReceiver) Start(ctx context.Context, _ component.Host) error {
 			select {
 			case <-ticker.C:
 				batchMetrics := r.parser.GetMetrics()
-				for _, batch := range batchMetrics {
-					batchCtx := client.NewContext(ctx, batch.Info)
-					numPoints := batch.Metrics.DataPointCount()
-					flushCtx := r.obsrecv.StartMetricsOp(batchCtx)
-					err := r.Flush(flushCtx, batch.Metrics, r.nextConsumer)
-					if err != nil {
-						r.reporter.OnDebugf("Error flushing metrics", zap.Error(err))
+				if r.nextMetricsConsumer != nil {
+					for _, batch := range batchMetrics {
+						batchCtx := client.NewContext(ctx, batch.Info)
+						numPoints := batch.Metrics.DataPointCount()
+						flushCtx := r.obsrecv.StartMetricsOp(batchCtx)
+						err := r.Flush(flushCtx, batch.Metrics, r.nextMetricsConsumer)
+						if err != nil {
+							r.reporter.OnDebugf("Error flushing metrics", zap.Error(err))
+						}
+						r.obsrecv.EndMetricsOp(flushCtx, metadata.Type.String(), numPoints, err)
+					}
+				}
+				batchLogs := r.parser.GetLogs()
+				if r.nextLogsConsumer != nil {
+					for _, batch := range batchLogs {
+						batchCtx := client.NewContext(ctx, batch.Info)
+						numRecords := batch.Logs.LogRecordCount()
+						flushCtx := r.obsrecv.StartLogsOp(batchCtx)
+						err := r.nextLogsConsumer.ConsumeLogs(flushCtx, batch.Logs)
+						if err != nil {
+							r.reporter.OnDebugf("Error flushing logs", zap.Error(err))
+						}
+						r.obsrecv.EndLogsOp(flushCtx, metadata.Type.String(), numRecords, err)
 					}
-					r.obsrecv.EndMetricsOp(flushCtx, metadata.Type.String(), numPoints, err)
 				}
 			case metric := <-transferChan:
 				err := r.parser.Aggregate(metric.Raw, metric.Addr)
@@ -13,7 +13,6 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confignet"
-	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver/receivertest"
@@ -24,8 +23,7 @@ import (
 
 func Test_statsdreceiver_Start(t *testing.T) {
 	type args struct {
-		config       Config
-		nextConsumer consumer.Metrics
+		config Config
 	}
 	tests := []struct {
 		name    string
@@ -41,14 +39,13 @@ func Test_statsdreceiver_Start(t *testing.T) {
 						Transport: "unknown",
 					},
 				},
-				nextConsumer: consumertest.NewNop(),
 			},
 			wantErr: errors.New("unsupported transport \"unknown\""),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			receiver, err := newReceiver(receivertest.NewNopSettings(), tt.args.config, tt.args.nextConsumer)
+			receiver, err := newReceiver(receivertest.NewNopSettings(), tt.args.config)
 			require.NoError(t, err)
 			err = receiver.Start(context.Background(), componenttest.NewNopHost())
 			assert.Equal(t, tt.wantErr, err)
@@ -61,8 +58,7 @@ func Test_statsdreceiver_Start(t *testing.T) {
 func TestStatsdReceiver_ShutdownBeforeStart(t *testing.T) {
 	ctx := context.Background()
 	cfg := createDefaultConfig().(*Config)
-	nextConsumer := consumertest.NewNop()
-	rcv, err := newReceiver(receivertest.NewNopSettings(), *cfg, nextConsumer)
+	rcv, err := newReceiver(receivertest.NewNopSettings(), *cfg)
 	assert.NoError(t, err)
 	r := rcv.(*statsdReceiver)
 	assert.NoError(t, r.Shutdown(ctx))
@@ -72,9 +68,10 @@ func TestStatsdReceiver_Flush(t *testing.T) {
 	ctx := context.Background()
 	cfg := createDefaultConfig().(*Config)
 	nextConsumer := consumertest.NewNop()
-	rcv, err := newReceiver(receivertest.NewNopSettings(), *cfg, nextConsumer)
+	rcv, err := newReceiver(receivertest.NewNopSettings(), *cfg)
 	assert.NoError(t, err)
 	r := rcv.(*statsdReceiver)
+	r.nextMetricsConsumer = nextConsumer
 	var metrics = pmetric.NewMetrics()
 	assert.Nil(t, r.Flush(ctx, metrics, nextConsumer))
 	assert.NoError(t, r.Start(ctx, componenttest.NewNopHost()))
@@ -112,9 +109,10 @@ func Test_statsdreceiver_EndToEnd(t *testing.T) {
 			cfg := tt.configFn()
 			cfg.NetAddr.Endpoint = tt.addr
 			sink := new(consumertest.MetricsSink)
-			rcv, err := newReceiver(receivertest.NewNopSettings(), *cfg, sink)
+			rcv, err := newReceiver(receivertest.NewNopSettings(), *cfg)
 			require.NoError(t, err)
 			r := rcv.(*statsdReceiver)
+			r.nextMetricsConsumer = sink
 
 			require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
 			defer func() {